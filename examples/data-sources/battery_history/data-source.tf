@@ -0,0 +1,8 @@
+data "pathfinder_battery_history" "example" {
+  limit = 24
+  since = "2024-01-02T00:00:00Z"
+}
+
+output "battery_values" {
+  value = data.pathfinder_battery_history.example.readings[*].value
+}
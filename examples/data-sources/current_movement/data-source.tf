@@ -0,0 +1,5 @@
+data "pathfinder_current_movement" "example" {}
+
+output "current_movement" {
+  value = data.pathfinder_current_movement.example.moving ? "executing ${data.pathfinder_current_movement.example.name}" : "idle"
+}
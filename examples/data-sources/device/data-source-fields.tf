@@ -0,0 +1,3 @@
+data "pathfinder_device" "example" {
+  fields = ["name", "uptime"]
+}
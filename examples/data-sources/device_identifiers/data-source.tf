@@ -0,0 +1,11 @@
+data "pathfinder_device_identifiers" "example" {}
+
+resource "pathfinder_movement" "example" {
+  name = "patrol-${data.pathfinder_device_identifiers.example.short}"
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 1
+  }
+}
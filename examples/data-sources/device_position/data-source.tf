@@ -0,0 +1,16 @@
+data "pathfinder_device_position" "example" {}
+
+data "pathfinder_movement_preview" "example" {
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 2
+  }
+}
+
+output "position_error" {
+  value = {
+    x = data.pathfinder_device_position.example.x - data.pathfinder_movement_preview.example.final_x
+    y = data.pathfinder_device_position.example.y - data.pathfinder_movement_preview.example.final_y
+  }
+}
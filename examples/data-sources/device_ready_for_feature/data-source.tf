@@ -0,0 +1,20 @@
+data "pathfinder_device_ready_for_feature" "lidar" {
+  feature = "lidar"
+}
+
+resource "pathfinder_movement" "survey" {
+  name = "survey"
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 2
+  }
+
+  lifecycle {
+    precondition {
+      condition     = data.pathfinder_device_ready_for_feature.lidar.ready
+      error_message = "The lidar feature of the device is not usable."
+    }
+  }
+}
@@ -0,0 +1,5 @@
+data "pathfinder_device_status" "example" {}
+
+output "device_identifiers" {
+  value = data.pathfinder_device_status.example.identifiers
+}
@@ -0,0 +1,5 @@
+data "pathfinder_device_time" "example" {}
+
+output "clock_skew_seconds" {
+  value = data.pathfinder_device_time.example.skew_seconds
+}
@@ -0,0 +1,8 @@
+data "pathfinder_feature_catalog" "example" {}
+
+output "experimental_features" {
+  value = [
+    for feature in data.pathfinder_feature_catalog.example.features : feature.key
+    if feature.experimental == true
+  ]
+}
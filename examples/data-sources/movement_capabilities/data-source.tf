@@ -0,0 +1,5 @@
+data "pathfinder_movement_capabilities" "example" {}
+
+output "supports_backward" {
+  value = contains(data.pathfinder_movement_capabilities.example.directions, "backward")
+}
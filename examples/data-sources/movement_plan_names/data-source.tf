@@ -0,0 +1,5 @@
+data "pathfinder_movement_plan_names" "example" {}
+
+output "movement_plans" {
+  value = toset(data.pathfinder_movement_plan_names.example.names)
+}
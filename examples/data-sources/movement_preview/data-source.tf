@@ -0,0 +1,26 @@
+data "pathfinder_movement_preview" "example" {
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 2
+  }
+
+  steps {
+    angle     = 90
+    direction = "right"
+  }
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 1
+  }
+}
+
+output "final_position" {
+  value = {
+    x       = data.pathfinder_movement_preview.example.final_x
+    y       = data.pathfinder_movement_preview.example.final_y
+    heading = data.pathfinder_movement_preview.example.final_heading
+  }
+}
@@ -0,0 +1,7 @@
+data "pathfinder_ping" "example" {
+  timeout = "2s"
+}
+
+output "latency_ms" {
+  value = data.pathfinder_ping.example.latency_ms
+}
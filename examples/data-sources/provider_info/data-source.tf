@@ -0,0 +1,5 @@
+data "pathfinder_provider_info" "example" {}
+
+output "pathfinder_address" {
+  value = data.pathfinder_provider_info.example.address
+}
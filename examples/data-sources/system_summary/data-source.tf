@@ -0,0 +1,5 @@
+data "pathfinder_system_summary" "example" {}
+
+output "system_summary" {
+  value = data.pathfinder_system_summary.example
+}
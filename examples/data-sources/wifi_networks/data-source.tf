@@ -1,5 +1,11 @@
-data "pathfinder_wifi_networks" "example" {}
+data "pathfinder_wifi_networks" "example" {
+  sort_by_rssi = true
+}
 
 output "wifi_networks" {
   value = data.pathfinder_wifi_networks.example.networks
 }
+
+output "strongest_wifi_network" {
+  value = try(data.pathfinder_wifi_networks.example.networks[0].ssid, null)
+}
@@ -0,0 +1,20 @@
+data "pathfinder_wifi_reachable" "example" {
+  ssid = "workshop"
+}
+
+resource "pathfinder_movement" "example" {
+  name = "return-to-workshop"
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 5
+  }
+
+  lifecycle {
+    precondition {
+      condition     = data.pathfinder_wifi_reachable.example.reachable
+      error_message = "The workshop WiFi network is not in range of the device."
+    }
+  }
+}
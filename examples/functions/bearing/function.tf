@@ -0,0 +1,18 @@
+locals {
+  from = { x = 0, y = 0 }
+  to   = { x = 3, y = 4 }
+
+  # The device only accepts whole angles between 0 and 359.
+  heading = floor(provider::pathfinder::bearing(local.from.x, local.from.y, local.to.x, local.to.y) + 0.5) % 360
+}
+
+resource "pathfinder_movement" "example" {
+  name            = "example"
+  coordinate_mode = "absolute"
+
+  steps {
+    angle     = local.heading
+    direction = "forward"
+    distance  = provider::pathfinder::distance_between(local.from.x, local.from.y, local.to.x, local.to.y)
+  }
+}
@@ -0,0 +1,20 @@
+resource "pathfinder_movement" "example" {
+  name = "example"
+
+  dynamic "steps" {
+    for_each = provider::pathfinder::clamp_plan(
+      provider::pathfinder::parse_path("F5;R270;F1.5"),
+      {
+        max_distance = 2
+        max_angle    = 180
+        max_steps    = null
+      },
+    )
+
+    content {
+      angle     = steps.value.angle
+      direction = steps.value.direction
+      distance  = steps.value.distance
+    }
+  }
+}
@@ -0,0 +1,20 @@
+variable "patrol_plan" {
+  type        = string
+  description = "Movement plan encoded with the encode_plan function."
+}
+
+resource "pathfinder_movement" "example" {
+  name = "patrol"
+
+  dynamic "steps" {
+    for_each = provider::pathfinder::decode_plan(var.patrol_plan)
+
+    content {
+      angle     = steps.value.angle
+      direction = steps.value.direction
+      distance  = steps.value.distance
+      speed     = steps.value.speed
+      waypoint  = steps.value.waypoint
+    }
+  }
+}
@@ -0,0 +1,11 @@
+locals {
+  address = "https://rover.local"
+}
+
+provider "pathfinder" {
+  address = local.address
+}
+
+output "status_url" {
+  value = provider::pathfinder::device_url(local.address, "/v1/device/status")
+}
@@ -0,0 +1,16 @@
+variable "waypoints" {
+  type    = list(object({ x = number, y = number }))
+  default = [
+    { x = 0, y = 0 },
+    { x = 3, y = 4 },
+    { x = 3, y = 10 },
+  ]
+}
+
+output "path_length" {
+  value = sum([
+    for i in range(1, length(var.waypoints)) : provider::pathfinder::distance_between(
+      var.waypoints[i - 1].x, var.waypoints[i - 1].y, var.waypoints[i].x, var.waypoints[i].y,
+    )
+  ])
+}
@@ -0,0 +1,3 @@
+output "patrol_plan" {
+  value = provider::pathfinder::encode_plan(provider::pathfinder::parse_path("F1.5;R90;F2"))
+}
@@ -0,0 +1,24 @@
+variable "path" {
+  type = list(object({
+    direction = string
+    distance  = number
+  }))
+  default = [
+    { direction = "FWD", distance = 2 },
+    { direction = "Back", distance = 1 },
+  ]
+}
+
+resource "pathfinder_movement" "example" {
+  name = "example"
+
+  dynamic "steps" {
+    for_each = var.path
+
+    content {
+      angle     = 0
+      direction = provider::pathfinder::normalize_direction(steps.value.direction)
+      distance  = steps.value.distance
+    }
+  }
+}
@@ -0,0 +1,13 @@
+resource "pathfinder_movement" "example" {
+  name = "example"
+
+  dynamic "steps" {
+    for_each = provider::pathfinder::parse_path("F1.5;R90;F2")
+
+    content {
+      angle     = steps.value.angle
+      direction = steps.value.direction
+      distance  = steps.value.distance
+    }
+  }
+}
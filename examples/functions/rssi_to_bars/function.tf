@@ -0,0 +1,10 @@
+data "pathfinder_wifi_networks" "example" {}
+
+output "wifi_bars" {
+  value = [
+    for network in data.pathfinder_wifi_networks.example.networks : {
+      ssid = network.ssid
+      bars = provider::pathfinder::rssi_to_bars(network.rssi)
+    }
+  ]
+}
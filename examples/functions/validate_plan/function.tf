@@ -0,0 +1,24 @@
+locals {
+  steps = provider::pathfinder::parse_path(var.path)
+}
+
+resource "pathfinder_movement" "example" {
+  name = "example"
+
+  dynamic "steps" {
+    for_each = local.steps
+
+    content {
+      angle     = steps.value.angle
+      direction = steps.value.direction
+      distance  = steps.value.distance
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = length(provider::pathfinder::validate_plan(local.steps)) == 0
+      error_message = join("\n", provider::pathfinder::validate_plan(local.steps))
+    }
+  }
+}
@@ -0,0 +1,22 @@
+resource "pathfinder_broadcast_movement" "example" {
+  name = "patrol"
+
+  addresses = [
+    "https://rover-1.local",
+    "https://rover-2.local",
+    "https://rover-3.local",
+  ]
+
+  concurrency = 2
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 2
+  }
+
+  steps {
+    angle     = 90
+    direction = "left"
+  }
+}
@@ -0,0 +1,4 @@
+resource "pathfinder_device_feature" "example" {
+  name    = "obstacle_avoidance"
+  enabled = true
+}
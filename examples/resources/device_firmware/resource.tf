@@ -0,0 +1,7 @@
+resource "pathfinder_device_firmware" "example" {
+  version = "2.1.0"
+  confirm = true
+
+  wait_for_completion = true
+  wait_timeout        = "20m"
+}
@@ -0,0 +1,17 @@
+resource "pathfinder_device_reboot" "example" {
+  wait_for_online = true
+  wait_timeout    = "2m"
+}
+
+# Submitted once the device is back online after rebooting.
+resource "pathfinder_movement" "example" {
+  name = "example"
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 1
+  }
+
+  depends_on = [pathfinder_device_reboot.example]
+}
@@ -0,0 +1,4 @@
+resource "pathfinder_device_reset" "example" {
+  confirm           = true
+  device_identifier = "0a1b2c"
+}
@@ -9,12 +9,10 @@ resource "pathfinder_movement" "example" {
   steps {
     angle     = 90
     direction = "right"
-    distance  = 1
   }
 
   steps {
     angle     = 90
     direction = "right"
-    distance  = 1
   }
 }
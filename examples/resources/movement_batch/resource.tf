@@ -0,0 +1,18 @@
+resource "pathfinder_movement_batch" "example" {
+  plans {
+    name = "approach"
+    steps {
+      angle     = 0
+      direction = "forward"
+      distance  = 2
+    }
+  }
+
+  plans {
+    name = "turn"
+    steps {
+      angle     = 90
+      direction = "left"
+    }
+  }
+}
@@ -0,0 +1,3 @@
+resource "pathfinder_movement_lock" "example" {
+  ttl = "30m"
+}
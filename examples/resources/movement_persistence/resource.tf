@@ -0,0 +1,15 @@
+resource "pathfinder_movement" "patrol" {
+  name    = "patrol"
+  persist = false
+
+  steps {
+    angle     = 0
+    direction = "forward"
+    distance  = 2
+  }
+}
+
+resource "pathfinder_movement_persistence" "patrol" {
+  name      = pathfinder_movement.patrol.name
+  persisted = true
+}
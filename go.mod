@@ -7,6 +7,8 @@ require (
 	github.com/hashicorp/terraform-plugin-framework-validators v0.13.0
 	github.com/hashicorp/terraform-plugin-go v0.23.0
 	github.com/hashicorp/terraform-plugin-log v0.9.0
+	golang.org/x/sync v0.7.0
+	golang.org/x/time v0.5.0
 )
 
 require (
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the default number of consecutive
+	// failed requests to a host after which the circuit breaker opens.
+	DefaultCircuitBreakerThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is the default time the circuit breaker
+	// stays open before requests are sent to the host again.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// CircuitOpenError is returned without sending the request when the previous
+// requests to the host failed, so that a device that is down is not sent
+// requests by every resource and data source.
+type CircuitOpenError struct {
+	Host     string
+	Failures int
+	Until    time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("device appears down: the last %d requests to %s failed, so requests are not sent until %s",
+		e.Failures, e.Host, e.Until.Format(time.RFC3339))
+}
+
+// circuitBreaker counts consecutive failed requests to each host, and refuses
+// requests to a host for a cooldown after threshold consecutive failures.
+//
+// Once the cooldown has passed, requests are sent again, and the first
+// failure opens the circuit breaker again until a request succeeds.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*circuitState
+}
+
+type circuitState struct {
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		hosts:     map[string]*circuitState{},
+	}
+}
+
+// allow returns a CircuitOpenError if requests to the host are refused.
+func (b *circuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.hosts[host]
+	if !ok || !time.Now().Before(state.openUntil) {
+		return nil
+	}
+
+	return &CircuitOpenError{Host: host, Failures: state.failures, Until: state.openUntil}
+}
+
+// record counts the outcome of a request to the host.
+func (b *circuitBreaker) record(host string, httpResp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isHostFailure(httpResp, err) {
+		delete(b.hosts, host)
+		return
+	}
+
+	state, ok := b.hosts[host]
+	if !ok {
+		state = &circuitState{}
+		b.hosts[host] = state
+	}
+
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// isHostFailure returns true if the request failed because the host is
+// unreachable or unavailable, rather than because of the request itself.
+func isHostFailure(httpResp *http.Response, err error) bool {
+	if err != nil {
+		// Cancelled requests say nothing about the host.
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch httpResp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_circuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cooldown := 50 * time.Millisecond
+	client, err := NewClient(ClientConfig{
+		Address:                 server.URL,
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  cooldown,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	ctx := context.Background()
+
+	// The breaker opens after 2 consecutive failures.
+	for i := 0; i < 2; i++ {
+		var statusErr *StatusError
+		if err := client.GetJSON(ctx, "/v1/healthz", nil); !errors.As(err, &statusErr) {
+			t.Fatalf("expected status error, got: %v", err)
+		}
+	}
+
+	var circuitErr *CircuitOpenError
+	if err := client.GetJSON(ctx, "/v1/healthz", nil); !errors.As(err, &circuitErr) {
+		t.Fatalf("expected circuit open error, got: %v", err)
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("expected the open breaker to refuse the request, got %d requests", requests.Load())
+	}
+
+	// Requests are retried until the final attempt, which is refused.
+	err = client.SendJSON(ctx, Request{Method: http.MethodGet, Path: "/v1/healthz", Retry: true}, nil)
+	if !errors.As(err, &circuitErr) {
+		t.Fatalf("expected circuit open error, got: %v", err)
+	}
+
+	// Once the cooldown has passed, a successful request closes the breaker.
+	down.Store(false)
+	time.Sleep(cooldown)
+
+	if err := client.GetJSON(ctx, "/v1/healthz", nil); err != nil {
+		t.Fatalf("unexpected error after cooldown: %s", err)
+	}
+
+	down.Store(true)
+
+	var statusErr *StatusError
+	if err := client.GetJSON(ctx, "/v1/healthz", nil); !errors.As(err, &statusErr) {
+		t.Fatalf("expected closed breaker to send the request, got: %v", err)
+	}
+
+	if requests.Load() != 4 {
+		t.Errorf("expected 4 requests, got %d", requests.Load())
+	}
+}
+
+func TestClient_circuitBreakerDisabled(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, CircuitBreakerThreshold: -1})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	for i := 0; i < DefaultCircuitBreakerThreshold+1; i++ {
+		_ = client.GetJSON(context.Background(), "/v1/healthz", nil)
+	}
+
+	if requests.Load() != DefaultCircuitBreakerThreshold+1 {
+		t.Errorf("expected every request to be sent, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+)
+
+// MovementCapabilitiesPath is the path of the endpoint reporting the
+// movements supported by the device.
+const MovementCapabilitiesPath = "/v1/movement/capabilities"
+
+// MovementCapabilities returns the movements supported by the device. When
+// the client is configured with CacheCapabilities, the first successful
+// response is kept and returned by later calls without a request, until
+// InvalidateCapabilities is called. Failed requests are not cached.
+func (c *Client) MovementCapabilities(ctx context.Context) (model.MovementCapabilitiesResponse, error) {
+	if !c.Config.CacheCapabilities {
+		var readResp model.MovementCapabilitiesResponse
+		err := c.GetJSON(ctx, MovementCapabilitiesPath, &readResp)
+
+		return readResp, err
+	}
+
+	// The lock is held during the request, so that resources reading the
+	// capabilities concurrently share a single request.
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.capabilities != nil {
+		return *c.capabilities, nil
+	}
+
+	var readResp model.MovementCapabilitiesResponse
+	if err := c.GetJSON(ctx, MovementCapabilitiesPath, &readResp); err != nil {
+		return readResp, err
+	}
+
+	c.capabilities = &readResp
+
+	return readResp, nil
+}
+
+// InvalidateCapabilities discards the cached capabilities of the device, so
+// that the next MovementCapabilities call reads them again.
+func (c *Client) InvalidateCapabilities() {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	c.capabilities = nil
+}
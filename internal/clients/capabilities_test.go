@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_MovementCapabilities(t *testing.T) {
+	testCases := map[string]struct {
+		cache      bool
+		invalidate bool
+		expected   int64
+	}{
+		"uncached": {
+			expected: 3,
+		},
+		"cached": {
+			cache:    true,
+			expected: 1,
+		},
+		"invalidated": {
+			cache:      true,
+			invalidate: true,
+			expected:   2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int64
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				_, _ = w.Write([]byte(`{"directions":["forward"],"max_distance":20}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL, CacheCapabilities: testCase.cache})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for i := 0; i < 3; i++ {
+				if testCase.invalidate && i == 2 {
+					client.InvalidateCapabilities()
+				}
+
+				capabilities, err := client.MovementCapabilities(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if capabilities.MaxDistance != 20 {
+					t.Errorf("expected max distance 20, got %g", capabilities.MaxDistance)
+				}
+			}
+
+			if got := requests.Load(); got != testCase.expected {
+				t.Errorf("expected %d requests, got %d", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_MovementCapabilities_errorNotCached(t *testing.T) {
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"directions":["forward"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, CacheCapabilities: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.MovementCapabilities(context.Background()); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	if _, err := client.MovementCapabilities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.MovementCapabilities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
@@ -4,27 +4,552 @@
 package clients
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"golang.org/x/time/rate"
 )
 
+// Doer sends HTTP requests and returns HTTP responses. It is satisfied by
+// *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 // Client is an HCP client capable of making requests on behalf of a service principal.
+//
+// HttpClient is used for every request sent by the resources and data sources,
+// and can be replaced with another Doer to intercept requests, for example with
+// a stub returning canned responses in tests.
 type Client struct {
 	Config     ClientConfig
-	HttpClient *http.Client
+	HttpClient Doer
+
+	// semaphore bounds the number of concurrent HttpClient.Do calls when
+	// Config.MaxConcurrentRequests is set.
+	semaphore chan struct{}
+
+	// limiter paces requests when Config.RequestsPerSecond is set.
+	limiter *rate.Limiter
+
+	// breaker refuses requests to a host that appears down, unless disabled
+	// by a negative Config.CircuitBreakerThreshold.
+	breaker *circuitBreaker
+
+	// metrics counts requests and pushes them to Config.MetricsEndpoint,
+	// when set.
+	metrics *metricsRecorder
+
+	// capabilities caches the movement capabilities of the device when
+	// Config.CacheCapabilities is set, guarded by capabilitiesMu.
+	capabilitiesMu sync.Mutex
+	capabilities   *model.MovementCapabilitiesResponse
+
+	// devices contains the clients returned by ForAddress, keyed by
+	// address, guarded by devicesMu.
+	devicesMu sync.Mutex
+	devices   map[string]*Client
 }
 
 // ClientConfig specifies configuration for the client that interacts with the Pathfinder API.
 type ClientConfig struct {
 	Address string
 	ApiKey  string
+
+	// AuthScheme selects how requests are authenticated, either
+	// AuthSchemeAPIKey, sending ApiKey in the x-api-key header, or
+	// AuthSchemeBearer, sending AuthToken in the Authorization header.
+	// Defaults to AuthSchemeAPIKey when empty.
+	AuthScheme string
+	AuthToken  string
+
+	// RetryMax is the maximum number of times a request failing with a
+	// transient error is retried. Defaults to DefaultRetryMax when zero,
+	// and disables retries when negative.
+	RetryMax int
+
+	// RetryWaitMin is the wait before the first retry. Defaults to
+	// DefaultRetryWaitMin when zero.
+	RetryWaitMin time.Duration
+
+	// RetryOnStatus are the status codes of responses that are retried, in
+	// addition to connection errors. Must be between 400 and 599. Defaults
+	// to DefaultRetryOnStatus when nil, and only connection errors are
+	// retried when empty.
+	RetryOnStatus []int
+
+	// PollInterval is the interval between polls while waiting for the
+	// device to reach a state. Defaults to DefaultPollInterval when zero.
+	PollInterval time.Duration
+
+	// MaxResponseBytes is the maximum size of a response body, reading
+	// beyond it fails with a ResponseTooLargeError. Defaults to
+	// DefaultMaxResponseBytes when zero.
+	MaxResponseBytes int64
+
+	// ReadOnly refuses to send requests that could change the state of the
+	// device, failing them with ErrReadOnly instead.
+	ReadOnly bool
+
+	// RecordOnly records and logs requests using a RecordingDoer instead of
+	// sending them, for trying out configurations without a device.
+	RecordOnly bool
+
+	// StrictDecode fails decoding responses with fields that are not part of
+	// the model they are decoded into, rather than ignoring them.
+	StrictDecode bool
+
+	// HTTPProxy and HTTPSProxy are the URLs of the proxies used for requests
+	// to http:// and https:// addresses. The proxy is taken from the
+	// environment, as with http.ProxyFromEnvironment, when empty.
+	HTTPProxy  string
+	HTTPSProxy string
+
+	// MaxConcurrentRequests is the maximum number of requests in flight at
+	// once, further requests wait until an earlier request completes. The
+	// number of requests is not limited when zero.
+	MaxConcurrentRequests int
+
+	// RequestsPerSecond is the maximum rate at which requests are sent,
+	// further requests wait until they can be sent without exceeding it. The
+	// rate is not limited when zero.
+	RequestsPerSecond float64
+
+	// CircuitBreakerThreshold is the number of consecutive failed requests to
+	// a host, such as connection errors or 503 Service Unavailable responses,
+	// after which further requests fail immediately with a CircuitOpenError
+	// for CircuitBreakerCooldown. Defaults to DefaultCircuitBreakerThreshold
+	// when zero, and disables the circuit breaker when negative.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is the time requests to a host that appears down
+	// are refused. Defaults to DefaultCircuitBreakerCooldown when zero.
+	CircuitBreakerCooldown time.Duration
+
+	// CacheCapabilities keeps the movement capabilities of the device after
+	// they are first read, so that resources and data sources share a single
+	// request. See Client.MovementCapabilities.
+	CacheCapabilities bool
+
+	// DebugHTTPBody indents the JSON bodies of requests in logs for
+	// readability. The bodies sent to the device are not changed.
+	DebugHTTPBody bool
+
+	// MethodOverride sends every request that is not a POST request as a POST
+	// request, with the X-HTTP-Method-Override header set to the method of
+	// the request, for proxies that block other methods.
+	MethodOverride bool
+
+	// Headers are added to every request, such as the custom authentication
+	// headers of a gateway in front of the device. The headers set by the
+	// client, such as x-api-key, take precedence.
+	Headers map[string]string
+
+	// SensitiveHeaders are the names of request headers whose values are
+	// redacted in logs, in addition to DefaultSensitiveHeaders.
+	SensitiveHeaders []string
+
+	// MovementPath is the path of the movement plan endpoint, for devices
+	// running customized firmware. Defaults to DefaultMovementPath when
+	// empty.
+	MovementPath string
+
+	// MetricsEndpoint is the URL of a Prometheus Pushgateway, such as
+	// http://pushgateway.example.com:9091, that request metrics are pushed
+	// to in the background after requests, grouped by the Address as the
+	// instance. Metrics are not recorded when empty.
+	MetricsEndpoint string
+
+	// DefaultPersist is the value of persist for movement resources that do
+	// not set it. The resource default is used when nil.
+	DefaultPersist *bool
+
+	// SafeMode clamps the distance and speed of every movement step to
+	// conservative maxima before it is sent, regardless of the configuration
+	// of the movement resources.
+	SafeMode bool
+}
+
+// Authentication schemes of ClientConfig.AuthScheme.
+const (
+	AuthSchemeAPIKey = "api_key"
+	AuthSchemeBearer = "bearer"
+)
+
+// Validate returns an error describing every invalid value of the
+// configuration, or nil if it is valid. Zero values are valid, as NewClient
+// replaces them with defaults.
+func (c ClientConfig) Validate() error {
+	var errs []error
+
+	if c.Address == "" {
+		errs = append(errs, errors.New("address must not be empty"))
+	} else if u, err := url.Parse(c.Address); err != nil {
+		errs = append(errs, fmt.Errorf("invalid address %q: %w", c.Address, err))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid address %q, must be an http:// or https:// URL such as https://rover.example.com", c.Address))
+	}
+
+	switch c.AuthScheme {
+	case "", AuthSchemeAPIKey:
+		if c.AuthToken != "" {
+			errs = append(errs, errors.New("auth token is only sent with the bearer auth scheme"))
+		}
+	case AuthSchemeBearer:
+		if c.AuthToken == "" {
+			errs = append(errs, errors.New("auth token must not be empty with the bearer auth scheme"))
+		}
+
+		if c.ApiKey != "" {
+			errs = append(errs, errors.New("API key is only sent with the api_key auth scheme"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported auth scheme %q, must be %q or %q", c.AuthScheme, AuthSchemeAPIKey, AuthSchemeBearer))
+	}
+
+	if c.RetryWaitMin < 0 {
+		errs = append(errs, fmt.Errorf("retry wait must not be negative, got %s", c.RetryWaitMin))
+	}
+
+	for _, status := range c.RetryOnStatus {
+		if status < 400 || status > 599 {
+			errs = append(errs, fmt.Errorf("retry status code %d must be between 400 and 599", status))
+		}
+	}
+
+	if c.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval must not be negative, got %s", c.PollInterval))
+	}
+
+	if c.CircuitBreakerCooldown < 0 {
+		errs = append(errs, fmt.Errorf("circuit breaker cooldown must not be negative, got %s", c.CircuitBreakerCooldown))
+	}
+
+	if c.MaxResponseBytes < 0 {
+		errs = append(errs, fmt.Errorf("maximum response size must not be negative, got %d", c.MaxResponseBytes))
+	}
+
+	if c.MaxConcurrentRequests < 0 {
+		errs = append(errs, fmt.Errorf("maximum concurrent requests must not be negative, got %d", c.MaxConcurrentRequests))
+	}
+
+	if c.RequestsPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("requests per second must not be negative, got %g", c.RequestsPerSecond))
+	}
+
+	if c.MovementPath != "" && !strings.HasPrefix(c.MovementPath, "/") {
+		errs = append(errs, fmt.Errorf("movement path %q must start with /", c.MovementPath))
+	}
+
+	if c.MetricsEndpoint != "" {
+		if u, err := url.Parse(c.MetricsEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid metrics endpoint %q, must be an http:// or https:// URL such as http://pushgateway.example.com:9091", c.MetricsEndpoint))
+		}
+	}
+
+	for scheme, rawURL := range map[string]string{"http": c.HTTPProxy, "https": c.HTTPSProxy} {
+		if rawURL == "" {
+			continue
+		}
+
+		if _, err := parseProxyURL(scheme, rawURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // NewClient creates a new Client that is capable of making Pathfinder API requests.
+//
+// An error is returned if the configuration is not valid.
 func NewClient(config ClientConfig) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if config.RetryMax == 0 {
+		config.RetryMax = DefaultRetryMax
+	}
+
+	if config.RetryWaitMin == 0 {
+		config.RetryWaitMin = DefaultRetryWaitMin
+	}
+
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	if config.MaxResponseBytes == 0 {
+		config.MaxResponseBytes = DefaultMaxResponseBytes
+	}
+
+	if config.CircuitBreakerThreshold == 0 {
+		config.CircuitBreakerThreshold = DefaultCircuitBreakerThreshold
+	}
+
+	if config.CircuitBreakerCooldown == 0 {
+		config.CircuitBreakerCooldown = DefaultCircuitBreakerCooldown
+	}
+
+	proxy, err := proxyFunc(config.HTTPProxy, config.HTTPSProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Config: config,
+		HttpClient: &http.Client{
+			Transport: &limitTransport{
+				base:     newTransport(proxy),
+				maxBytes: config.MaxResponseBytes,
+			},
+			CheckRedirect: checkRedirect,
+		},
+	}
+
+	if config.RecordOnly {
+		client.HttpClient = &RecordingDoer{IndentBody: config.DebugHTTPBody}
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		client.semaphore = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	// A burst of one spaces the requests evenly, rather than allowing a
+	// burst of requests after a quiet period.
+	if config.RequestsPerSecond > 0 {
+		client.limiter = rate.NewLimiter(rate.Limit(config.RequestsPerSecond), 1)
+	}
+
+	if config.CircuitBreakerThreshold > 0 {
+		client.breaker = newCircuitBreaker(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown)
+	}
+
+	if config.MetricsEndpoint != "" {
+		client.metrics = newMetricsRecorder(config.MetricsEndpoint, config.Address)
+	}
+
+	return client, nil
+}
+
+// newTransport returns a transport with its own connection pool, mirroring the
+// settings of http.DefaultTransport, so idle connections to the device are
+// kept alive and reused across requests made by the same client.
+func newTransport(proxy func(*http.Request) (*url.URL, error)) *http.Transport {
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// proxyFunc returns the proxy function of the transport, which sends requests
+// to http:// and https:// addresses through httpProxy and httpsProxy. The proxy
+// is taken from the environment for schemes without a proxy URL.
+func proxyFunc(httpProxy, httpsProxy string) (func(*http.Request) (*url.URL, error), error) {
+	proxies := map[string]*url.URL{}
+
+	for scheme, rawURL := range map[string]string{"http": httpProxy, "https": httpsProxy} {
+		if rawURL == "" {
+			continue
+		}
+
+		proxyURL, err := parseProxyURL(scheme, rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		proxies[scheme] = proxyURL
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if proxyURL, ok := proxies[req.URL.Scheme]; ok {
+			return proxyURL, nil
+		}
+
+		return http.ProxyFromEnvironment(req)
+	}, nil
+}
+
+// parseProxyURL parses the URL of the proxy for the scheme, which must be an
+// absolute URL.
+func parseProxyURL(scheme, rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid %s proxy URL %q, must be an absolute URL such as http://proxy.example.com:3128", scheme, rawURL)
+	}
+
+	return proxyURL, nil
+}
+
+// maxRedirects is the maximum number of redirects followed for a request.
+const maxRedirects = 10
+
+// checkRedirect only follows redirects to the host of the original request,
+// such as an upgrade from http:// to https://. Headers of the original
+// request, including the API key, are preserved on these redirects.
+//
+// Redirects to other hosts are refused rather than sending the API key to
+// them, or silently sending the request without it.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if original := via[0].URL; req.URL.Hostname() != original.Hostname() {
+		return fmt.Errorf("refusing to follow redirect from %s to different host %s, "+
+			"update the provider address to the new host instead", original.Hostname(), req.URL.Hostname())
+	}
+
+	return nil
+}
+
+// DefaultMovementPath is the default path of the movement plan endpoint.
+const DefaultMovementPath = "/v1/movement-plan"
+
+// MovementPath returns the path of the movement plan endpoint.
+func (c *Client) MovementPath() string {
+	if c.Config.MovementPath == "" {
+		return DefaultMovementPath
+	}
+
+	return c.Config.MovementPath
+}
+
+// Prewarm establishes a connection to the Pathfinder API ahead of the first
+// request by sending a HEAD request to the readiness endpoint. The connection
+// is left idle in the pool for subsequent requests to reuse.
+func (c *Client) Prewarm(ctx context.Context) error {
+	reqURL, err := JoinURL(c.Config.Address, "/v1/readyz")
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodHead, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpResp, err := c.send(httpReq)
+	if err != nil {
+		return err
+	}
+
+	return drainAndClose(httpResp.Body)
+}
+
+// send sends the request using HttpClient, first waiting until it can be sent
+// within Config.RequestsPerSecond and for one of the
+// Config.MaxConcurrentRequests slots to be free, or for the context of the
+// request to be done.
+//
+// A CircuitOpenError is returned without sending the request when the host
+// appears down. The request is counted in the metrics of the client, if
+// enabled, once the response headers are received. The metrics are pushed in
+// the background, so that the push does not delay the request.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.breaker != nil {
+		if err := c.breaker.allow(req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.semaphore != nil {
+		select {
+		case c.semaphore <- struct{}{}:
+			defer func() { <-c.semaphore }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	start := time.Now()
+	httpResp, err := c.HttpClient.Do(req)
+
+	if c.breaker != nil {
+		c.breaker.record(req.URL.Host, httpResp, err)
+	}
+
+	if c.metrics != nil {
+		c.metrics.record(req.Context(), req.Method, httpResp, err, time.Since(start))
+	}
+
+	return httpResp, err
+}
+
+// ForAddress returns a client for the Pathfinder API at address, such as
+// another device of a fleet, with the configuration of c except for its
+// address. The client shares the HttpClient, concurrency limit, rate
+// limiter, circuit breaker, and metrics of c, so that requests to every
+// device count towards the same limits. The client for an address is
+// created once and reused, and c is returned for its own address.
+//
+// An error is returned if the address is not valid.
+func (c *Client) ForAddress(address string) (*Client, error) {
+	if address == c.Config.Address {
+		return c, nil
+	}
+
+	c.devicesMu.Lock()
+	defer c.devicesMu.Unlock()
+
+	if client, ok := c.devices[address]; ok {
+		return client, nil
+	}
+
+	config := c.Config
+	config.Address = address
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	client := &Client{
 		Config:     config,
-		HttpClient: http.DefaultClient,
+		HttpClient: c.HttpClient,
+		semaphore:  c.semaphore,
+		limiter:    c.limiter,
+		breaker:    c.breaker,
+		metrics:    c.metrics,
 	}
 
+	if c.devices == nil {
+		c.devices = map[string]*Client{}
+	}
+	c.devices[address] = client
+
 	return client, nil
 }
+
+// Close stops pushing metrics in the background, and pushes the requests
+// counted since the last push, if metrics are enabled, so that no count is
+// lost when the provider exits. Requests sent after the client is closed are
+// not pushed.
+func (c *Client) Close() {
+	if c.metrics != nil {
+		c.metrics.close()
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_redirects(t *testing.T) {
+	var apiKeys []string
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.Header.Get("x-api-key"))
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer target.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/same-host", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/v1/target", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/v1/target", func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.Header.Get("x-api-key"))
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	mux.HandleFunc("/v1/cross-host", func(w http.ResponseWriter, r *http.Request) {
+		// The target server listens on 127.0.0.1, so localhost is a
+		// different host for the same server.
+		http.Redirect(w, r, strings.Replace(target.URL, "127.0.0.1", "localhost", 1), http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/v1/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/v1/loop", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, ApiKey: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	testCases := map[string]struct {
+		path        string
+		expectError string
+	}{
+		"same-host":  {path: "/v1/same-host"},
+		"cross-host": {path: "/v1/cross-host", expectError: "refusing to follow redirect"},
+		"loop":       {path: "/v1/loop", expectError: "stopped after 10 redirects"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			apiKeys = nil
+
+			var out struct {
+				Message string `json:"message"`
+			}
+			err := client.GetJSON(context.Background(), testCase.path, &out)
+
+			if testCase.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+					t.Errorf("expected error containing %q, got: %v", testCase.expectError, err)
+				}
+
+				if len(apiKeys) != 0 {
+					t.Errorf("expected no requests to the redirect target, got %d", len(apiKeys))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(apiKeys) != 1 || apiKeys[0] != "secret" {
+				t.Errorf("expected API key to be preserved on redirect, got: %v", apiKeys)
+			}
+		})
+	}
+}
+
+func TestClient_maxConcurrentRequests(t *testing.T) {
+	const limit = 2
+
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, MaxConcurrentRequests: limit})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := client.GetJSON(context.Background(), "/v1/device/status", nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > limit {
+		t.Errorf("expected at most %d concurrent requests, got %d", limit, got)
+	}
+}
+
+func TestClient_ForAddress(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	rover1 := httptest.NewServer(handler)
+	defer rover1.Close()
+
+	rover2 := httptest.NewServer(handler)
+	defer rover2.Close()
+
+	client, err := NewClient(ClientConfig{Address: rover1.URL, MaxConcurrentRequests: 1})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	if got, err := client.ForAddress(rover1.URL); err != nil || got != client {
+		t.Errorf("expected the client itself for its own address, got %p, %v", got, err)
+	}
+
+	device, err := client.ForAddress(rover2.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if device.Config.Address != rover2.URL {
+		t.Errorf("expected address %s, got %s", rover2.URL, device.Config.Address)
+	}
+
+	if again, _ := client.ForAddress(rover2.URL); again != device {
+		t.Error("expected the client for an address to be reused")
+	}
+
+	// Requests to both devices share the limit of one concurrent request.
+	var wg sync.WaitGroup
+	for _, c := range []*Client{client, device, client, device} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := c.GetJSON(context.Background(), "/v1/device/status", nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > 1 {
+		t.Errorf("expected at most 1 concurrent request, got %d", got)
+	}
+
+	if _, err := client.ForAddress("rover.local"); err == nil {
+		t.Error("expected an error for an invalid address, got none")
+	}
+}
+
+func TestClient_maxConcurrentRequests_contextCanceled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(ClientConfig{Address: server.URL, MaxConcurrentRequests: 1})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	// Occupy the only slot until the test ends.
+	go func() {
+		_ = client.GetJSON(context.Background(), "/v1/device/status", nil)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = client.GetJSON(ctx, "/v1/device/status", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+}
+
+func TestClient_requestsPerSecond(t *testing.T) {
+	const rps = 20
+
+	var times []time.Time
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, RequestsPerSecond: rps})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := client.GetJSON(context.Background(), "/v1/device/status", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first request is sent immediately, and each further request waits
+	// for the interval of the rate, allowing for timer imprecision.
+	interval := time.Second / rps
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < interval-5*time.Millisecond {
+			t.Errorf("expected request %d to be sent at least %s after the previous request, got %s", i, interval, gap)
+		}
+	}
+}
+
+func TestClient_requestsPerSecond_contextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, RequestsPerSecond: 0.1})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	if err := client.GetJSON(context.Background(), "/v1/device/status", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The next request would wait 10 seconds for a token.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.GetJSON(ctx, "/v1/device/status", nil); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to stop waiting with the context, waited %s", elapsed)
+	}
+}
+
+func TestNewClient_invalidProxy(t *testing.T) {
+	testCases := map[string]ClientConfig{
+		"http-relative":  {Address: "https://rover.test", HTTPProxy: "proxy.example.com:3128"},
+		"https-relative": {Address: "https://rover.test", HTTPSProxy: "/proxy"},
+		"http-invalid":   {Address: "https://rover.test", HTTPProxy: "http://proxy\x7f.example.com"},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(config); err == nil {
+				t.Error("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestClientConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config      ClientConfig
+		expectError string
+	}{
+		"valid": {
+			config: ClientConfig{Address: "https://rover.test", PollInterval: time.Second},
+		},
+		"valid-path": {
+			config: ClientConfig{Address: "http://127.0.0.1:8080/pathfinder"},
+		},
+		"empty-address": {
+			config:      ClientConfig{},
+			expectError: "address must not be empty",
+		},
+		"relative-address": {
+			config:      ClientConfig{Address: "rover.test"},
+			expectError: "must be an http:// or https:// URL",
+		},
+		"unsupported-scheme": {
+			config:      ClientConfig{Address: "ftp://rover.test"},
+			expectError: "must be an http:// or https:// URL",
+		},
+		"unparseable-address": {
+			config:      ClientConfig{Address: "http://rover\x7f.test"},
+			expectError: "invalid address",
+		},
+		"negative-poll-interval": {
+			config:      ClientConfig{Address: "https://rover.test", PollInterval: -time.Second},
+			expectError: "poll interval must not be negative",
+		},
+		"negative-retry-wait": {
+			config:      ClientConfig{Address: "https://rover.test", RetryWaitMin: -time.Second},
+			expectError: "retry wait must not be negative",
+		},
+		"retry-on-success-status": {
+			config:      ClientConfig{Address: "https://rover.test", RetryOnStatus: []int{503, 200}},
+			expectError: "retry status code 200 must be between 400 and 599",
+		},
+		"negative-max-response-bytes": {
+			config:      ClientConfig{Address: "https://rover.test", MaxResponseBytes: -1},
+			expectError: "maximum response size must not be negative",
+		},
+		"negative-max-concurrent-requests": {
+			config:      ClientConfig{Address: "https://rover.test", MaxConcurrentRequests: -1},
+			expectError: "maximum concurrent requests must not be negative",
+		},
+		"negative-requests-per-second": {
+			config:      ClientConfig{Address: "https://rover.test", RequestsPerSecond: -1},
+			expectError: "requests per second must not be negative",
+		},
+		"relative-movement-path": {
+			config:      ClientConfig{Address: "https://rover.test", MovementPath: "v1/movement"},
+			expectError: "must start with /",
+		},
+		"relative-metrics-endpoint": {
+			config:      ClientConfig{Address: "https://rover.test", MetricsEndpoint: "pushgateway:9091"},
+			expectError: "invalid metrics endpoint",
+		},
+		"invalid-proxy": {
+			config:      ClientConfig{Address: "https://rover.test", HTTPSProxy: "/proxy"},
+			expectError: "invalid https proxy URL",
+		},
+		"multiple": {
+			config:      ClientConfig{PollInterval: -time.Second},
+			expectError: "address must not be empty\npoll interval must not be negative",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.config.Validate()
+
+			if testCase.expectError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+				t.Errorf("expected error containing %q, got: %v", testCase.expectError, err)
+			}
+
+			// NewClient refuses invalid configurations.
+			if _, err := NewClient(testCase.config); err == nil {
+				t.Error("expected NewClient error, got none")
+			}
+		})
+	}
+}
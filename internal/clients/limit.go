@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseBytes is the default maximum size of a response body.
+const DefaultMaxResponseBytes = 10 * 1024 * 1024
+
+// ResponseTooLargeError is returned when reading a response body that exceeds
+// the maximum response size.
+type ResponseTooLargeError struct {
+	MaxBytes int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the maximum size of %d bytes", e.MaxBytes)
+}
+
+// limitTransport limits the size of response bodies, so that a device
+// responding with an unbounded body cannot exhaust the memory of the
+// provider while the body is decoded.
+type limitTransport struct {
+	base     http.RoundTripper
+	maxBytes int64
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = &limitedBody{
+		// One byte more than the limit is read to detect bodies exceeding it.
+		reader:   io.LimitReader(resp.Body, t.maxBytes+1),
+		closer:   resp.Body,
+		maxBytes: t.maxBytes,
+	}
+
+	return resp, nil
+}
+
+// limitedBody is a response body returning a ResponseTooLargeError once more
+// than maxBytes have been read.
+type limitedBody struct {
+	reader   io.Reader
+	closer   io.Closer
+	maxBytes int64
+	read     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+
+	if b.read > b.maxBytes {
+		// Only the bytes up to the limit are returned, which are none once
+		// the limit has already been exceeded by an earlier read.
+		excess := min(b.read-b.maxBytes, int64(n))
+		return n - int(excess), &ResponseTooLargeError{MaxBytes: b.maxBytes}
+	}
+
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.closer.Close()
+}
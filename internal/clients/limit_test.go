@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_maxResponseBytes(t *testing.T) {
+	// The body is exactly 32 bytes long.
+	body := `{"message":"` + strings.Repeat("a", 18) + `"}`
+
+	testCases := map[string]struct {
+		maxBytes    int64
+		expectError bool
+	}{
+		"below-limit":    {maxBytes: 64},
+		"at-limit":       {maxBytes: int64(len(body))},
+		"exceeds-limit":  {maxBytes: int64(len(body)) - 1, expectError: true},
+		"far-over-limit": {maxBytes: 4, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL, MaxResponseBytes: testCase.maxBytes})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			var out struct {
+				Message string `json:"message"`
+			}
+			err = client.GetJSON(context.Background(), "/v1/device/status", &out)
+
+			if testCase.expectError {
+				var tooLargeErr *ResponseTooLargeError
+				if !errors.As(err, &tooLargeErr) {
+					t.Errorf("expected response too large error, got: %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
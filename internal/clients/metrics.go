@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Names of the metrics pushed to the Prometheus Pushgateway at
+// ClientConfig.MetricsEndpoint, all labeled with the HTTP method of the
+// request.
+const (
+	// MetricRequestsTotal counts requests by method and response status code,
+	// with a code of "error" for requests that failed without a response.
+	MetricRequestsTotal = "pathfinder_requests_total"
+
+	// MetricRequestErrorsTotal counts requests that failed without a
+	// response, or with a 4xx or 5xx status code.
+	MetricRequestErrorsTotal = "pathfinder_request_errors_total"
+
+	// MetricRequestDurationSeconds is a summary of the time from sending a
+	// request to receiving the response headers.
+	MetricRequestDurationSeconds = "pathfinder_request_duration_seconds"
+)
+
+// metricsJob is the job label of the metrics pushed to the Pushgateway.
+const metricsJob = "terraform-provider-pathfinder"
+
+// metricsPushTimeout is the maximum time to wait for the Pushgateway to
+// accept the metrics.
+const metricsPushTimeout = 5 * time.Second
+
+// metricsRecorder counts the requests sent by a client, and pushes the
+// counts to a Prometheus Pushgateway after requests. The metrics are grouped
+// by the job and the address of the client as the instance, and the metrics
+// of the group are replaced on every push, so the Pushgateway holds the
+// counts since the provider started for each address.
+//
+// Pushes are sent by a single background flusher rather than by the requests
+// themselves, so that a slow Pushgateway does not delay requests to the
+// Pathfinder API, and pushes cannot reach the Pushgateway out of order.
+// Requests counted while a push is in progress are sent together by the next
+// push. The flusher is started by the first request counted, and stopped by
+// close.
+type metricsRecorder struct {
+	endpoint   string
+	instance   string
+	httpClient Doer
+
+	// pending is signaled when counts changed since the last push.
+	pending chan struct{}
+	// done is closed to stop the flusher, which closes stopped once it
+	// returned.
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	// pushCtx is the context of the last request counted, without its
+	// cancellation, used to log push failures.
+	pushCtx       context.Context
+	requests      map[metricsRequestKey]int
+	errors        map[string]int
+	durationSum   map[string]float64
+	durationCount map[string]int
+}
+
+type metricsRequestKey struct {
+	method string
+	code   string
+}
+
+// newMetricsRecorder returns a recorder pushing to the Pushgateway at
+// endpoint, with the address of the client as the instance of the metrics.
+func newMetricsRecorder(endpoint, address string) *metricsRecorder {
+	return &metricsRecorder{
+		endpoint:      endpoint,
+		instance:      metricsInstance(address),
+		httpClient:    &http.Client{Timeout: metricsPushTimeout},
+		pending:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		requests:      map[metricsRequestKey]int{},
+		errors:        map[string]int{},
+		durationSum:   map[string]float64{},
+		durationCount: map[string]int{},
+	}
+}
+
+// record counts the request, which received resp or failed with err after
+// duration, and schedules a push of the counts without waiting for it.
+func (m *metricsRecorder) record(ctx context.Context, method string, resp *http.Response, err error, duration time.Duration) {
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Push even when the request was canceled, so that the cancellation is
+	// counted.
+	m.pushCtx = context.WithoutCancel(ctx)
+	m.requests[metricsRequestKey{method: method, code: code}]++
+	m.durationSum[method] += duration.Seconds()
+	m.durationCount[method]++
+
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		m.errors[method]++
+	}
+
+	if !m.started && !m.closed {
+		m.started = true
+		go m.flush()
+	}
+
+	// A push is already pending when the channel is full, and it sends these
+	// counts too.
+	select {
+	case m.pending <- struct{}{}:
+	default:
+	}
+}
+
+// flush pushes the metrics each time counts change, until the recorder is
+// closed.
+func (m *metricsRecorder) flush() {
+	defer close(m.stopped)
+
+	for {
+		select {
+		case <-m.pending:
+			m.push()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// close stops the flusher, and then pushes the counts changed since the last
+// push, if any, waiting for the push to complete. Requests counted after the
+// recorder is closed are not pushed.
+func (m *metricsRecorder) close() {
+	m.closeOnce.Do(func() {
+		m.mu.Lock()
+		m.closed = true
+		started := m.started
+		m.mu.Unlock()
+
+		close(m.done)
+		if started {
+			<-m.stopped
+		}
+
+		select {
+		case <-m.pending:
+			m.push()
+		default:
+		}
+	})
+}
+
+// push sends the metrics to the Pushgateway in the Prometheus text format.
+// Failures are logged rather than returned, as they do not affect requests
+// to the Pathfinder API.
+func (m *metricsRecorder) push() {
+	m.mu.Lock()
+	ctx := m.pushCtx
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, metricsPushTimeout)
+	defer cancel()
+
+	// The instance is base64 encoded, as the Pushgateway does not allow
+	// slashes in grouping key values otherwise.
+	pushURL, err := JoinURL(m.endpoint, "/metrics/job/"+metricsJob+"/instance@base64/"+base64.RawURLEncoding.EncodeToString([]byte(m.instance)))
+	if err != nil {
+		tflog.Warn(ctx, "Unable to push metrics", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, pushURL, bytes.NewReader(m.encode()))
+	if err != nil {
+		tflog.Warn(ctx, "Unable to push metrics", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to push metrics", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		tflog.Warn(ctx, "Unable to push metrics", map[string]interface{}{"status": resp.StatusCode})
+	}
+}
+
+// encode returns the metrics in the Prometheus text exposition format, with
+// series sorted by their labels so the output is deterministic.
+func (m *metricsRecorder) encode() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	requestKeys := make([]metricsRequestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	slices.SortFunc(requestKeys, func(a, b metricsRequestKey) int {
+		return cmp.Or(strings.Compare(a.method, b.method), strings.Compare(a.code, b.code))
+	})
+
+	fmt.Fprintf(&buf, "# TYPE %s counter\n", MetricRequestsTotal)
+	for _, key := range requestKeys {
+		fmt.Fprintf(&buf, "%s{method=%q,code=%q} %d\n", MetricRequestsTotal, key.method, key.code, m.requests[key])
+	}
+
+	methods := make([]string, 0, len(m.durationCount))
+	for method := range m.durationCount {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	fmt.Fprintf(&buf, "# TYPE %s counter\n", MetricRequestErrorsTotal)
+	for _, method := range methods {
+		fmt.Fprintf(&buf, "%s{method=%q} %d\n", MetricRequestErrorsTotal, method, m.errors[method])
+	}
+
+	fmt.Fprintf(&buf, "# TYPE %s summary\n", MetricRequestDurationSeconds)
+	for _, method := range methods {
+		fmt.Fprintf(&buf, "%s_sum{method=%q} %g\n", MetricRequestDurationSeconds, method, m.durationSum[method])
+		fmt.Fprintf(&buf, "%s_count{method=%q} %d\n", MetricRequestDurationSeconds, method, m.durationCount[method])
+	}
+
+	return buf.Bytes()
+}
+
+// metricsInstance returns the address without its user information, as the
+// instance grouping key of the metrics of a client.
+func metricsInstance(address string) string {
+	u, err := url.Parse(address)
+	if err != nil {
+		return address
+	}
+
+	u.User = nil
+
+	return u.String()
+}
@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Structure of a single battery history item.
+type BatteryHistoryItem struct {
+	// Timestamp of the reading, in RFC 3339 format or as a Unix epoch
+	Timestamp Timestamp `json:"timestamp"`
+	// Unit of the battery item
+	Unit string `json:"unit"`
+	// Value of the battery item
+	Value LenientInt64 `json:"value"`
+}
@@ -8,5 +8,5 @@ type BatteryResponse struct {
 	// Unit of the battery item
 	Unit string `json:"unit"`
 	// Value of the battery item
-	Value int64 `json:"value"`
+	Value LenientInt64 `json:"value"`
 }
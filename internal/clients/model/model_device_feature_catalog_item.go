@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Structure of a single item of the device feature catalog.
+type DeviceFeatureCatalogItem struct {
+	// Key of the feature flag
+	Key string `json:"key"`
+	// Description of the feature
+	Description string `json:"description"`
+	// Whether the feature is experimental
+	Experimental bool `json:"experimental"`
+	// Whether the feature is enabled
+	Enabled bool `json:"enabled"`
+}
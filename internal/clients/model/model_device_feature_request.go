@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Request to enable or disable a feature flag.
+type DeviceFeatureRequest struct {
+	// Whether the feature is enabled
+	Enabled bool `json:"enabled"`
+}
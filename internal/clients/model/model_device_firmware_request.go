@@ -0,0 +1,13 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Request to update the firmware of the device.
+type DeviceFirmwareRequest struct {
+	// Version of the firmware to update to
+	Version string `json:"version"`
+	// URL to download the firmware from, the device uses its update server
+	// when omitted
+	Url string `json:"url,omitempty"`
+}
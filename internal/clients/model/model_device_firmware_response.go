@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Response containing the firmware update status.
+type DeviceFirmwareResponse struct {
+	// Firmware update status
+	Updating bool `json:"updating"`
+}
@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Response containing the reset status.
+type DeviceResetResponse struct {
+	// Reset status
+	Resetting bool `json:"resetting"`
+}
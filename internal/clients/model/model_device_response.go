@@ -11,6 +11,6 @@ type DeviceResponse struct {
 	// Name
 	Name string `json:"name"`
 	// Uptime (in seconds)
-	Uptime   float64                 `json:"uptime"`
+	Uptime   LenientFloat64          `json:"uptime"`
 	Versions *DeviceResponseVersions `json:"versions"`
 }
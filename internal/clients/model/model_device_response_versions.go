@@ -3,6 +3,8 @@
 
 package model
 
+import "encoding/json"
+
 // Versions.
 type DeviceResponseVersions struct {
 	// API version
@@ -10,3 +12,38 @@ type DeviceResponseVersions struct {
 	// Application version
 	App string `json:"app"`
 }
+
+// UnmarshalJSON decodes the versions, accepting the field names used by
+// different firmware variants, such as apiVersion or api_version instead of
+// api. The canonical name is preferred when several are present.
+func (v *DeviceResponseVersions) UnmarshalJSON(data []byte) error {
+	var variants struct {
+		Api             string `json:"api"`
+		ApiVersionCamel string `json:"apiVersion"`
+		ApiVersionSnake string `json:"api_version"`
+		App             string `json:"app"`
+		AppVersionCamel string `json:"appVersion"`
+		AppVersionSnake string `json:"app_version"`
+	}
+
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return err
+	}
+
+	v.Api = firstNonEmpty(variants.Api, variants.ApiVersionCamel, variants.ApiVersionSnake)
+	v.App = firstNonEmpty(variants.App, variants.AppVersionCamel, variants.AppVersionSnake)
+
+	return nil
+}
+
+// firstNonEmpty returns the first of the values that is not empty, or an
+// empty string if all of them are.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
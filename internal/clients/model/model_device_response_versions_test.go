@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDeviceResponseVersions_fieldNames(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected DeviceResponseVersions
+	}{
+		"canonical": {
+			body:     `{"api":"1.2.0","app":"3.4.5"}`,
+			expected: DeviceResponseVersions{Api: "1.2.0", App: "3.4.5"},
+		},
+		"camel": {
+			body:     `{"apiVersion":"1.2.0","appVersion":"3.4.5"}`,
+			expected: DeviceResponseVersions{Api: "1.2.0", App: "3.4.5"},
+		},
+		"snake": {
+			body:     `{"api_version":"1.2.0","app_version":"3.4.5"}`,
+			expected: DeviceResponseVersions{Api: "1.2.0", App: "3.4.5"},
+		},
+		"canonical-preferred": {
+			body:     `{"api":"1.2.0","apiVersion":"0.9.0","app_version":"3.4.5"}`,
+			expected: DeviceResponseVersions{Api: "1.2.0", App: "3.4.5"},
+		},
+		"missing": {
+			body:     `{}`,
+			expected: DeviceResponseVersions{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var resp DeviceResponse
+			if err := json.Unmarshal([]byte(`{"versions":`+testCase.body+`}`), &resp); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if *resp.Versions != testCase.expected {
+				t.Errorf("expected versions %+v, got %+v", testCase.expected, *resp.Versions)
+			}
+		})
+	}
+}
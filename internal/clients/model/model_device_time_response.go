@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Response containing the current time of the device clock.
+type DeviceTimeResponse struct {
+	// Current time of the device, in RFC 3339 format or as a Unix epoch
+	Time Timestamp `json:"time"`
+}
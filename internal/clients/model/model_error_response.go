@@ -9,4 +9,7 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 	// HTTP status code
 	Status int32 `json:"status"`
+	// Errors of individual request fields, keyed by the path of the field
+	// such as steps[0].distance, sent with 422 Unprocessable Entity responses
+	Fields map[string]string `json:"fields,omitempty"`
 }
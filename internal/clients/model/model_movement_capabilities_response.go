@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Response containing the movements supported by the device.
+type MovementCapabilitiesResponse struct {
+	// Directions the device can move in
+	Directions []string `json:"directions"`
+	// Minimum distance (in meters) of a linear step
+	MinDistance float64 `json:"min_distance"`
+	// Maximum distance (in meters) of a linear step
+	MaxDistance float64 `json:"max_distance"`
+	// Minimum angle (in degrees) of a step
+	MinAngle int64 `json:"min_angle"`
+	// Maximum angle (in degrees) of a step
+	MaxAngle int64 `json:"max_angle"`
+}
@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Request to acquire the movement lock.
+type MovementLockRequest struct {
+	// Time (in seconds) after which the lock is released automatically, the
+	// lock is held until released when omitted
+	TTLSeconds int64 `json:"ttl_seconds,omitempty"`
+}
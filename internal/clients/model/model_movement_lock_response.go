@@ -7,4 +7,7 @@ package model
 type MovementLockResponse struct {
 	// Movement lock status
 	Locked bool `json:"locked"`
+	// Time the lock expires at in RFC 3339 format or as a Unix epoch, if it
+	// has a TTL
+	ExpiresAt Timestamp `json:"expires_at"`
 }
@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Request to save or remove an existing movement plan from the filesystem.
+type MovementPersistenceRequest struct {
+	// Whether the movement plan is saved to the filesystem
+	Persist bool `json:"persist"`
+}
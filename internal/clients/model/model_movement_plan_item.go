@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Structure of a single persisted movement plan item.
+type MovementPlanItem struct {
+	// Name of the movement plan
+	Name string `json:"name"`
+}
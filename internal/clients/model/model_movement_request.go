@@ -5,10 +5,25 @@ package model
 
 // Request for a movement.
 type MovementRequest struct {
+	// Stop or steer around obstacles detected by the device while moving,
+	// the device default is used when omitted
+	AvoidObstacles *bool `json:"avoid_obstacles,omitempty"`
+	// How the angles of the steps are interpreted, either relative or
+	// absolute, the device default is used when omitted
+	CoordinateMode string `json:"coordinate_mode,omitempty"`
+	// Time to execute the movement plan at in RFC 3339 format, the plan is
+	// executed immediately when omitted
+	At string `json:"at,omitempty"`
 	// Name of the movement plan
 	Name string `json:"name"`
-	// Persist the movement plan to the filesystem
-	Persist bool `json:"persist"`
+	// Persist the movement plan to the filesystem, the device default is used when omitted
+	Persist *bool `json:"persist,omitempty"`
+	// Behavior when another movement plan is running, one of replace, queue,
+	// or reject, the device default is used when omitted
+	QueueMode string `json:"queue_mode,omitempty"`
+	// Halt the movement plan when a step fails, rather than continuing with
+	// the next step, the device default is used when omitted
+	StopOnError *bool `json:"stop_on_error,omitempty"`
 	// List of movement steps
 	Steps []MovementStepItem `json:"steps"`
 }
@@ -5,6 +5,14 @@ package model
 
 // Response containing the movement operation status.
 type MovementResponse struct {
+	// Name of the movement plan, if reported by the device
+	Name string `json:"name,omitempty"`
 	// Status of the movement operation
 	Moving bool `json:"moving"`
+	// Indicates the movement plan is waiting for the time it is scheduled at
+	Scheduled bool `json:"scheduled,omitempty"`
+	// Persistence of the movement plan, if reported by the device
+	Persist *bool `json:"persist,omitempty"`
+	// Results of the steps of the movement plan, if reported by the device
+	Steps []MovementStepResult `json:"steps,omitempty"`
 }
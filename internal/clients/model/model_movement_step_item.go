@@ -10,5 +10,7 @@ type MovementStepItem struct {
 	// Direction of movement
 	Direction string `json:"direction"`
 	// Distance (in centimeters) of movement
-	Distance float64 `json:"distance"`
+	Distance float64 `json:"distance,omitempty"`
+	// Speed (in meters per second) of movement, the device default is used when omitted
+	Speed float64 `json:"speed,omitempty"`
 }
@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Result of executing a single movement step.
+type MovementStepResult struct {
+	// Index of the step in the movement plan, starting at 0
+	Index int64 `json:"index"`
+	// Status of the step, such as pending, completed, or failed
+	Status string `json:"status"`
+	// Reason for the status, if reported by the device
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// LenientInt64 is an integer that some firmware versions encode as a JSON
+// string, such as "95" instead of 95. Both representations are decoded.
+type LenientInt64 int64
+
+// UnmarshalJSON decodes a JSON number, or a JSON string containing one.
+func (n *LenientInt64) UnmarshalJSON(data []byte) error {
+	var value int64
+	if err := json.Unmarshal(unquoteNumber(data), &value); err != nil {
+		return err
+	}
+
+	*n = LenientInt64(value)
+
+	return nil
+}
+
+// LenientFloat64 is a number that some firmware versions encode as a JSON
+// string, such as "-60.5" instead of -60.5. Both representations are decoded.
+type LenientFloat64 float64
+
+// UnmarshalJSON decodes a JSON number, or a JSON string containing one.
+func (n *LenientFloat64) UnmarshalJSON(data []byte) error {
+	var value float64
+	if err := json.Unmarshal(unquoteNumber(data), &value); err != nil {
+		return err
+	}
+
+	*n = LenientFloat64(value)
+
+	return nil
+}
+
+// unquoteNumber returns the contents of a JSON string, so that a number
+// encoded as a string can be decoded as a number. Any other JSON value is
+// returned unchanged, and fails to decode as a number if it is not one.
+func unquoteNumber(data []byte) []byte {
+	if len(data) == 0 || data[0] != '"' {
+		return data
+	}
+
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return data
+	}
+
+	return bytes.TrimSpace([]byte(value))
+}
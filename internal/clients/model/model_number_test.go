@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBatteryResponse_lenientValue(t *testing.T) {
+	testCases := map[string]struct {
+		body        string
+		expected    LenientInt64
+		expectError bool
+	}{
+		"number":             {body: `{"value":95,"unit":"%"}`, expected: 95},
+		"string":             {body: `{"value":"95","unit":"%"}`, expected: 95},
+		"string-whitespace":  {body: `{"value":" 95 ","unit":"%"}`, expected: 95},
+		"null":               {body: `{"value":null,"unit":"%"}`, expected: 0},
+		"string-not-number":  {body: `{"value":"full","unit":"%"}`, expectError: true},
+		"string-fractional":  {body: `{"value":"95.5","unit":"%"}`, expectError: true},
+		"boolean-not-number": {body: `{"value":true,"unit":"%"}`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var resp BatteryResponse
+			err := json.Unmarshal([]byte(testCase.body), &resp)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got value %d", resp.Value)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if resp.Value != testCase.expected {
+				t.Errorf("expected value %d, got %d", testCase.expected, resp.Value)
+			}
+		})
+	}
+}
+
+func TestWifiNetworkItem_lenientRssi(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected LenientFloat64
+	}{
+		"number": {body: `{"ssid":"lab","rssi":-60.5}`, expected: -60.5},
+		"string": {body: `{"ssid":"lab","rssi":"-60.5"}`, expected: -60.5},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var item WifiNetworkItem
+			if err := json.Unmarshal([]byte(testCase.body), &item); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if item.Rssi != testCase.expected {
+				t.Errorf("expected rssi %v, got %v", testCase.expected, item.Rssi)
+			}
+		})
+	}
+}
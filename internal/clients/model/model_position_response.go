@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+// Response containing the position of the device.
+type PositionResponse struct {
+	// Position (in meters) along the X axis
+	X float64 `json:"x"`
+	// Position (in meters) along the Y axis
+	Y float64 `json:"y"`
+	// Heading (in degrees) clockwise from the positive Y axis
+	Heading float64 `json:"heading"`
+}
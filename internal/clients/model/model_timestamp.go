@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// timestampMillisThreshold is the magnitude above which an epoch timestamp
+// is read as milliseconds rather than seconds. In seconds it is in the year
+// 5138, in milliseconds it is in 1973.
+const timestampMillisThreshold = 1e11
+
+// Timestamp is a point in time that firmware versions encode as an RFC 3339
+// string, or as a Unix epoch in seconds or milliseconds, either as a JSON
+// number or as a JSON string containing one. All of them are decoded. The
+// zero value, decoded from null or an empty string, means the time is unset.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON decodes an RFC 3339 string or an epoch in seconds or
+// milliseconds. Epoch timestamps are normalized to UTC, while RFC 3339
+// strings keep the offset they were sent with.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+
+		if value == "" {
+			*t = Timestamp{}
+			return nil
+		}
+
+		if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			*t = Timestamp{parsed}
+			return nil
+		}
+	}
+
+	epoch, err := strconv.ParseFloat(string(unquoteNumber(data)), 64)
+	if err != nil || math.IsInf(epoch, 0) || math.IsNaN(epoch) {
+		return fmt.Errorf("timestamp %s is neither RFC 3339 nor a Unix epoch", data)
+	}
+
+	if math.Abs(epoch) >= math.MaxInt64 {
+		return fmt.Errorf("timestamp %s is out of range", data)
+	}
+
+	*t = Timestamp{epochTime(epoch)}
+
+	return nil
+}
+
+// MarshalJSON encodes the timestamp in RFC 3339 format, or null if unset.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// epochTime returns the time of an epoch in seconds, or in milliseconds when
+// it is too large to be a plausible number of seconds. The whole and
+// fractional parts are converted separately, as a float64 cannot hold an
+// epoch in nanoseconds precisely, and the whole part is passed to time.Unix
+// or time.UnixMilli, as a time.Duration overflows after 292 years.
+func epochTime(epoch float64) time.Time {
+	whole, frac := math.Modf(epoch)
+
+	if math.Abs(epoch) >= timestampMillisThreshold {
+		return time.UnixMilli(int64(whole)).
+			Add(time.Duration(math.Round(frac * float64(time.Millisecond)))).
+			UTC()
+	}
+
+	return time.Unix(int64(whole), int64(math.Round(frac*float64(time.Second)))).UTC()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDeviceTimeResponse_timestamp(t *testing.T) {
+	expected := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	testCases := map[string]struct {
+		body        string
+		expected    time.Time
+		expectError bool
+	}{
+		"rfc3339":              {body: `{"time":"2024-01-02T15:04:05Z"}`, expected: expected},
+		"rfc3339-nano":         {body: `{"time":"2024-01-02T15:04:05.25Z"}`, expected: expected.Add(250 * time.Millisecond)},
+		"rfc3339-offset":       {body: `{"time":"2024-01-02T16:04:05+01:00"}`, expected: expected},
+		"epoch-seconds":        {body: `{"time":1704207845}`, expected: expected},
+		"epoch-seconds-string": {body: `{"time":"1704207845"}`, expected: expected},
+		"epoch-seconds-float":  {body: `{"time":1704207845.5}`, expected: expected.Add(500 * time.Millisecond)},
+		"epoch-millis":         {body: `{"time":1704207845250}`, expected: expected.Add(250 * time.Millisecond)},
+		"epoch-millis-string":  {body: `{"time":"1704207845250"}`, expected: expected.Add(250 * time.Millisecond)},
+		"epoch-seconds-large":  {body: `{"time":10000000000}`, expected: time.Date(2286, 11, 20, 17, 46, 40, 0, time.UTC)},
+		"epoch-seconds-max":    {body: `{"time":99999999999}`, expected: time.Date(5138, 11, 16, 9, 46, 39, 0, time.UTC)},
+		"epoch-millis-min":     {body: `{"time":100000000000}`, expected: time.Date(1973, 3, 3, 9, 46, 40, 0, time.UTC)},
+		"epoch-millis-large":   {body: `{"time":10000000000000000}`, expected: time.Unix(10000000000000, 0).UTC()},
+		"epoch-out-of-range":   {body: `{"time":1e19}`, expectError: true},
+		"null":                 {body: `{"time":null}`},
+		"empty-string":         {body: `{"time":""}`},
+		"missing":              {body: `{}`},
+		"string-not-time":      {body: `{"time":"yesterday"}`, expectError: true},
+		"boolean-not-time":     {body: `{"time":true}`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var resp DeviceTimeResponse
+			err := json.Unmarshal([]byte(testCase.body), &resp)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got time %s", resp.Time)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !resp.Time.Equal(testCase.expected) {
+				t.Errorf("expected time %s, got %s", testCase.expected, resp.Time)
+			}
+		})
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		timestamp Timestamp
+		expected  string
+	}{
+		"set":   {timestamp: Timestamp{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}, expected: `"2024-01-02T15:04:05Z"`},
+		"unset": {expected: `null`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.Marshal(testCase.timestamp)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(got) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
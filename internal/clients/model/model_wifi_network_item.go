@@ -8,7 +8,7 @@ type WifiNetworkItem struct {
 	// Encryption status
 	Encrypted bool `json:"encrypted"`
 	// RSSI (in dBm)
-	Rssi float64 `json:"rssi"`
+	Rssi LenientFloat64 `json:"rssi"`
 	// SSID
 	Ssid string `json:"ssid"`
 }
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is the default interval between polls of the
+// Pathfinder API while waiting for a condition.
+const DefaultPollInterval = 2 * time.Second
+
+// Poll calls condition immediately and then every interval, until it returns
+// true or an error, or the context is done. The context error is returned if
+// the context is done before the condition is met.
+func Poll(ctx context.Context, interval time.Duration, condition func(ctx context.Context) (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := Poll(context.Background(), time.Millisecond, func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestPoll_conditionError(t *testing.T) {
+	expected := errors.New("condition failed")
+	err := Poll(context.Background(), time.Millisecond, func(ctx context.Context) (bool, error) {
+		return false, expected
+	})
+
+	if !errors.Is(err, expected) {
+		t.Errorf("expected %s, got %s", expected, err)
+	}
+}
+
+func TestPoll_contextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := Poll(ctx, time.Hour, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %s, got %s", context.DeadlineExceeded, err)
+	}
+}
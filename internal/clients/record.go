@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RecordedRequest is a request recorded by a RecordingDoer instead of being
+// sent.
+type RecordedRequest struct {
+	// HTTP method of the request, such as GET or POST
+	Method string
+
+	// Path of the request URL, including any query string
+	Path string
+
+	// Body of the request, empty for requests without one
+	Body string
+}
+
+// recordedResponseBody is the body of the responses returned by a
+// RecordingDoer. A JSON null decodes into any model, leaving it empty.
+const recordedResponseBody = "null"
+
+// RecordingDoer is a Doer that records and logs requests instead of sending
+// them, for trying out configurations without a device. Every request
+// receives a 200 OK response with a JSON null body, so that responses decode
+// into empty models.
+type RecordingDoer struct {
+	// IndentBody indents the JSON bodies of the requests in logs.
+	IndentBody bool
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+// Do records the request and returns an empty response.
+func (d *RecordingDoer) Do(req *http.Request) (*http.Response, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.RequestURI(),
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		recorded.Body = string(body)
+	}
+
+	d.mu.Lock()
+	d.requests = append(d.requests, recorded)
+	d.mu.Unlock()
+
+	fields := map[string]interface{}{
+		"method": recorded.Method,
+		"path":   recorded.Path,
+	}
+	if recorded.Body != "" {
+		fields["body"] = string(logBody([]byte(recorded.Body), d.IndentBody))
+	}
+
+	tflog.Info(req.Context(), "Recorded request instead of sending it", fields)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(recordedResponseBody)),
+		Request:    req,
+	}, nil
+}
+
+// Requests returns the requests recorded so far, in the order they were
+// received.
+func (d *RecordingDoer) Requests() []RecordedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]RecordedRequest(nil), d.requests...)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// redactedValue replaces sensitive values, matching the mask used by tflog.
+const redactedValue = "***"
+
+// redactedBody replaces bodies that cannot be redacted, as any part of them
+// may be sensitive.
+const redactedBody = "[unparseable body redacted]"
+
+// SensitiveBodyFields are the JSON fields of request and response bodies whose
+// values are never logged, such as the password of a wifi network.
+var SensitiveBodyFields = []string{"password"}
+
+// DefaultSensitiveHeaders are the request headers whose values are never
+// logged, as they carry credentials.
+var DefaultSensitiveHeaders = []string{"x-api-key", "Authorization", "Proxy-Authorization"}
+
+// RedactHeaders returns the headers as a map of header names to values, safe
+// to log, with the values of the given headers replaced. Header names are
+// matched case-insensitively, and multiple values are joined by ", ".
+func RedactHeaders(header http.Header, names ...string) map[string]string {
+	redacted := make(map[string]string, len(header))
+
+	for name, values := range header {
+		if isSensitiveField(name, names) {
+			redacted[name] = redactedValue
+			continue
+		}
+
+		redacted[name] = strings.Join(values, ", ")
+	}
+
+	return redacted
+}
+
+// RedactJSON returns the JSON body with the values of the given fields
+// replaced, at any depth, so that the body is safe to log. Field names are
+// matched case-insensitively.
+//
+// Bodies that are not a single valid JSON value cannot be redacted, so they
+// are replaced entirely by a placeholder.
+func RedactJSON(body []byte, fields ...string) []byte {
+	return redactJSON(body, fields, false)
+}
+
+// RedactJSONIndent is like RedactJSON, but indents the redacted body for
+// readability. The fields are redacted before the body is indented.
+func RedactJSONIndent(body []byte, fields ...string) []byte {
+	return redactJSON(body, fields, true)
+}
+
+func redactJSON(body []byte, fields []string, indent bool) []byte {
+	// Numbers are kept as written, as decoding them as float64 would change
+	// large integers in the logged body.
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return []byte(redactedBody)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return []byte(redactedBody)
+	}
+
+	marshal := json.Marshal
+	if indent {
+		marshal = func(v any) ([]byte, error) {
+			return json.MarshalIndent(v, "", "  ")
+		}
+	}
+
+	redacted, err := marshal(redactValue(value, fields))
+	if err != nil {
+		return []byte(redactedBody)
+	}
+
+	return redacted
+}
+
+// logBody returns the JSON body of a request with SensitiveBodyFields
+// redacted, indented when indent is true, so that it is safe to log.
+func logBody(body []byte, indent bool) []byte {
+	if indent {
+		return RedactJSONIndent(body, SensitiveBodyFields...)
+	}
+
+	return RedactJSON(body, SensitiveBodyFields...)
+}
+
+func redactValue(value any, fields []string) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if isSensitiveField(key, fields) {
+				value[key] = redactedValue
+				continue
+			}
+
+			value[key] = redactValue(nested, fields)
+		}
+	case []any:
+		for i, nested := range value {
+			value[i] = redactValue(nested, fields)
+		}
+	}
+
+	return value
+}
+
+func isSensitiveField(key string, fields []string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
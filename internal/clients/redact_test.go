@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactJSON(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected string
+	}{
+		"top level": {
+			body:     `{"ssid":"home","password":"hunter2"}`,
+			expected: `{"password":"***","ssid":"home"}`,
+		},
+		"case insensitive": {
+			body:     `{"ssid":"home","Password":"hunter2"}`,
+			expected: `{"Password":"***","ssid":"home"}`,
+		},
+		"nested": {
+			body:     `{"networks":[{"ssid":"home","password":"hunter2"}]}`,
+			expected: `{"networks":[{"password":"***","ssid":"home"}]}`,
+		},
+		"no sensitive fields": {
+			body:     `{"name":"square"}`,
+			expected: `{"name":"square"}`,
+		},
+		"large integer": {
+			body:     `{"features":{"odometer":9007199254740993},"password":"hunter2"}`,
+			expected: `{"features":{"odometer":9007199254740993},"password":"***"}`,
+		},
+		"invalid json": {
+			body:     `password=hunter2`,
+			expected: `[unparseable body redacted]`,
+		},
+		"trailing data": {
+			body:     `{"password":"hunter2"} {}`,
+			expected: `[unparseable body redacted]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := string(RedactJSON([]byte(testCase.body), SensitiveBodyFields...))
+
+			if got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+
+			if strings.Contains(got, "hunter2") {
+				t.Errorf("expected the password to be redacted, got %s", got)
+			}
+		})
+	}
+}
+
+func TestRedactJSONIndent(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected string
+	}{
+		"nested": {
+			body:     `{"networks":[{"ssid":"home","password":"hunter2"}]}`,
+			expected: "{\n  \"networks\": [\n    {\n      \"password\": \"***\",\n      \"ssid\": \"home\"\n    }\n  ]\n}",
+		},
+		"invalid json": {
+			body:     `password=hunter2`,
+			expected: `[unparseable body redacted]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := string(RedactJSONIndent([]byte(testCase.body), SensitiveBodyFields...))
+
+			if got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedactJSON_debugLogs(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	body := []byte(`{"ssid":"home","password":"hunter2"}`)
+	tflog.Debug(ctx, "Sending request", map[string]interface{}{
+		"body": string(RedactJSON(body, SensitiveBodyFields...)),
+	})
+
+	if strings.Contains(output.String(), "hunter2") {
+		t.Errorf("expected password to be redacted from logs, got: %s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "home") {
+		t.Errorf("expected non-sensitive fields in logs, got: %s", output.String())
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrEmptyResponse is returned when the Pathfinder API responds successfully
+// but without a response body to decode.
+var ErrEmptyResponse = errors.New("empty response body")
+
+// ErrReadOnly is returned when a request that could change the state of the
+// device is sent by a client configured to be read-only.
+var ErrReadOnly = errors.New("client is read-only")
+
+// StatusError is returned when the Pathfinder API responds with an unexpected
+// HTTP status code.
+type StatusError struct {
+	StatusCode int
+	Message    string
+	// Fields are the errors of individual request fields, keyed by the path
+	// of the field, when the device reports them, such as with 422
+	// Unprocessable Entity responses.
+	Fields map[string]string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("unexpected status code %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if len(e.Fields) > 0 {
+		fields := make([]string, 0, len(e.Fields))
+		for _, field := range slices.Sorted(maps.Keys(e.Fields)) {
+			fields = append(fields, field+": "+e.Fields[field])
+		}
+
+		msg += " (" + strings.Join(fields, ", ") + ")"
+	}
+
+	return msg
+}
+
+// NonJSONResponseError is returned when the response body cannot be decoded
+// because it is not JSON, such as an HTML page returned by a captive portal or
+// a gateway in front of the device.
+type NonJSONResponseError struct {
+	ContentType string
+	Snippet     string
+}
+
+func (e *NonJSONResponseError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "no content type"
+	}
+
+	return fmt.Sprintf("expected a JSON response, got %s, the address may not be a Pathfinder API or a proxy may be "+
+		"intercepting requests. Response body begins with: %s", contentType, e.Snippet)
+}
+
+// IsNotFound returns true if the error is a StatusError with a 404 Not Found
+// status code.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// Request describes a request to the Pathfinder API.
+type Request struct {
+	// HTTP method of the request, such as GET or POST
+	Method string
+
+	// Path of the endpoint, relative to the address of the API
+	Path string
+
+	// Body is encoded as the JSON request body, if not nil
+	Body any
+
+	// ExpectedStatus contains the status codes that indicate success,
+	// defaulting to DefaultExpectedStatus for the method when empty
+	ExpectedStatus []int
+
+	// Retry transient failures using Client.Do, which is only safe for
+	// idempotent requests
+	Retry bool
+}
+
+// DefaultExpectedStatus returns the status codes that indicate success for
+// requests with the given HTTP method, when a request does not set its own.
+func DefaultExpectedStatus(method string) []int {
+	switch method {
+	case http.MethodPost:
+		return []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}
+	default:
+		return []int{http.StatusOK}
+	}
+}
+
+// MethodOverrideHeader carries the method of a request sent as a POST request
+// when the client is configured with MethodOverride.
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// GetJSON sends a GET request to the given path of the Pathfinder API and
+// decodes the JSON response body into out.
+func (c *Client) GetJSON(ctx context.Context, path string, out any) error {
+	return c.SendJSON(ctx, Request{Method: http.MethodGet, Path: path}, out)
+}
+
+// SendJSON sends the request to the Pathfinder API and decodes the JSON
+// response body into out, unless out is nil.
+//
+// A StatusError is returned when the response status code is not one of the
+// expected status codes of the request, and ErrReadOnly when the client is
+// read-only and the request could change the state of the device.
+func (c *Client) SendJSON(ctx context.Context, req Request, out any) error {
+	if c.Config.ReadOnly && !isSafeMethod(req.Method) {
+		return fmt.Errorf("refusing to send %s request: %w", req.Method, ErrReadOnly)
+	}
+
+	var reqBody []byte
+
+	if req.Body != nil {
+		var err error
+
+		reqBody, err = json.Marshal(req.Body)
+		if err != nil {
+			return fmt.Errorf("error marshalling request: %w", err)
+		}
+	}
+
+	reqURL, err := JoinURL(c.Config.Address, req.Path)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, reqURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	c.setHeaders(httpReq)
+
+	if req.Body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Config.MethodOverride && httpReq.Method != http.MethodPost {
+		httpReq.Header.Set(MethodOverrideHeader, httpReq.Method)
+		httpReq.Method = http.MethodPost
+	}
+
+	ctx = tflog.SetField(ctx, "method", req.Method)
+	ctx = tflog.SetField(ctx, "url", httpReq.URL.String())
+
+	fields := map[string]interface{}{
+		"body_bytes": len(reqBody),
+		"headers":    RedactHeaders(httpReq.Header, c.sensitiveHeaders()...),
+	}
+	if req.Body != nil {
+		fields["body"] = string(logBody(reqBody, c.Config.DebugHTTPBody))
+	}
+
+	tflog.Debug(ctx, "Sending request", fields)
+
+	send := c.send
+	if req.Retry {
+		send = c.Do
+	}
+
+	httpResp, err := send(httpReq)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(httpResp.Body)
+
+	tflog.Debug(ctx, "Received response", map[string]interface{}{
+		"status": httpResp.StatusCode,
+	})
+
+	expectedStatus := req.ExpectedStatus
+	if len(expectedStatus) == 0 {
+		expectedStatus = DefaultExpectedStatus(req.Method)
+	}
+
+	if !slices.Contains(expectedStatus, httpResp.StatusCode) {
+		return newStatusError(httpResp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	// Keep the beginning of the body, to describe responses that are not
+	// JSON.
+	body := bufio.NewReader(httpResp.Body)
+	prefix, _ := body.Peek(snippetBytes)
+	prefix = bytes.Clone(prefix)
+
+	// Responses decoded into untyped values, such as map[string]any, keep
+	// their numbers as json.Number rather than float64, which cannot hold
+	// every int64. Typed fields are decoded as before.
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+	if c.Config.StrictDecode {
+		dec.DisallowUnknownFields()
+	}
+
+	err = dec.Decode(out)
+	if errors.Is(err, io.EOF) {
+		return ErrEmptyResponse
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && !isJSONContentType(httpResp.Header.Get("Content-Type")) {
+		return &NonJSONResponseError{
+			ContentType: httpResp.Header.Get("Content-Type"),
+			Snippet:     responseSnippet(prefix),
+		}
+	}
+
+	if err != nil && c.Config.StrictDecode && strings.HasPrefix(err.Error(), "json: unknown field") {
+		return fmt.Errorf("response contains a field the provider does not support, "+
+			"the device may be running a newer version: %w", err)
+	}
+
+	return err
+}
+
+// snippetBytes is the number of bytes at the beginning of a response body
+// included in a NonJSONResponseError.
+const snippetBytes = 256
+
+// isJSONContentType returns true if the media type of the Content-Type header
+// is JSON, such as application/json or application/problem+json.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// responseSnippet returns the beginning of a response body on a single line,
+// with runs of whitespace, such as the indentation of HTML, collapsed.
+func responseSnippet(prefix []byte) string {
+	snippet := strings.Join(strings.Fields(strings.ToValidUTF8(string(prefix), "")), " ")
+	if len(prefix) == snippetBytes {
+		snippet += "..."
+	}
+
+	return snippet
+}
+
+// newStatusError returns a StatusError for the response, with the message
+// and field errors of its body when the body is an error response.
+func newStatusError(httpResp *http.Response) *StatusError {
+	statusErr := &StatusError{StatusCode: httpResp.StatusCode}
+
+	var errResp model.ErrorResponse
+	if json.NewDecoder(httpResp.Body).Decode(&errResp) == nil {
+		statusErr.Message = errResp.Message
+		statusErr.Fields = errResp.Fields
+	}
+
+	return statusErr
+}
+
+// setHeaders sets the headers sent with every request, the configured
+// headers and the credentials of the auth scheme.
+func (c *Client) setHeaders(req *http.Request) {
+	for name, value := range c.Config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch {
+	case c.Config.AuthScheme == AuthSchemeBearer:
+		req.Header.Set("Authorization", "Bearer "+c.Config.AuthToken)
+	case c.Config.ApiKey != "":
+		req.Header.Set("x-api-key", c.Config.ApiKey)
+	}
+}
+
+// sensitiveHeaders returns the names of the request headers whose values are
+// redacted in logs.
+func (c *Client) sensitiveHeaders() []string {
+	return append(slices.Clone(DefaultSensitiveHeaders), c.Config.SensitiveHeaders...)
+}
+
+// isSafeMethod returns true if requests with the HTTP method do not change
+// the state of the device.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// drainAndClose reads the remainder of the response body before closing it.
+// The connection is only returned to the pool for reuse once the body has
+// been read to the end, which the JSON decoder does not do when the body has
+// trailing bytes, such as a newline after the value.
+func drainAndClose(body io.ReadCloser) error {
+	_, _ = io.Copy(io.Discard, body)
+
+	return body.Close()
+}
@@ -0,0 +1,564 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestGetJSON_reusesConnection(t *testing.T) {
+	var connections atomic.Int32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Trailing bytes after the JSON value are not read by the decoder.
+		// They must exceed what the transport drains on its own when a body
+		// is closed early.
+		_, _ = w.Write([]byte(`{"message":"ok"}` + strings.Repeat(" ", 1024*1024)))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		var out struct {
+			Message string `json:"message"`
+		}
+
+		if err := client.GetJSON(context.Background(), "/v1/healthz", &out); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected 1 connection to be reused, got %d connections", got)
+	}
+}
+
+func TestSendJSON_expectedStatus(t *testing.T) {
+	testCases := map[string]struct {
+		method         string
+		status         int
+		expectedStatus []int
+		expectError    bool
+	}{
+		"get-ok":             {method: http.MethodGet, status: http.StatusOK},
+		"get-accepted":       {method: http.MethodGet, status: http.StatusAccepted, expectError: true},
+		"post-ok":            {method: http.MethodPost, status: http.StatusOK},
+		"post-created":       {method: http.MethodPost, status: http.StatusCreated},
+		"post-accepted":      {method: http.MethodPost, status: http.StatusAccepted},
+		"post-no-content":    {method: http.MethodPost, status: http.StatusNoContent, expectError: true},
+		"delete-no-content":  {method: http.MethodDelete, status: http.StatusNoContent},
+		"delete-bad-request": {method: http.MethodDelete, status: http.StatusBadRequest, expectError: true},
+		"custom-accepted":    {method: http.MethodGet, status: http.StatusAccepted, expectedStatus: []int{http.StatusAccepted}},
+		"custom-excludes-ok": {method: http.MethodPost, status: http.StatusOK, expectedStatus: []int{http.StatusCreated}, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != testCase.method {
+					t.Errorf("expected method %s, got %s", testCase.method, r.Method)
+				}
+
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			err = client.SendJSON(context.Background(), Request{
+				Method:         testCase.method,
+				Path:           "/v1/movement-plan",
+				ExpectedStatus: testCase.expectedStatus,
+			}, nil)
+
+			if testCase.expectError {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != testCase.status {
+					t.Errorf("expected status error with status %d, got: %v", testCase.status, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSendJSON_validationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"invalid movement plan","status":422,` +
+			`"fields":{"steps[1].speed":"must be at most 1.5","name":"is reserved"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	err = client.SendJSON(context.Background(), Request{Method: http.MethodPost, Path: "/v1/movement-plan"}, nil)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected status error, got: %v", err)
+	}
+
+	expectedFields := map[string]string{"steps[1].speed": "must be at most 1.5", "name": "is reserved"}
+	if !maps.Equal(statusErr.Fields, expectedFields) {
+		t.Errorf("expected fields %v, got %v", expectedFields, statusErr.Fields)
+	}
+
+	expected := "unexpected status code 422: invalid movement plan (name: is reserved, steps[1].speed: must be at most 1.5)"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestSendJSON_body(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("expected JSON content type, got %q", got)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var out struct {
+		Message string `json:"message"`
+	}
+
+	err = client.SendJSON(context.Background(), Request{
+		Method: http.MethodPost,
+		Path:   "/v1/echo",
+		Body:   map[string]string{"message": "hello"},
+	}, &out)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if out.Message != "hello" {
+		t.Errorf("expected message hello, got %q", out.Message)
+	}
+}
+
+func TestSendJSON_untypedNumbers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"features":{"odometer":9007199254740993},"value":42}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var out struct {
+		Features map[string]any `json:"features"`
+		Value    int64          `json:"value"`
+	}
+
+	if err := client.GetJSON(context.Background(), "/v1/device/status", &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// A float64 would round the value to 9007199254740992.
+	if got, ok := out.Features["odometer"].(json.Number); !ok || got.String() != "9007199254740993" {
+		t.Errorf("expected the odometer feature to be decoded as 9007199254740993, got %#v", out.Features["odometer"])
+	}
+
+	if out.Value != 42 {
+		t.Errorf("expected typed value 42, got %d", out.Value)
+	}
+}
+
+func TestSendJSON_typedNumbers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"directions":["forward"],"min_distance":0.5,"max_distance":20,"max_angle":9007199254740993}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	// UseNumber only affects untyped values, so typed model fields decode
+	// as they did before.
+	var out model.MovementCapabilitiesResponse
+	if err := client.GetJSON(context.Background(), "/v1/movement/capabilities", &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if out.MaxAngle != 9007199254740993 {
+		t.Errorf("expected max angle 9007199254740993, got %d", out.MaxAngle)
+	}
+
+	if out.MinDistance != 0.5 || out.MaxDistance != 20 {
+		t.Errorf("expected distance between 0.5 and 20, got %g and %g", out.MinDistance, out.MaxDistance)
+	}
+}
+
+func TestSendJSON_logs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	err = client.SendJSON(ctx, Request{
+		Method: http.MethodPost,
+		Path:   "/v1/wifi",
+		Body:   map[string]string{"ssid": "home", "password": "hunter2"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d: %v", len(entries), entries)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"@message":   "Sending request",
+			"method":     http.MethodPost,
+			"url":        server.URL + "/v1/wifi",
+			"body_bytes": float64(36),
+			"body":       `{"password":"***","ssid":"home"}`,
+		},
+		{
+			"@message": "Received response",
+			"method":   http.MethodPost,
+			"url":      server.URL + "/v1/wifi",
+			"status":   float64(http.StatusAccepted),
+		},
+	}
+
+	for i, fields := range expected {
+		for key, value := range fields {
+			if entries[i][key] != value {
+				t.Errorf("expected log entry %d field %s to be %v, got %v", i, key, value, entries[i][key])
+			}
+		}
+	}
+}
+
+func TestSendJSON_logsIndentedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		// Only the logged body is indented, not the body sent.
+		if expected := `{"password":"hunter2","ssid":"home"}`; string(body) != expected {
+			t.Errorf("expected request body %s, got %s", expected, body)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL, DebugHTTPBody: true})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	err = client.SendJSON(ctx, Request{
+		Method: http.MethodPost,
+		Path:   "/v1/wifi",
+		Body:   map[string]string{"ssid": "home", "password": "hunter2"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	expected := "{\n  \"password\": \"***\",\n  \"ssid\": \"home\"\n}"
+	if entries[0]["body"] != expected {
+		t.Errorf("expected logged body %q, got %q", expected, entries[0]["body"])
+	}
+}
+
+func TestSendJSON_logsSensitiveHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Gateway-Token"); token != "gateway-secret" {
+			t.Errorf("expected X-Gateway-Token header to be sent, got %q", token)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		Address: server.URL,
+		ApiKey:  "api-secret",
+		Headers: map[string]string{
+			"X-Gateway-Token":  "gateway-secret",
+			"X-Request-Source": "terraform",
+		},
+		SensitiveHeaders: []string{"x-gateway-token"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	err = client.SendJSON(ctx, Request{Method: http.MethodGet, Path: "/v1/device/status"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	headers, ok := entries[0]["headers"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected headers in log entry, got: %v", entries[0])
+	}
+
+	expected := map[string]string{
+		"X-Api-Key":        "***",
+		"X-Gateway-Token":  "***",
+		"X-Request-Source": "terraform",
+	}
+
+	for name, value := range expected {
+		if headers[name] != value {
+			t.Errorf("expected logged header %s to be %q, got %v", name, value, headers[name])
+		}
+	}
+
+	if strings.Contains(output.String(), "secret") {
+		t.Errorf("expected sensitive header values to be redacted, got logs: %s", output.String())
+	}
+}
+
+func TestSendJSON_readOnly(t *testing.T) {
+	testCases := map[string]struct {
+		method      string
+		expectError bool
+	}{
+		"get":    {method: http.MethodGet},
+		"head":   {method: http.MethodHead},
+		"post":   {method: http.MethodPost, expectError: true},
+		"put":    {method: http.MethodPut, expectError: true},
+		"patch":  {method: http.MethodPatch, expectError: true},
+		"delete": {method: http.MethodDelete, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL, ReadOnly: true})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			err = client.SendJSON(context.Background(), Request{Method: testCase.method, Path: "/v1/movement-plan"}, nil)
+
+			if testCase.expectError {
+				if !errors.Is(err, ErrReadOnly) {
+					t.Errorf("expected read-only error, got: %v", err)
+				}
+
+				if calls != 0 {
+					t.Errorf("expected no requests, got %d", calls)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSendJSON_methodOverride(t *testing.T) {
+	testCases := map[string]struct {
+		methodOverride bool
+		method         string
+		expectMethod   string
+		expectHeader   string
+	}{
+		"disabled": {
+			method:       http.MethodDelete,
+			expectMethod: http.MethodDelete,
+		},
+		"delete": {
+			methodOverride: true,
+			method:         http.MethodDelete,
+			expectMethod:   http.MethodPost,
+			expectHeader:   http.MethodDelete,
+		},
+		"get": {
+			methodOverride: true,
+			method:         http.MethodGet,
+			expectMethod:   http.MethodPost,
+			expectHeader:   http.MethodGet,
+		},
+		"post": {
+			methodOverride: true,
+			method:         http.MethodPost,
+			expectMethod:   http.MethodPost,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != testCase.expectMethod {
+					t.Errorf("expected %s request, got %s", testCase.expectMethod, r.Method)
+				}
+
+				if got := r.Header.Get(MethodOverrideHeader); got != testCase.expectHeader {
+					t.Errorf("expected %s header %q, got %q", MethodOverrideHeader, testCase.expectHeader, got)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL, MethodOverride: testCase.methodOverride})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			// The expected status codes are those of the logical method.
+			err = client.SendJSON(context.Background(), Request{Method: testCase.method, Path: "/v1/movement-plan"}, nil)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSendJSON_nonJSONResponse(t *testing.T) {
+	testCases := map[string]struct {
+		contentType     string
+		body            string
+		expectNonJSON   bool
+		expectedSnippet string
+	}{
+		"html": {
+			contentType:     "text/html; charset=utf-8",
+			body:            "<!DOCTYPE html>\n<html>\n  <body>\n    <h1>Sign in to the guest network</h1>\n  </body>\n</html>\n",
+			expectNonJSON:   true,
+			expectedSnippet: "<!DOCTYPE html> <html> <body> <h1>Sign in to the guest network</h1> </body> </html>",
+		},
+		"html-truncated": {
+			contentType:     "text/html",
+			body:            "<p>" + strings.Repeat("a", 1024) + "</p>",
+			expectNonJSON:   true,
+			expectedSnippet: "<p>" + strings.Repeat("a", snippetBytes-3) + "...",
+		},
+		"invalid-json": {
+			contentType: "application/json",
+			body:        `{"name":`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", testCase.contentType)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			var out map[string]any
+			err = client.GetJSON(context.Background(), "/v1/device/status", &out)
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			var nonJSONErr *NonJSONResponseError
+			if errors.As(err, &nonJSONErr) != testCase.expectNonJSON {
+				t.Fatalf("expected non-JSON response error: %t, got: %v", testCase.expectNonJSON, err)
+			}
+
+			if !testCase.expectNonJSON {
+				return
+			}
+
+			if nonJSONErr.ContentType != testCase.contentType {
+				t.Errorf("expected content type %q, got %q", testCase.contentType, nonJSONErr.ContentType)
+			}
+
+			if nonJSONErr.Snippet != testCase.expectedSnippet {
+				t.Errorf("expected snippet %q, got %q", testCase.expectedSnippet, nonJSONErr.Snippet)
+			}
+		})
+	}
+}
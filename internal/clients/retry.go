@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultRetryMax is the default maximum number of retries of a request.
+	DefaultRetryMax = 3
+
+	// DefaultRetryWaitMin is the default wait before the first retry, which
+	// doubles with every subsequent retry.
+	DefaultRetryWaitMin = 500 * time.Millisecond
+
+	// retryWaitMax caps the wait between retries.
+	retryWaitMax = 10 * time.Second
+)
+
+// DefaultRetryOnStatus are the status codes of responses that are retried by
+// default, which usually indicate that the device or a proxy in front of it is
+// temporarily unavailable.
+var DefaultRetryOnStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Do sends the request using HttpClient, retrying up to Config.RetryMax times
+// with exponential backoff when the request fails with a transient error.
+//
+// Connection errors and the status codes of Config.RetryOnStatus, or
+// DefaultRetryOnStatus when nil, are considered transient. The response of
+// the final attempt is returned.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	wait := c.Config.RetryWaitMin
+
+	retryOnStatus := c.Config.RetryOnStatus
+	if retryOnStatus == nil {
+		retryOnStatus = DefaultRetryOnStatus
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = body
+		}
+
+		httpResp, err := c.send(req)
+
+		if attempt >= c.Config.RetryMax || !isRetryable(ctx, httpResp, err, retryOnStatus) {
+			return httpResp, err
+		}
+
+		// Release the connection of the failed attempt before retrying.
+		if httpResp != nil {
+			_ = drainAndClose(httpResp.Body)
+		}
+
+		tflog.Debug(ctx, "Retrying request after transient failure", map[string]interface{}{
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		wait = min(wait*2, retryWaitMax)
+	}
+}
+
+// isRetryable returns true if the request failed with a connection error, or
+// with one of the retryOnStatus status codes.
+func isRetryable(ctx context.Context, httpResp *http.Response, err error, retryOnStatus []int) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	// Retrying cannot succeed until the circuit breaker closes.
+	var circuitErr *CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return slices.Contains(retryOnStatus, httpResp.StatusCode)
+}
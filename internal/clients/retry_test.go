@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_retryOnStatus(t *testing.T) {
+	testCases := map[string]struct {
+		retryOnStatus    []int
+		status           int
+		expectedRequests int32
+	}{
+		"default-retried": {
+			status:           http.StatusServiceUnavailable,
+			expectedRequests: 3,
+		},
+		"default-not-retried": {
+			status:           http.StatusInternalServerError,
+			expectedRequests: 1,
+		},
+		"configured-retried": {
+			retryOnStatus:    []int{http.StatusInternalServerError},
+			status:           http.StatusInternalServerError,
+			expectedRequests: 3,
+		},
+		"configured-not-retried": {
+			retryOnStatus:    []int{http.StatusTooManyRequests},
+			status:           http.StatusServiceUnavailable,
+			expectedRequests: 1,
+		},
+		"empty-not-retried": {
+			retryOnStatus:    []int{},
+			status:           http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{
+				Address:                 server.URL,
+				RetryMax:                2,
+				RetryWaitMin:            time.Millisecond,
+				RetryOnStatus:           testCase.retryOnStatus,
+				CircuitBreakerThreshold: -1,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v1/healthz", nil)
+			if err != nil {
+				t.Fatalf("unexpected error creating request: %s", err)
+			}
+
+			httpResp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_ = httpResp.Body.Close()
+
+			if httpResp.StatusCode != testCase.status {
+				t.Errorf("expected status %d, got %d", testCase.status, httpResp.StatusCode)
+			}
+
+			if got := requests.Load(); got != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, got)
+			}
+		})
+	}
+}
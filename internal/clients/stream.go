@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrStreamUnsupported is returned when the Pathfinder API responds to a
+// stream request with a response that is not an event stream, such as a
+// device running a firmware version without streaming support.
+var ErrStreamUnsupported = errors.New("endpoint does not support event streams")
+
+// ErrStreamClosed is returned when the event stream ends before the handler
+// has returned true.
+var ErrStreamClosed = errors.New("event stream closed before completion")
+
+// Event is a Server-Sent Event received from the Pathfinder API.
+type Event struct {
+	// Type of the event, "message" when the event does not set one
+	Type string
+
+	// Data of the event, with the lines of multi-line data joined by "\n"
+	Data string
+}
+
+// StreamEvents sends a GET request to the given path of the Pathfinder API and
+// calls handle with each Server-Sent Event received, until handle returns
+// true or an error, or the context is done, which closes the stream.
+//
+// A StatusError is returned when the response status code is not 200 OK, and
+// ErrStreamUnsupported when the response is not an event stream.
+func (c *Client) StreamEvents(ctx context.Context, path string, handle func(event Event) (bool, error)) error {
+	reqURL, err := JoinURL(c.Config.Address, path)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	c.setHeaders(httpReq)
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	ctx = tflog.SetField(ctx, "method", http.MethodGet)
+	ctx = tflog.SetField(ctx, "url", httpReq.URL.String())
+
+	tflog.Debug(ctx, "Opening event stream")
+
+	httpResp, err := c.send(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return newStatusError(httpResp)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		return ErrStreamUnsupported
+	}
+
+	event := Event{}
+	var data []string
+
+	scanner := bufio.NewScanner(httpResp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the event, as defined by the
+		// Server-Sent Events specification.
+		if line == "" {
+			if data == nil {
+				event = Event{}
+				continue
+			}
+
+			if event.Type == "" {
+				event.Type = "message"
+			}
+			event.Data = strings.Join(data, "\n")
+
+			done, err := handle(event)
+			if err != nil || done {
+				return err
+			}
+
+			event = Event{}
+			data = nil
+
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading event stream: %w", err)
+	}
+
+	return ErrStreamClosed
+}
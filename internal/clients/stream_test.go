@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStreamEvents(t *testing.T) {
+	testCases := map[string]struct {
+		contentType string
+		status      int
+		body        string
+		expected    []Event
+		expectError error
+	}{
+		"complete": {
+			body: ": keep-alive\n\n" +
+				"event: progress\ndata: {\"step\":0}\n\n" +
+				"data: first line\ndata: second line\n\n" +
+				"event: complete\ndata: {}\n\n" +
+				"event: progress\ndata: {\"step\":1}\n\n",
+			expected: []Event{
+				{Type: "progress", Data: `{"step":0}`},
+				{Type: "message", Data: "first line\nsecond line"},
+				{Type: "complete", Data: "{}"},
+			},
+		},
+		"closed": {
+			body:        "event: progress\ndata: {\"step\":0}\n\n",
+			expected:    []Event{{Type: "progress", Data: `{"step":0}`}},
+			expectError: ErrStreamClosed,
+		},
+		"unsupported": {
+			contentType: "application/json",
+			body:        `{"moving":true}`,
+			expectError: ErrStreamUnsupported,
+		},
+		"not-found": {
+			status:      http.StatusNotFound,
+			expectError: &StatusError{StatusCode: http.StatusNotFound},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+					t.Errorf("expected Accept header text/event-stream, got %q", accept)
+				}
+
+				contentType := testCase.contentType
+				if contentType == "" {
+					contentType = "text/event-stream"
+				}
+
+				w.Header().Set("Content-Type", contentType)
+
+				if testCase.status != 0 {
+					w.WriteHeader(testCase.status)
+				}
+
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{Address: server.URL})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			var received []Event
+			err = client.StreamEvents(context.Background(), "/v1/movement/stream", func(event Event) (bool, error) {
+				received = append(received, event)
+				return event.Type == "complete", nil
+			})
+
+			var statusErr *StatusError
+			switch expected := testCase.expectError.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			case *StatusError:
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != expected.StatusCode {
+					t.Fatalf("expected status error with status %d, got: %v", expected.StatusCode, err)
+				}
+			default:
+				if !errors.Is(err, expected) {
+					t.Fatalf("expected error %s, got: %v", expected, err)
+				}
+			}
+
+			if !slices.Equal(received, testCase.expected) {
+				t.Errorf("expected events %v, got %v", testCase.expected, received)
+			}
+		})
+	}
+}
+
+func TestStreamEvents_contextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{Address: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = client.StreamEvents(ctx, "/v1/movement/stream", func(event Event) (bool, error) {
+		return false, nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %s, got: %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// JoinURL returns the URL of the API endpoint at path, such as
+// /v1/device/status, relative to the address of the Pathfinder API. Any path
+// of the address is kept, and slashes between the address and the path are
+// normalized, so that the address may or may not end with a slash. A query
+// string in path is kept as is.
+func JoinURL(address, path string) (string, error) {
+	base, err := url.Parse(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %w", err)
+	}
+
+	endpoint, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+
+	// Joining onto an empty path would result in a relative path.
+	if base.Path == "" {
+		base.Path = "/"
+	}
+
+	joined := base.JoinPath(endpoint.Path)
+	joined.RawQuery = endpoint.RawQuery
+
+	return joined.String(), nil
+}
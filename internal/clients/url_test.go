@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"testing"
+)
+
+func TestJoinURL(t *testing.T) {
+	testCases := map[string]struct {
+		address     string
+		path        string
+		expected    string
+		expectError bool
+	}{
+		"address": {
+			address:  "https://rover.local",
+			path:     "/v1/device/status",
+			expected: "https://rover.local/v1/device/status",
+		},
+		"address-trailing-slash": {
+			address:  "https://rover.local/",
+			path:     "/v1/device/status",
+			expected: "https://rover.local/v1/device/status",
+		},
+		"path-without-leading-slash": {
+			address:  "https://rover.local",
+			path:     "v1/device/status",
+			expected: "https://rover.local/v1/device/status",
+		},
+		"address-with-path": {
+			address:  "https://gateway.local/rovers/1/",
+			path:     "/v1/device/status",
+			expected: "https://gateway.local/rovers/1/v1/device/status",
+		},
+		"query": {
+			address:  "https://rover.local",
+			path:     "/v1/device/status?fields=name",
+			expected: "https://rover.local/v1/device/status?fields=name",
+		},
+		"empty-address": {
+			address:  "",
+			path:     "/v1/device/status",
+			expected: "/v1/device/status",
+		},
+		"invalid-address": {
+			address:     "http://rover\x7f.local",
+			path:        "/v1/device/status",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := JoinURL(testCase.address, testCase.path)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
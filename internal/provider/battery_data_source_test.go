@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatteryDataSource_reusesConnections(t *testing.T) {
+	var connections atomic.Int32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Trailing whitespace keeps the decoder from reading the body to the
+		// end, so its connection is only released once the body is closed.
+		_, _ = w.Write([]byte(`{"value":95,"unit":"%"}` + strings.Repeat(" ", 4096)))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := testClient(t, server)
+
+	// Response bodies that are never closed hold on to their connection, so
+	// every read would open a new one.
+	for i := 0; i < 20; i++ {
+		resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+	}
+
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected 1 connection to be reused, got %d connections", got)
+	}
+}
+
+func TestBatteryDataSource_strictDecode(t *testing.T) {
+	testCases := map[string]struct {
+		strictDecode bool
+		expectError  bool
+	}{
+		"lenient": {
+			strictDecode: false,
+		},
+		"strict": {
+			strictDecode: true,
+			expectError:  true,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":95,"unit":"%","temperature":31}`))
+	}))
+	defer server.Close()
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := testClient(t, server)
+			client.Config.StrictDecode = testCase.strictDecode
+
+			resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, `unknown field "temperature"`) {
+					t.Errorf("expected error detail to name the unknown field, got: %s", detail)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
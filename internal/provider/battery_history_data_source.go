@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BatteryHistoryDataSource{}
+
+func NewBatteryHistoryDataSource() datasource.DataSource {
+	return &BatteryHistoryDataSource{}
+}
+
+// BatteryHistoryDataSource defines the data source implementation.
+type BatteryHistoryDataSource struct {
+	client *clients.Client
+}
+
+// BatteryHistoryDataSourceModel describes the data source data model.
+type BatteryHistoryDataSourceModel struct {
+	Limit    types.Int64           `tfsdk:"limit"`
+	Since    types.String          `tfsdk:"since"`
+	Readings []BatteryReadingModel `tfsdk:"readings"`
+}
+
+type BatteryReadingModel struct {
+	Timestamp types.String `tfsdk:"timestamp"`
+	Unit      types.String `tfsdk:"unit"`
+	Value     types.Int64  `tfsdk:"value"`
+}
+
+func (d *BatteryHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_battery_history"
+}
+
+func (d *BatteryHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the history of readings of the on-board battery, such as to predict when it runs low.",
+
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of readings to return.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"since": schema.StringAttribute{
+				MarkdownDescription: "Only return readings taken at or after this RFC 3339 timestamp, such as `2024-01-02T15:04:05Z`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.RFC3339(),
+				},
+			},
+			"readings": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"timestamp": schema.StringAttribute{
+							Description: "Time the reading was taken, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"unit": schema.StringAttribute{
+							Description: "Unit of the battery value.",
+							Computed:    true,
+						},
+						"value": schema.Int64Attribute{
+							Description: "Battery value.",
+							Computed:    true,
+						},
+					},
+				},
+				MarkdownDescription: "Readings of the battery, in the order returned by the device. Empty when there is no history.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BatteryHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *BatteryHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BatteryHistoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Limit.IsNull() {
+		query.Set("limit", strconv.FormatInt(data.Limit.ValueInt64(), 10))
+	}
+	if !data.Since.IsNull() {
+		query.Set("since", data.Since.ValueString())
+	}
+
+	path := "/v1/device/battery/history"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var readResp []model.BatteryHistoryItem
+	err := d.client.GetJSON(ctx, path, &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Iterate over the response and convert it to the model. The list is
+	// never nil, so that an empty history is an empty list rather than null.
+	readings := make([]BatteryReadingModel, len(readResp))
+	for i := range readResp {
+		readings[i] = BatteryReadingModel{
+			Timestamp: flattenTimestamp(readResp[i].Timestamp),
+			Unit:      types.StringValue(readResp[i].Unit),
+			Value:     types.Int64Value(int64(readResp[i].Value)),
+		}
+	}
+
+	data.Readings = readings
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBatteryHistoryDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		config        BatteryHistoryDataSourceModel
+		body          string
+		expectedQuery string
+		expected      []BatteryReadingModel
+	}{
+		"all": {
+			config: BatteryHistoryDataSourceModel{},
+			body:   `[{"timestamp":"2024-01-02T15:00:00Z","unit":"%","value":95},{"timestamp":"2024-01-02T16:00:00Z","unit":"%","value":"90"}]`,
+			expected: []BatteryReadingModel{
+				{Timestamp: types.StringValue("2024-01-02T15:00:00Z"), Unit: types.StringValue("%"), Value: types.Int64Value(95)},
+				{Timestamp: types.StringValue("2024-01-02T16:00:00Z"), Unit: types.StringValue("%"), Value: types.Int64Value(90)},
+			},
+		},
+		"filtered": {
+			config: BatteryHistoryDataSourceModel{
+				Limit: types.Int64Value(1),
+				Since: types.StringValue("2024-01-02T16:00:00Z"),
+			},
+			body:          `[{"timestamp":"2024-01-02T16:00:00Z","unit":"%","value":90}]`,
+			expectedQuery: "limit=1&since=2024-01-02T16%3A00%3A00Z",
+			expected: []BatteryReadingModel{
+				{Timestamp: types.StringValue("2024-01-02T16:00:00Z"), Unit: types.StringValue("%"), Value: types.Int64Value(90)},
+			},
+		},
+		"empty": {
+			config:   BatteryHistoryDataSourceModel{},
+			body:     `[]`,
+			expected: []BatteryReadingModel{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/device/battery/history" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				if r.URL.RawQuery != testCase.expectedQuery {
+					t.Errorf("expected query %q, got %q", testCase.expectedQuery, r.URL.RawQuery)
+				}
+
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewBatteryHistoryDataSource(), testClient(t, server), &testCase.config)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data BatteryHistoryDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.Readings == nil {
+				t.Fatal("expected readings to be a list, got null")
+			}
+
+			if len(data.Readings) != len(testCase.expected) {
+				t.Fatalf("expected %d readings, got %d", len(testCase.expected), len(data.Readings))
+			}
+
+			for i, reading := range data.Readings {
+				if reading != testCase.expected[i] {
+					t.Errorf("expected reading %d to be %v, got %v", i, testCase.expected[i], reading)
+				}
+			}
+		})
+	}
+}
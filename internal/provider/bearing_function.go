@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &BearingFunction{}
+
+func NewBearingFunction() function.Function {
+	return &BearingFunction{}
+}
+
+// BearingFunction defines the function implementation.
+type BearingFunction struct{}
+
+func (f *BearingFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "bearing"
+}
+
+func (f *BearingFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the heading from one point to another.",
+		MarkdownDescription: "Computes the heading in degrees from the point (`x1`, `y1`) to the point (`x2`, `y2`), " +
+			"in the range [0, 360). Headings follow the convention of the `absolute` coordinate mode of the " +
+			"`pathfinder_movement` resource: they are clockwise from the positive Y axis, so that a heading of 0 " +
+			"faces along the positive Y axis and a heading of 90 faces along the positive X axis.\n\n" +
+			"The heading is not rounded, while the device only accepts whole angles. The bearing between " +
+			"identical points is undefined and returns an error.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "x1",
+				MarkdownDescription: "X coordinate of the point to compute the heading from, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "y1",
+				MarkdownDescription: "Y coordinate of the point to compute the heading from, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "x2",
+				MarkdownDescription: "X coordinate of the point to compute the heading to, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "y2",
+				MarkdownDescription: "Y coordinate of the point to compute the heading to, in meters.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *BearingFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var x1, y1, x2, y2 float64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &x1, &y1, &x2, &y2))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if x1 == x2 && y1 == y2 {
+		resp.Error = function.NewFuncError("the bearing between identical points is undefined")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, bearing(x1, y1, x2, y2)))
+}
+
+// bearing returns the heading in degrees from the point (x1, y1) to the
+// point (x2, y2), clockwise from the positive Y axis like movementPosition,
+// in the range [0, 360). The bearing between identical points is 0.
+func bearing(x1, y1, x2, y2 float64) float64 {
+	return normalizeHeading(math.Atan2(x2-x1, y2-y1) * 180 / math.Pi)
+}
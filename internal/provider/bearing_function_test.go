@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBearingFunction(t *testing.T) {
+	testCases := map[string]struct {
+		x1, y1, x2, y2 float64
+		expected       float64
+		expectError    bool
+	}{
+		"positive-y":      {x2: 0, y2: 1, expected: 0},
+		"positive-x":      {x2: 1, y2: 0, expected: 90},
+		"negative-y":      {x2: 0, y2: -1, expected: 180},
+		"negative-x":      {x2: -1, y2: 0, expected: 270},
+		"diagonal":        {x2: 1, y2: 1, expected: 45},
+		"diagonal-behind": {x2: -1, y2: -1, expected: 225},
+		"offset-origin":   {x1: 2, y1: 3, x2: 2, y2: 1, expected: 180},
+		"identical":       {x1: 1, y1: 1, x2: 1, y2: 1, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.Float64Unknown()),
+			}
+
+			NewBearingFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.Float64Value(testCase.x1),
+					types.Float64Value(testCase.y1),
+					types.Float64Value(testCase.x2),
+					types.Float64Value(testCase.y2),
+				}),
+			}, resp)
+
+			if (resp.Error != nil) != testCase.expectError {
+				t.Fatalf("expected error %t, got: %v", testCase.expectError, resp.Error)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			got := resp.Result.Value().(types.Float64).ValueFloat64()
+			if math.Abs(got-testCase.expected) > 1e-9 {
+				t.Errorf("expected %g, got %g", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BroadcastMovementResource{}
+var _ resource.ResourceWithValidateConfig = &BroadcastMovementResource{}
+var _ resource.ResourceWithModifyPlan = &BroadcastMovementResource{}
+
+func NewBroadcastMovementResource() resource.Resource {
+	return &BroadcastMovementResource{}
+}
+
+// BroadcastMovementResource defines the resource implementation.
+type BroadcastMovementResource struct {
+	client *clients.Client
+}
+
+// BroadcastMovementResourceModel describes the resource data model.
+type BroadcastMovementResourceModel struct {
+	Id          types.String         `tfsdk:"id"`
+	Name        types.String         `tfsdk:"name"`
+	Addresses   []string             `tfsdk:"addresses"`
+	Concurrency types.Int64          `tfsdk:"concurrency"`
+	Submitted   types.Map            `tfsdk:"submitted"`
+	Steps       []MovementStepsModel `tfsdk:"steps"`
+}
+
+// defaultBroadcastConcurrency is the maximum number of devices a movement
+// plan is sent to at once when no concurrency is configured.
+const defaultBroadcastConcurrency = 4
+
+func (r *BroadcastMovementResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_broadcast_movement"
+}
+
+func (r *BroadcastMovementResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Submits the same movement plan to several devices concurrently, such as a fleet of devices. " +
+			"Every device is sent requests with the configuration of the provider, except for its address. " +
+			"Requests to every device count towards the same `max_concurrent_requests` and `requests_per_second` limits. " +
+			"If the plan fails to submit to some devices, the devices it was submitted to are recorded in `submitted`, " +
+			"and the next apply only submits the plan to the devices that failed. " +
+			"Devices removed from `addresses` keep the movement plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the movement plan to execute. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"addresses": schema.ListAttribute{
+				MarkdownDescription: "Addresses of the Pathfinder APIs of the devices to submit the movement plan to, " +
+					"such as `https://rover-1.local`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of devices the movement plan is sent to at once. Defaults to `%d`.", defaultBroadcastConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"submitted": schema.MapAttribute{
+				MarkdownDescription: "Indicates for each device, keyed by address, if the movement plan has been submitted to it.",
+				ElementType:         types.BoolType,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"steps": movementStepsBlock(),
+		},
+	}
+}
+
+func (r *BroadcastMovementResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The steps are read as values, as they may not be known yet.
+	var steps types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("steps"), &steps)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The broadcast does not define waypoints for steps to move to, and the
+	// devices may not start at the same position.
+	for i, element := range steps.Elements() {
+		step, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		if waypoint, ok := step.Attributes()["waypoint"].(types.String); ok && !waypoint.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("steps").AtListIndex(i).AtName("waypoint"),
+				"Unsupported Movement Step Waypoint",
+				"Steps of a broadcast movement cannot move to waypoints, use the pathfinder_movement resource instead.",
+			)
+		}
+	}
+}
+
+func (r *BroadcastMovementResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// ModifyPlan plans an update when a device recorded in state has not been
+// sent the movement plan, so that the next apply retries the devices that
+// failed.
+func (r *BroadcastMovementResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to retry when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var submitted map[string]bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("submitted"), &submitted)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, done := range submitted {
+		if !done {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("submitted"), types.MapUnknown(types.BoolType))...)
+			return
+		}
+	}
+}
+
+func (r *BroadcastMovementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data BroadcastMovementResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	submitted, errs := r.submit(ctx, data, nil, &resp.Diagnostics)
+
+	// An error would taint the resource, so that the next apply replaces it
+	// and submits the plan to every device again. The devices the plan was
+	// submitted to are kept in state instead, and the next apply retries
+	// the remaining devices.
+	if !anySubmitted(submitted) {
+		addBroadcastErrors(&resp.Diagnostics, data.Addresses, errs, "Unable to Create Resource", "")
+		return
+	}
+
+	addBroadcastWarnings(&resp.Diagnostics, data.Addresses, errs)
+
+	r.setState(ctx, &data, submitted, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the resource as it is, as the devices do not report which
+// movement plans they have been sent.
+func (r *BroadcastMovementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *BroadcastMovementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	var data, state BroadcastMovementResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	submitted, errs := r.submit(ctx, data, &state, &resp.Diagnostics)
+
+	// The state of an update is saved even when it fails, so the devices the
+	// plan was submitted to are not sent it again.
+	r.setState(ctx, &data, submitted, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addBroadcastErrors(&resp.Diagnostics, data.Addresses, errs, "Unable to Update Resource",
+		" The movement plan is submitted to the device by the next apply.")
+}
+
+func (r *BroadcastMovementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "delete")
+		return
+	}
+
+	var data BroadcastMovementResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Transient errors are retried, if the final attempt fails for any device
+	// the resource is kept in state so that the deletion can be retried.
+	// Devices that do not have the movement plan, reported by HTTP 404 Not
+	// Found, are treated as already deleted.
+	errs := r.broadcast(ctx, data.Addresses, data.Concurrency, func(ctx context.Context, client *clients.Client) error {
+		err := client.SendJSON(ctx, clients.Request{
+			Method: http.MethodDelete,
+			Path:   client.MovementPath(),
+			Retry:  true,
+		}, nil)
+
+		if clients.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	})
+
+	addBroadcastErrors(&resp.Diagnostics, data.Addresses, errs, "Unable to Delete Resource", "")
+}
+
+// submit submits the movement plan to the devices concurrently, and returns
+// whether the plan has been submitted to each device, keyed by address, and
+// the error of each device it failed to submit to. Devices the plan was
+// submitted to according to state, and whose steps have not changed since,
+// are skipped.
+func (r *BroadcastMovementResource) submit(ctx context.Context, data BroadcastMovementResourceModel, state *BroadcastMovementResourceModel, diags *diag.Diagnostics) (map[string]bool, map[string]error) {
+	var previousSubmitted map[string]bool
+	if state != nil && movementStepsEqual(state.Steps, data.Steps) {
+		// The map is always known in state, so converting it cannot fail.
+		state.Submitted.ElementsAs(ctx, &previousSubmitted, false)
+	}
+
+	submitted := make(map[string]bool, len(data.Addresses))
+	var pending []string
+	for _, address := range data.Addresses {
+		submitted[address] = previousSubmitted[address]
+		if !submitted[address] {
+			pending = append(pending, address)
+		}
+	}
+
+	// The plan is clamped once rather than for every device, so that every
+	// clamped step is only warned about once.
+	submitReq := expandMovementRequest(MovementResourceModel{
+		Name:    data.Name,
+		Persist: types.BoolNull(),
+		Steps:   data.Steps,
+	})
+	if r.client.Config.SafeMode {
+		clampSafeModeSteps(submitReq.Name, submitReq.Steps, diags)
+	}
+
+	errs := r.broadcast(ctx, pending, data.Concurrency, func(ctx context.Context, client *clients.Client) error {
+		return client.SendJSON(ctx, clients.Request{
+			Method: http.MethodPost,
+			Path:   client.MovementPath(),
+			Body:   submitReq,
+		}, nil)
+	})
+
+	for _, address := range pending {
+		submitted[address] = errs[address] == nil
+	}
+
+	return submitted, errs
+}
+
+// broadcast calls send concurrently with a client for each of the
+// addresses, at most concurrency at a time, and returns the errors of the
+// devices that failed keyed by address. Every client has the configuration
+// of the provider, except for its address, and shares the request limits of
+// the provider client.
+func (r *BroadcastMovementResource) broadcast(ctx context.Context, addresses []string, concurrency types.Int64, send func(ctx context.Context, client *clients.Client) error) map[string]error {
+	limit := defaultBroadcastConcurrency
+	if !concurrency.IsNull() {
+		limit = int(concurrency.ValueInt64())
+	}
+
+	errs := make([]error, len(addresses))
+
+	// Each device records its own error rather than returning it, so that a
+	// single unavailable device does not cancel the others.
+	var g errgroup.Group
+	g.SetLimit(limit)
+
+	for i, address := range addresses {
+		g.Go(func() error {
+			client, err := r.client.ForAddress(address)
+			if err != nil {
+				errs[i] = err
+				return nil
+			}
+
+			errs[i] = send(ctx, client)
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	failed := map[string]error{}
+	for i, err := range errs {
+		if err != nil {
+			failed[addresses[i]] = err
+		}
+	}
+
+	return failed
+}
+
+// setState sets the computed attributes of the model from the devices the
+// movement plan was submitted to.
+func (r *BroadcastMovementResource) setState(ctx context.Context, data *BroadcastMovementResourceModel, submitted map[string]bool, diags *diag.Diagnostics) {
+	var d diag.Diagnostics
+	data.Id = types.StringValue(data.Name.ValueString())
+	data.Submitted, d = types.MapValueFrom(ctx, types.BoolType, submitted)
+	diags.Append(d...)
+}
+
+// addBroadcastErrors adds an error for each device that failed, on its
+// index in addresses. The suffix is appended to the first sentence of
+// every error.
+func addBroadcastErrors(diags *diag.Diagnostics, addresses []string, errs map[string]error, summary, suffix string) {
+	for i, address := range addresses {
+		err, ok := errs[address]
+		if !ok {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root("addresses").AtListIndex(i),
+			summary,
+			fmt.Sprintf("An unexpected error occurred while sending the movement plan request to the device at %s.%s "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: %s", address, suffix, err),
+		)
+	}
+}
+
+// addBroadcastWarnings adds a warning for each device the movement plan was
+// not submitted to, on its index in addresses.
+func addBroadcastWarnings(diags *diag.Diagnostics, addresses []string, errs map[string]error) {
+	for i, address := range addresses {
+		err, ok := errs[address]
+		if !ok {
+			continue
+		}
+
+		diags.AddAttributeWarning(
+			path.Root("addresses").AtListIndex(i),
+			"Movement Plan Not Submitted to Device",
+			fmt.Sprintf("The movement plan was not submitted to the device at %s, it is retried by the next apply.\n\n"+
+				"HTTP Error: %s", address, err),
+		)
+	}
+}
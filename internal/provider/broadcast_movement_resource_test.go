@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testBroadcastDevice is a mock device that records the movement plans it
+// is sent, and fails every request with status while it is set.
+type testBroadcastDevice struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	received []string
+}
+
+func newTestBroadcastDevice(t *testing.T) *testBroadcastDevice {
+	t.Helper()
+
+	device := &testBroadcastDevice{}
+	device.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		device.mu.Lock()
+		defer device.mu.Unlock()
+
+		var req model.MovementRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		device.received = append(device.received, r.Method+" "+req.Name)
+
+		if device.status != 0 {
+			w.WriteHeader(device.status)
+			_, _ = w.Write([]byte(`{"message":"device is busy"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"moving":true}`))
+	}))
+	t.Cleanup(device.server.Close)
+
+	return device
+}
+
+func testBroadcastMovementResourceModel(devices ...*testBroadcastDevice) *BroadcastMovementResourceModel {
+	data := &BroadcastMovementResourceModel{
+		Id:          types.StringUnknown(),
+		Name:        types.StringValue("patrol"),
+		Concurrency: types.Int64Null(),
+		Submitted:   types.MapUnknown(types.BoolType),
+		Steps:       []MovementStepsModel{testLinearStep("forward", 1)},
+	}
+
+	for _, device := range devices {
+		data.Addresses = append(data.Addresses, device.server.URL)
+	}
+
+	return data
+}
+
+func TestBroadcastMovementResource_partialApply(t *testing.T) {
+	devices := []*testBroadcastDevice{newTestBroadcastDevice(t), newTestBroadcastDevice(t), newTestBroadcastDevice(t)}
+	devices[1].status = http.StatusConflict
+
+	client := testClient(t, devices[0].server)
+	plan := testBroadcastMovementResourceModel(devices...)
+
+	// The second device fails, so the plan is recorded as submitted to the
+	// others only.
+	createResp := testCreateResource(t, NewBroadcastMovementResource(), client, plan)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", createResp.Diagnostics)
+	}
+
+	warnings := createResp.Diagnostics.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected a warning for the failed device, got: %v", createResp.Diagnostics)
+	}
+
+	if warnPath := warnings[0].(diag.DiagnosticWithPath).Path(); !warnPath.Equal(path.Root("addresses").AtListIndex(1)) {
+		t.Errorf("expected the warning on the second address, got: %s", warnPath)
+	}
+
+	for i, device := range devices {
+		if len(device.received) != 1 || device.received[0] != "POST patrol" {
+			t.Errorf("expected device %d to be sent the plan once, got: %v", i, device.received)
+		}
+	}
+
+	var state BroadcastMovementResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(context.Background(), &state)...)
+
+	var submitted map[string]bool
+	state.Submitted.ElementsAs(context.Background(), &submitted, false)
+
+	for i, device := range devices {
+		if expected := i != 1; submitted[device.server.URL] != expected {
+			t.Errorf("expected device %d submitted: %t, got: %t", i, expected, submitted[device.server.URL])
+		}
+	}
+
+	// Once the device recovers, the re-apply only submits the plan to it.
+	devices[1].status = 0
+
+	updateResp := testUpdateResource(t, NewBroadcastMovementResource(), client, &state, plan)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	for i, expected := range []int{1, 2, 1} {
+		if len(devices[i].received) != expected {
+			t.Errorf("expected device %d to be sent %d requests, got: %v", i, expected, devices[i].received)
+		}
+	}
+
+	updateResp.Diagnostics.Append(updateResp.State.Get(context.Background(), &state)...)
+	state.Submitted.ElementsAs(context.Background(), &submitted, false)
+
+	for address, done := range submitted {
+		if !done {
+			t.Errorf("expected the plan to be submitted to %s", address)
+		}
+	}
+}
+
+func TestBroadcastMovementResource_Create_allFail(t *testing.T) {
+	devices := []*testBroadcastDevice{newTestBroadcastDevice(t), newTestBroadcastDevice(t)}
+	for _, device := range devices {
+		device.status = http.StatusConflict
+	}
+
+	resp := testCreateResource(t, NewBroadcastMovementResource(), testClient(t, devices[0].server), testBroadcastMovementResourceModel(devices...))
+
+	if resp.Diagnostics.ErrorsCount() != len(devices) {
+		t.Fatalf("expected an error for each device, got: %v", resp.Diagnostics)
+	}
+
+	for i, d := range resp.Diagnostics.Errors() {
+		if errPath := d.(diag.DiagnosticWithPath).Path(); !errPath.Equal(path.Root("addresses").AtListIndex(i)) {
+			t.Errorf("expected error %d on address %d, got: %s", i, i, errPath)
+		}
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state to be saved, got: %s", resp.State.Raw)
+	}
+}
+
+func TestBroadcastMovementResource_Update_stepsChanged(t *testing.T) {
+	devices := []*testBroadcastDevice{newTestBroadcastDevice(t), newTestBroadcastDevice(t)}
+	client := testClient(t, devices[0].server)
+
+	createResp := testCreateResource(t, NewBroadcastMovementResource(), client, testBroadcastMovementResourceModel(devices...))
+
+	var state BroadcastMovementResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(context.Background(), &state)...)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Changed steps are submitted to every device again.
+	plan := testBroadcastMovementResourceModel(devices...)
+	plan.Steps = []MovementStepsModel{testLinearStep("backward", 1)}
+
+	updateResp := testUpdateResource(t, NewBroadcastMovementResource(), client, &state, plan)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	for i, device := range devices {
+		if len(device.received) != 2 {
+			t.Errorf("expected device %d to be sent the plan twice, got: %v", i, device.received)
+		}
+	}
+}
+
+func TestBroadcastMovementResource_Create_concurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"moving":true}`))
+	}))
+	defer server.Close()
+
+	plan := testBroadcastMovementResourceModel()
+	plan.Concurrency = types.Int64Value(2)
+
+	// Every device is the same server under a different address.
+	for i := 0; i < 6; i++ {
+		plan.Addresses = append(plan.Addresses, fmt.Sprintf("%s/rovers/%d", server.URL, i))
+	}
+
+	resp := testCreateResource(t, NewBroadcastMovementResource(), testClient(t, server), plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got: %d", maxInFlight.Load())
+	}
+}
+
+func TestBroadcastMovementResource_Delete(t *testing.T) {
+	devices := []*testBroadcastDevice{newTestBroadcastDevice(t), newTestBroadcastDevice(t), newTestBroadcastDevice(t)}
+
+	// A device without the plan is treated as already deleted.
+	devices[1].status = http.StatusNotFound
+	devices[2].status = http.StatusConflict
+
+	state := testBroadcastMovementResourceModel(devices...)
+	state.Id = types.StringValue("patrol")
+	state.Submitted = types.MapValueMust(types.BoolType, map[string]attr.Value{})
+
+	resp := testDeleteResource(t, NewBroadcastMovementResource(), testClient(t, devices[0].server), state)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected an error for the conflicting device, got: %v", resp.Diagnostics)
+	}
+
+	if errPath := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(path.Root("addresses").AtListIndex(2)) {
+		t.Errorf("expected the error on the third address, got: %s", errPath)
+	}
+
+	for i, device := range devices {
+		if len(device.received) == 0 || device.received[0] != "DELETE " {
+			t.Errorf("expected device %d to be sent a deletion, got: %v", i, device.received)
+		}
+	}
+}
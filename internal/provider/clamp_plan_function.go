@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ClampPlanFunction{}
+
+func NewClampPlanFunction() function.Function {
+	return &ClampPlanFunction{}
+}
+
+// ClampPlanFunction defines the function implementation.
+type ClampPlanFunction struct{}
+
+// ClampPlanLimitsModel describes the limits argument of the function.
+type ClampPlanLimitsModel struct {
+	MaxDistance types.Float64 `tfsdk:"max_distance"`
+	MaxAngle    types.Int64   `tfsdk:"max_angle"`
+	MaxSteps    types.Int64   `tfsdk:"max_steps"`
+}
+
+// clampPlanMaxDroppedFraction is the largest fraction of the steps of a plan
+// that clamp_plan drops to fit max_steps. Dropping more would leave little
+// of the original plan, so it is an error instead.
+const clampPlanMaxDroppedFraction = 0.5
+
+func (f *ClampPlanFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "clamp_plan"
+}
+
+func (f *ClampPlanFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Clamp movement steps to the limits of a device.",
+		MarkdownDescription: "Clamps a list of movement steps to the limits of a device and returns the clamped steps, " +
+			"so that generated plans, such as the result of `parse_path`, can be sanitized before they are applied.\n\n" +
+			"- `distance` of `forward` and `backward` steps is reduced to `max_distance`.\n" +
+			"- `angle` of `left` and `right` steps is reduced to `max_angle`. The angle of `forward` and `backward` steps " +
+			"is a heading rather than an amount to rotate by, so it is not clamped.\n" +
+			"- Steps after the first `max_steps` are dropped. An error is returned instead if more than half of the steps " +
+			"would be dropped, as little of the plan would remain.\n\n" +
+			"Limits that are `null` are not applied. Steps with a `waypoint` are returned unchanged, as their distance " +
+			"and angle are only computed by the `pathfinder_movement` resource.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name: "steps",
+				MarkdownDescription: "Movement steps to clamp, as objects with the `angle`, `direction`, `distance`, " +
+					"`speed`, and `waypoint` attributes. `distance`, `speed`, and `waypoint` may be `null`.",
+				ElementType: types.ObjectType{
+					AttrTypes: movementStepAttrTypes,
+				},
+			},
+			function.ObjectParameter{
+				Name: "limits",
+				MarkdownDescription: "Limits of the device, as an object with the `max_distance` attribute in meters, the " +
+					"`max_angle` attribute in degrees, and the `max_steps` attribute. Each attribute may be `null`.",
+				AttributeTypes: map[string]attr.Type{
+					"max_distance": types.Float64Type,
+					"max_angle":    types.Int64Type,
+					"max_steps":    types.Int64Type,
+				},
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: movementStepAttrTypes,
+			},
+		},
+	}
+}
+
+func (f *ClampPlanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var steps []MovementStepsModel
+	var limits ClampPlanLimitsModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &steps, &limits))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateClampPlanLimits(limits); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	clamped, err := clampPlan(steps, limits)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, clamped))
+}
+
+// validateClampPlanLimits returns an error if a limit that is set cannot be
+// met by any step.
+func validateClampPlanLimits(limits ClampPlanLimitsModel) error {
+	if !limits.MaxDistance.IsNull() && limits.MaxDistance.ValueFloat64() <= 0 {
+		return fmt.Errorf("max_distance must be positive, got: %g", limits.MaxDistance.ValueFloat64())
+	}
+
+	if !limits.MaxAngle.IsNull() && limits.MaxAngle.ValueInt64() < 0 {
+		return fmt.Errorf("max_angle must not be negative, got: %d", limits.MaxAngle.ValueInt64())
+	}
+
+	if !limits.MaxSteps.IsNull() && limits.MaxSteps.ValueInt64() < 1 {
+		return fmt.Errorf("max_steps must be at least 1, got: %d", limits.MaxSteps.ValueInt64())
+	}
+
+	return nil
+}
+
+// clampPlan returns the steps clamped to the limits, with the steps beyond
+// max_steps dropped. It returns an error if more than
+// clampPlanMaxDroppedFraction of the steps would be dropped.
+func clampPlan(steps []MovementStepsModel, limits ClampPlanLimitsModel) ([]MovementStepsModel, error) {
+	if !limits.MaxSteps.IsNull() && int64(len(steps)) > limits.MaxSteps.ValueInt64() {
+		dropped := int64(len(steps)) - limits.MaxSteps.ValueInt64()
+		if float64(dropped) > clampPlanMaxDroppedFraction*float64(len(steps)) {
+			return nil, fmt.Errorf("clamping the plan to max_steps %d would drop %d of its %d steps, more than half of the plan",
+				limits.MaxSteps.ValueInt64(), dropped, len(steps))
+		}
+
+		steps = steps[:limits.MaxSteps.ValueInt64()]
+	}
+
+	clamped := make([]MovementStepsModel, 0, len(steps))
+	for _, step := range steps {
+		if !step.Waypoint.IsNull() {
+			clamped = append(clamped, step)
+			continue
+		}
+
+		if validators.IsLinearDirection(step.Direction.ValueString()) {
+			if !limits.MaxDistance.IsNull() && !step.Distance.IsNull() && step.Distance.ValueFloat64() > limits.MaxDistance.ValueFloat64() {
+				step.Distance = limits.MaxDistance
+			}
+		} else if !limits.MaxAngle.IsNull() && !step.Angle.IsNull() && step.Angle.ValueInt64() > limits.MaxAngle.ValueInt64() {
+			step.Angle = limits.MaxAngle
+		}
+
+		clamped = append(clamped, step)
+	}
+
+	return clamped, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestClampPlanFunction(t *testing.T) {
+	noLimits := ClampPlanLimitsModel{
+		MaxDistance: types.Float64Null(),
+		MaxAngle:    types.Int64Null(),
+		MaxSteps:    types.Int64Null(),
+	}
+
+	testCases := map[string]struct {
+		steps       []MovementStepsModel
+		limits      ClampPlanLimitsModel
+		expected    []MovementStepsModel
+		expectError bool
+	}{
+		"no-limits": {
+			steps:    []MovementStepsModel{testLinearStep("forward", 10), testRotationStep("right", 270)},
+			limits:   noLimits,
+			expected: []MovementStepsModel{testLinearStep("forward", 10), testRotationStep("right", 270)},
+		},
+		"within-limits": {
+			steps: []MovementStepsModel{testLinearStep("forward", 2), testRotationStep("left", 90)},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Value(5),
+				MaxAngle:    types.Int64Value(180),
+				MaxSteps:    types.Int64Value(2),
+			},
+			expected: []MovementStepsModel{testLinearStep("forward", 2), testRotationStep("left", 90)},
+		},
+		"clamp-distance": {
+			steps: []MovementStepsModel{testLinearStep("forward", 10), testLinearStep("backward", 1), testRotationStep("right", 90)},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Value(2.5),
+				MaxAngle:    types.Int64Null(),
+				MaxSteps:    types.Int64Null(),
+			},
+			expected: []MovementStepsModel{testLinearStep("forward", 2.5), testLinearStep("backward", 1), testRotationStep("right", 90)},
+		},
+		"clamp-angle": {
+			steps: []MovementStepsModel{testRotationStep("left", 270), testRotationStep("right", 45), testLinearStep("forward", 1)},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Null(),
+				MaxAngle:    types.Int64Value(90),
+				MaxSteps:    types.Int64Null(),
+			},
+			expected: []MovementStepsModel{testRotationStep("left", 90), testRotationStep("right", 45), testLinearStep("forward", 1)},
+		},
+		"waypoint-unchanged": {
+			steps: []MovementStepsModel{testWaypointStep("dock")},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Value(1),
+				MaxAngle:    types.Int64Value(10),
+				MaxSteps:    types.Int64Null(),
+			},
+			expected: []MovementStepsModel{testWaypointStep("dock")},
+		},
+		"truncate": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 1), testRotationStep("left", 90), testLinearStep("forward", 2), testRotationStep("right", 90),
+			},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Null(),
+				MaxAngle:    types.Int64Null(),
+				MaxSteps:    types.Int64Value(2),
+			},
+			expected: []MovementStepsModel{testLinearStep("forward", 1), testRotationStep("left", 90)},
+		},
+		"truncate-too-many": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 1), testRotationStep("left", 90), testLinearStep("forward", 2), testRotationStep("right", 90),
+			},
+			limits: ClampPlanLimitsModel{
+				MaxDistance: types.Float64Null(),
+				MaxAngle:    types.Int64Null(),
+				MaxSteps:    types.Int64Value(1),
+			},
+			expectError: true,
+		},
+		"empty": {
+			steps:    []MovementStepsModel{},
+			limits:   ClampPlanLimitsModel{MaxDistance: types.Float64Null(), MaxAngle: types.Int64Null(), MaxSteps: types.Int64Value(1)},
+			expected: []MovementStepsModel{},
+		},
+		"invalid-max-distance": {
+			steps:       []MovementStepsModel{testLinearStep("forward", 1)},
+			limits:      ClampPlanLimitsModel{MaxDistance: types.Float64Value(0), MaxAngle: types.Int64Null(), MaxSteps: types.Int64Null()},
+			expectError: true,
+		},
+		"invalid-max-angle": {
+			steps:       []MovementStepsModel{testLinearStep("forward", 1)},
+			limits:      ClampPlanLimitsModel{MaxDistance: types.Float64Null(), MaxAngle: types.Int64Value(-1), MaxSteps: types.Int64Null()},
+			expectError: true,
+		},
+		"invalid-max-steps": {
+			steps:       []MovementStepsModel{testLinearStep("forward", 1)},
+			limits:      ClampPlanLimitsModel{MaxDistance: types.Float64Null(), MaxAngle: types.Int64Null(), MaxSteps: types.Int64Value(0)},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			stepType := types.ObjectType{AttrTypes: movementStepAttrTypes}
+
+			steps, diags := types.ListValueFrom(ctx, stepType, testCase.steps)
+			limits, limitsDiags := types.ObjectValueFrom(ctx, map[string]attr.Type{
+				"max_distance": types.Float64Type,
+				"max_angle":    types.Int64Type,
+				"max_steps":    types.Int64Type,
+			}, testCase.limits)
+			diags.Append(limitsDiags...)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(stepType)),
+			}
+
+			NewClampPlanFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{steps, limits}),
+			}, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected, diags := types.ListValueFrom(ctx, stepType, testCase.expected)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
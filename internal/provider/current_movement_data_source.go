@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CurrentMovementDataSource{}
+
+func NewCurrentMovementDataSource() datasource.DataSource {
+	return &CurrentMovementDataSource{}
+}
+
+// CurrentMovementDataSource defines the data source implementation.
+type CurrentMovementDataSource struct {
+	client *clients.Client
+}
+
+// CurrentMovementDataSourceModel describes the data source data model.
+type CurrentMovementDataSourceModel struct {
+	Name   types.String `tfsdk:"name"`
+	Moving types.Bool   `tfsdk:"moving"`
+}
+
+func (d *CurrentMovementDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_movement"
+}
+
+func (d *CurrentMovementDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the movement plan currently loaded on the device, and whether it is executing.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the movement plan loaded on the device, or null when no plan is loaded.",
+				Computed:            true,
+			},
+			"moving": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device is executing the movement plan.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CurrentMovementDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *CurrentMovementDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CurrentMovementDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.MovementResponse
+	err := d.client.GetJSON(ctx, "/v1/movement", &readResp)
+
+	// Devices without a movement plan loaded respond with HTTP 404 Not
+	// Found or an empty body, which leaves the name null.
+	if clients.IsNotFound(err) || errors.Is(err, clients.ErrEmptyResponse) {
+		readResp = model.MovementResponse{}
+		err = nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.Name = types.StringNull()
+	if readResp.Name != "" {
+		data.Name = types.StringValue(readResp.Name)
+	}
+	data.Moving = types.BoolValue(readResp.Moving)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
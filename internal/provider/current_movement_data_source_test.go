@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCurrentMovementDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		responses      map[string]string
+		expectedName   types.String
+		expectedMoving types.Bool
+	}{
+		"moving": {
+			responses:      map[string]string{"GET /v1/movement": `{"name":"patrol","moving":true}`},
+			expectedName:   types.StringValue("patrol"),
+			expectedMoving: types.BoolValue(true),
+		},
+		"loaded": {
+			responses:      map[string]string{"GET /v1/movement": `{"name":"patrol","moving":false}`},
+			expectedName:   types.StringValue("patrol"),
+			expectedMoving: types.BoolValue(false),
+		},
+		"no-plan-loaded": {
+			responses:      map[string]string{"GET /v1/movement": `{"moving":false}`},
+			expectedName:   types.StringNull(),
+			expectedMoving: types.BoolValue(false),
+		},
+		"no-plan-empty-body": {
+			responses:      map[string]string{"GET /v1/movement": ``},
+			expectedName:   types.StringNull(),
+			expectedMoving: types.BoolValue(false),
+		},
+		"no-plan-not-found": {
+			responses:      map[string]string{},
+			expectedName:   types.StringNull(),
+			expectedMoving: types.BoolValue(false),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				HttpClient: &testDoer{responses: testCase.responses},
+			}
+
+			resp := testReadDataSource(t, NewCurrentMovementDataSource(), client, &CurrentMovementDataSourceModel{})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data CurrentMovementDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Name.Equal(testCase.expectedName) {
+				t.Errorf("expected name %s, got %s", testCase.expectedName, data.Name)
+			}
+
+			if !data.Moving.Equal(testCase.expectedMoving) {
+				t.Errorf("expected moving %s, got %s", testCase.expectedMoving, data.Moving)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DecodePlanFunction{}
+
+func NewDecodePlanFunction() function.Function {
+	return &DecodePlanFunction{}
+}
+
+// DecodePlanFunction defines the function implementation.
+type DecodePlanFunction struct{}
+
+func (f *DecodePlanFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_plan"
+}
+
+func (f *DecodePlanFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode movement steps from a compact string.",
+		MarkdownDescription: "Decodes a string created by the `encode_plan` function back into a list of movement " +
+			"steps, which can be used to generate `steps` blocks of the `pathfinder_movement` resource with a " +
+			"`dynamic` block.\n\n" +
+			"The string must be base64-encoded JSON of a list of step objects. An error is returned if a step has " +
+			"keys other than `angle`, `direction`, `distance`, `speed`, and `waypoint`, if a value has the wrong " +
+			"type, or if a step sets neither a `waypoint` nor both `angle` and `direction`. The values themselves " +
+			"are not checked, use the `validate_plan` function for that.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "plan",
+				MarkdownDescription: "Encoded movement steps, as returned by `encode_plan`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: movementStepAttrTypes,
+			},
+		},
+	}
+}
+
+func (f *DecodePlanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var plan string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &plan))
+
+	if resp.Error != nil {
+		return
+	}
+
+	steps, err := decodePlan(plan)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, steps))
+}
+
+// decodePlan decodes movement steps encoded by encodePlan, checking that
+// they match the structure of a movement step.
+func decodePlan(plan string) ([]MovementStepsModel, error) {
+	data, err := base64.StdEncoding.DecodeString(plan)
+	if err != nil {
+		return nil, fmt.Errorf("plan is not valid base64: %s", err)
+	}
+
+	var encoded []encodedPlanStep
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&encoded); err != nil {
+		return nil, fmt.Errorf("plan is not a JSON list of movement steps: %s", err)
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("plan has unexpected data after the list of movement steps")
+	}
+
+	if encoded == nil {
+		return nil, fmt.Errorf("plan is not a JSON list of movement steps")
+	}
+
+	steps := make([]MovementStepsModel, 0, len(encoded))
+
+	for i, step := range encoded {
+		if step.Waypoint == nil && (step.Angle == nil || step.Direction == nil) {
+			return nil, fmt.Errorf("step %d must set either waypoint, or both angle and direction", i+1)
+		}
+
+		steps = append(steps, MovementStepsModel{
+			Angle:     types.Int64PointerValue(step.Angle),
+			Direction: types.StringPointerValue(step.Direction),
+			Distance:  types.Float64PointerValue(step.Distance),
+			Speed:     types.Float64PointerValue(step.Speed),
+			Waypoint:  types.StringPointerValue(step.Waypoint),
+		})
+	}
+
+	return steps, nil
+}
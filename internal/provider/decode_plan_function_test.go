@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDecodePlanFunction(t *testing.T) {
+	testCases := map[string]struct {
+		plan        string
+		expected    []MovementStepsModel
+		expectError bool
+	}{
+		"mixed": {
+			plan: `[{"angle":0,"direction":"forward","distance":1.5},{"angle":90,"direction":"right"},{"waypoint":"dock"}]`,
+			expected: []MovementStepsModel{
+				testLinearStep("forward", 1.5),
+				testRotationStep("right", 90),
+				testWaypointStep("dock"),
+			},
+		},
+		"empty":             {plan: `[]`, expected: []MovementStepsModel{}},
+		"not-a-list":        {plan: `{"angle":0,"direction":"forward"}`, expectError: true},
+		"null":              {plan: `null`, expectError: true},
+		"unknown-key":       {plan: `[{"angle":0,"direction":"forward","heading":90}]`, expectError: true},
+		"fractional-angle":  {plan: `[{"angle":4.5,"direction":"left"}]`, expectError: true},
+		"string-distance":   {plan: `[{"angle":0,"direction":"forward","distance":"1"}]`, expectError: true},
+		"missing-direction": {plan: `[{"angle":90}]`, expectError: true},
+		"trailing-data":     {plan: `[] []`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := testDecodePlan(t, base64.StdEncoding.EncodeToString([]byte(testCase.plan)))
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected, diags := types.ListValueFrom(context.Background(), types.ObjectType{AttrTypes: movementStepAttrTypes}, testCase.expected)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestDecodePlanFunction_invalidBase64(t *testing.T) {
+	if resp := testDecodePlan(t, "not base64!"); resp.Error == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
+
+func TestDecodePlanFunction_roundTrip(t *testing.T) {
+	fastStep := testLinearStep("backward", 2.25)
+	fastStep.Speed = types.Float64Value(0.5)
+
+	testCases := map[string][]MovementStepsModel{
+		"empty":    {},
+		"linear":   {testLinearStep("forward", 1.5), fastStep},
+		"rotation": {testRotationStep("left", 0), testRotationStep("right", 359)},
+		"waypoint": {testWaypointStep("dock"), testLinearStep("forward", 1)},
+	}
+
+	for name, steps := range testCases {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := encodePlan(steps)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp := testDecodePlan(t, encoded)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected, diags := types.ListValueFrom(context.Background(), types.ObjectType{AttrTypes: movementStepAttrTypes}, steps)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func testDecodePlan(t *testing.T, plan string) *function.RunResponse {
+	t.Helper()
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(types.ObjectType{AttrTypes: movementStepAttrTypes})),
+	}
+
+	NewDecodePlanFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(plan)}),
+	}, resp)
+
+	return resp
+}
@@ -5,18 +5,18 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -33,6 +33,7 @@ type DeviceDataSource struct {
 
 // DeviceDataSourceModel describes the data source data model.
 type DeviceDataSourceModel struct {
+	Fields      []string                        `tfsdk:"fields"`
 	Name        types.String                    `tfsdk:"name"`
 	Uptime      types.Float64                   `tfsdk:"uptime"`
 	Identifiers *DeviceResponseIdentifiersModel `tfsdk:"identifiers"`
@@ -60,6 +61,18 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "Get information about the device.",
 
 		Attributes: map[string]schema.Attribute{
+			"fields": schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Fields of the device status to request, to reduce the size of the response on " +
+					"bandwidth-constrained links. Must be one or more of `" + strings.Join(deviceStatusFields, "`, `") + "`. " +
+					"Only the requested fields are populated, and the others are null. All fields are requested when omitted.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(deviceStatusFields...)),
+				},
+			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the device.",
 				Computed:            true,
@@ -70,7 +83,7 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "Features of the device, including whether they're enabled or not.",
 			},
 			"uptime": schema.Float64Attribute{
-				MarkdownDescription: "Uptime (in seconds).",
+				MarkdownDescription: "Uptime (in seconds). Changes on every read, so referencing it in resource arguments causes a change to be planned on every run.",
 				Computed:            true,
 			},
 		},
@@ -131,31 +144,14 @@ func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/device/status", d.client.Config.Address),
-		io.NopCloser(strings.NewReader("")),
-	)
-
-	// Example of setting a custom header, such as an API key
-	// httpReq.Header.Set("x-api-key", d.client.Config.ApiKey)
-
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
-
-	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
-		return
+	path := "/v1/device/status"
+	if len(data.Fields) > 0 {
+		// Field names are validated, so they are safe to send unescaped.
+		path += "?fields=" + strings.Join(data.Fields, ",")
 	}
 
-	httpResp, err := d.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
-
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+	var readResp model.DeviceResponse
+	err := d.client.GetJSON(ctx, path, &readResp)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -168,38 +164,36 @@ func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-
-		return
+	// Fields that were not requested are left null, as the device does not
+	// return them.
+	if deviceStatusFieldRequested(data.Fields, "name") {
+		data.Name = types.StringValue(readResp.Name)
 	}
-
-	var readResp model.DeviceResponse
-	err = json.NewDecoder(httpResp.Body).Decode(&readResp)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while parsing the resource read response. "+
-				"Please report this issue to the provider developers.\n\n"+
-				"JSON Error: "+err.Error(),
-		)
-
-		return
+	if deviceStatusFieldRequested(data.Fields, "uptime") {
+		data.Uptime = types.Float64Value(float64(readResp.Uptime))
+	}
+	if deviceStatusFieldRequested(data.Fields, "identifiers") {
+		data.Identifiers = expandDeviceResponseIdentifiersModel(readResp.Identifiers)
+	}
+	if deviceStatusFieldRequested(data.Fields, "versions") {
+		data.Versions = expandDeviceResponseVersionsModel(readResp.Versions)
 	}
-
-	data.Name = types.StringValue(readResp.Name)
-	data.Uptime = types.Float64Value(readResp.Uptime)
-	data.Identifiers = expandDeviceResponseIdentifiersModel(readResp.Identifiers)
-	data.Versions = expandDeviceResponseVersionsModel(readResp.Versions)
 	//TODO: data.Features = something
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// deviceStatusFields are the fields of the device status that can be
+// requested with the fields query parameter.
+var deviceStatusFields = []string{"features", "identifiers", "name", "uptime", "versions"}
+
+// deviceStatusFieldRequested returns whether the field was requested, which
+// all fields are when no fields are selected.
+func deviceStatusFieldRequested(fields []string, field string) bool {
+	return len(fields) == 0 || slices.Contains(fields, field)
+}
+
 func expandDeviceResponseIdentifiersModel(in *model.DeviceResponseIdentifiers) *DeviceResponseIdentifiersModel {
 	if in == nil {
 		return nil
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceDataSource_Read_fields(t *testing.T) {
+	testCases := map[string]struct {
+		fields              []string
+		body                string
+		expectedQuery       string
+		expectedName        types.String
+		expectedUptime      types.Float64
+		expectedIdentifiers bool
+	}{
+		"all": {
+			body:                `{"name":"rover","uptime":12.5,"identifiers":{"long":"rover-1234","short":"r1"}}`,
+			expectedName:        types.StringValue("rover"),
+			expectedUptime:      types.Float64Value(12.5),
+			expectedIdentifiers: true,
+		},
+		"name-uptime": {
+			fields:         []string{"name", "uptime"},
+			body:           `{"name":"rover","uptime":12.5}`,
+			expectedQuery:  "fields=name,uptime",
+			expectedName:   types.StringValue("rover"),
+			expectedUptime: types.Float64Value(12.5),
+		},
+		"identifiers": {
+			fields:              []string{"identifiers"},
+			body:                `{"identifiers":{"long":"rover-1234","short":"r1"}}`,
+			expectedQuery:       "fields=identifiers",
+			expectedName:        types.StringNull(),
+			expectedUptime:      types.Float64Null(),
+			expectedIdentifiers: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/device/status" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				if r.URL.RawQuery != testCase.expectedQuery {
+					t.Errorf("expected query %q, got %q", testCase.expectedQuery, r.URL.RawQuery)
+				}
+
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewDeviceDataSource(), testClient(t, server), &DeviceDataSourceModel{
+				Fields:   testCase.fields,
+				Features: types.MapNull(types.StringType),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data DeviceDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Name.Equal(testCase.expectedName) {
+				t.Errorf("expected name %s, got %s", testCase.expectedName, data.Name)
+			}
+
+			if !data.Uptime.Equal(testCase.expectedUptime) {
+				t.Errorf("expected uptime %s, got %s", testCase.expectedUptime, data.Uptime)
+			}
+
+			if (data.Identifiers != nil) != testCase.expectedIdentifiers {
+				t.Errorf("expected identifiers %t, got %v", testCase.expectedIdentifiers, data.Identifiers)
+			}
+
+			if data.Versions != nil {
+				t.Errorf("expected null versions, got %v", data.Versions)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceFeatureResource_Create(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"PUT /v1/device/features/obstacle_avoidance": "",
+		},
+	}
+
+	resp := testCreateResource(t, NewDeviceFeatureResource(), &clients.Client{HttpClient: doer}, &DeviceFeatureResourceModel{
+		Id:      types.StringUnknown(),
+		Name:    types.StringValue("obstacle_avoidance"),
+		Enabled: types.BoolValue(true),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(doer.requests))
+	}
+
+	req := doer.requests[0]
+	if req.Method != http.MethodPut {
+		t.Errorf("expected PUT request, got %s", req.Method)
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"enabled":true}` {
+		t.Errorf("unexpected request body %s", body)
+	}
+
+	var data DeviceFeatureResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if data.Id.ValueString() != "obstacle_avoidance" {
+		t.Errorf("expected id obstacle_avoidance, got %s", data.Id)
+	}
+}
+
+func TestDeviceFeatureResource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		body            string
+		expectRemoved   bool
+		expectedEnabled types.Bool
+	}{
+		"unchanged": {
+			body:            `{"features":{"obstacle_avoidance":true}}`,
+			expectedEnabled: types.BoolValue(true),
+		},
+		"drift": {
+			body:            `{"features":{"obstacle_avoidance":false}}`,
+			expectedEnabled: types.BoolValue(false),
+		},
+		"removed": {
+			body:          `{"features":{"night_mode":true}}`,
+			expectRemoved: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				HttpClient: &testDoer{
+					responses: map[string]string{
+						"GET /v1/device/status": testCase.body,
+					},
+				},
+			}
+
+			resp := testReadResource(t, NewDeviceFeatureResource(), client, &DeviceFeatureResourceModel{
+				Id:      types.StringValue("obstacle_avoidance"),
+				Name:    types.StringValue("obstacle_avoidance"),
+				Enabled: types.BoolValue(true),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if resp.State.Raw.IsNull() != testCase.expectRemoved {
+				t.Fatalf("expected resource removed: %t, got state: %s", testCase.expectRemoved, resp.State.Raw)
+			}
+
+			if testCase.expectRemoved {
+				return
+			}
+
+			var data DeviceFeatureResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Enabled.Equal(testCase.expectedEnabled) {
+				t.Errorf("expected enabled %s, got %s", testCase.expectedEnabled, data.Enabled)
+			}
+		})
+	}
+}
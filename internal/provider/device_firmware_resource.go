@@ -0,0 +1,413 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceFirmwareResource{}
+var _ resource.ResourceWithValidateConfig = &DeviceFirmwareResource{}
+
+func NewDeviceFirmwareResource() resource.Resource {
+	return &DeviceFirmwareResource{}
+}
+
+// DeviceFirmwareResource defines the resource implementation.
+type DeviceFirmwareResource struct {
+	client *clients.Client
+}
+
+// DeviceFirmwareResourceModel describes the resource data model.
+type DeviceFirmwareResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Version           types.String `tfsdk:"version"`
+	Url               types.String `tfsdk:"url"`
+	Confirm           types.Bool   `tfsdk:"confirm"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	WaitTimeout       types.String `tfsdk:"wait_timeout"`
+	Updating          types.Bool   `tfsdk:"updating"`
+	CurrentVersion    types.String `tfsdk:"current_version"`
+}
+
+// defaultFirmwareWaitTimeout is the maximum time to wait for the device to
+// report the new firmware version when no wait_timeout is configured.
+const defaultFirmwareWaitTimeout = 30 * time.Minute
+
+func (r *DeviceFirmwareResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_firmware"
+}
+
+func (r *DeviceFirmwareResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Updates the firmware of the device to `version` when the resource is created, and again whenever `version` changes. " +
+			"The update only proceeds if `confirm` is `true`, and is skipped if the device already runs `version`. " +
+			"Destroying the resource does not change the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the firmware to update to, compared with the application version reported by the device.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL to download the firmware from. The device downloads the firmware from its update server when omitted.",
+				Optional:            true,
+			},
+			"confirm": schema.BoolAttribute{
+				MarkdownDescription: "Confirms that the firmware of the device should be updated. Must be `true`.",
+				Required:            true,
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the update to complete, by polling the device status until the device reports `version` " +
+					"as its application version, returning an error if it does not within `wait_timeout`. Defaults to `false`.",
+				Optional: true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for the update to complete, such as `30m`. Defaults to `30m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"updating": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device started updating, as reported by the device. " +
+					"Null if the device accepts the update without reporting it, and `false` if the device already ran `version`.",
+				Computed: true,
+			},
+			"current_version": schema.StringAttribute{
+				MarkdownDescription: "Application version reported by the device when the resource was last read. " +
+					"Differs from `version` while the update is in progress, or if it failed.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *DeviceFirmwareResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var confirm types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("confirm"), &confirm)...)
+
+	if resp.Diagnostics.HasError() || confirm.IsNull() || confirm.IsUnknown() {
+		return
+	}
+
+	if !confirm.ValueBool() {
+		addFirmwareNotConfirmedError(&resp.Diagnostics)
+	}
+}
+
+func (r *DeviceFirmwareResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *DeviceFirmwareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data DeviceFirmwareResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	requested := r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The firmware update has been requested, so a failure to wait is
+	// reported after saving state, rather than leaving the resource
+	// untracked and requesting the update again.
+	var waitDetail string
+	if requested && data.WaitForCompletion.ValueBool() {
+		waitDetail = r.waitForUpdate(ctx, &data)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addFirmwareNotCompletedError(&resp.Diagnostics, waitDetail)
+}
+
+// Read refreshes the version reported by the device, without planning
+// another update if it differs from version.
+func (r *DeviceFirmwareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeviceFirmwareResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.DeviceResponse
+	err := r.client.GetJSON(ctx, "/v1/device/status", &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.CurrentVersion = flattenFirmwareVersion(readResp)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the firmware again only when version changes, so that
+// changing how the update is waited for does not update the device again.
+func (r *DeviceFirmwareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DeviceFirmwareResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = state.Id
+
+	if data.Version.Equal(state.Version) {
+		data.Updating = state.Updating
+		data.CurrentVersion = state.CurrentVersion
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+		return
+	}
+
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	requested := r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var waitDetail string
+	if requested && data.WaitForCompletion.ValueBool() {
+		waitDetail = r.waitForUpdate(ctx, &data)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addFirmwareNotCompletedError(&resp.Diagnostics, waitDetail)
+}
+
+// Delete only removes the resource from state, as a firmware update cannot
+// be undone.
+func (r *DeviceFirmwareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// update requests the firmware update to the version of data, unless the
+// device already runs it, and returns whether the update was requested. The
+// computed attributes of data are set from the responses of the device.
+func (r *DeviceFirmwareResource) update(ctx context.Context, data *DeviceFirmwareResourceModel, diags *diag.Diagnostics) bool {
+	// The configuration is validated before planning, but values that were
+	// unknown at that point are only checked now.
+	if !data.Confirm.ValueBool() {
+		addFirmwareNotConfirmedError(diags)
+		return false
+	}
+
+	version := data.Version.ValueString()
+
+	var statusResp model.DeviceResponse
+	err := r.client.GetJSON(ctx, "/v1/device/status", &statusResp)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Update Firmware",
+			"An unexpected error occurred while attempting to read the firmware version of the device. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	data.CurrentVersion = flattenFirmwareVersion(statusResp)
+
+	if data.CurrentVersion.ValueString() == version {
+		tflog.Info(ctx, "Device already runs the firmware version, skipping the update", map[string]interface{}{
+			"version": version,
+		})
+
+		data.Updating = types.BoolValue(false)
+
+		return false
+	}
+
+	var updateResp model.DeviceFirmwareResponse
+	err = r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPost,
+		Path:   "/v1/device/firmware",
+		Body: model.DeviceFirmwareRequest{
+			Version: version,
+			Url:     data.Url.ValueString(),
+		},
+	}, &updateResp)
+
+	// Devices that accept the update without a response body do not report
+	// whether they started updating.
+	data.Updating = types.BoolValue(updateResp.Updating)
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		data.Updating = types.BoolNull()
+		err = nil
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Update Firmware",
+			"An unexpected error occurred while attempting to update the firmware of the device. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// waitForUpdate waits for the device to report the version of data, setting
+// its current version from the responses of the device. It returns the detail
+// of the error to report if the device did not report the version in time,
+// or an empty string.
+func (r *DeviceFirmwareResource) waitForUpdate(ctx context.Context, data *DeviceFirmwareResourceModel) string {
+	version := data.Version.ValueString()
+
+	timeout := defaultFirmwareWaitTimeout
+	if !data.WaitTimeout.IsNull() {
+		// The value has already been validated by the schema.
+		timeout, _ = time.ParseDuration(data.WaitTimeout.ValueString())
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The device restarts to apply the update, so errors are expected while
+	// it is updating. They are logged and the status is polled again until
+	// the timeout, with every version reported along the way logged as
+	// progress.
+	start := time.Now()
+
+	var lastErr error
+	err := clients.Poll(waitCtx, r.client.Config.PollInterval, func(ctx context.Context) (bool, error) {
+		var statusResp model.DeviceResponse
+		lastErr = r.client.GetJSON(ctx, "/v1/device/status", &statusResp)
+
+		if lastErr != nil {
+			tflog.Info(ctx, "Waiting for firmware update, device is unavailable", map[string]interface{}{
+				"elapsed": time.Since(start).Round(time.Second).String(),
+				"error":   lastErr.Error(),
+			})
+
+			return false, nil
+		}
+
+		data.CurrentVersion = flattenFirmwareVersion(statusResp)
+
+		tflog.Info(ctx, "Waiting for firmware update", map[string]interface{}{
+			"elapsed":         time.Since(start).Round(time.Second).String(),
+			"current_version": data.CurrentVersion.ValueString(),
+			"version":         version,
+		})
+
+		return data.CurrentVersion.ValueString() == version, nil
+	})
+
+	if err == nil {
+		return ""
+	}
+
+	detail := fmt.Sprintf("The firmware update to version %q was requested, but the device did not report it within %s.", version, timeout)
+	if !data.CurrentVersion.IsNull() {
+		detail += fmt.Sprintf(" The device last reported version %q.", data.CurrentVersion.ValueString())
+	}
+	if lastErr != nil {
+		detail += "\n\nLast HTTP Error: " + lastErr.Error()
+	}
+
+	return detail
+}
+
+// addFirmwareNotCompletedError adds an error with the detail returned by
+// waitForUpdate, if it is not empty.
+func addFirmwareNotCompletedError(diags *diag.Diagnostics, detail string) {
+	if detail == "" {
+		return
+	}
+
+	diags.AddError("Firmware Update Not Completed", detail)
+}
+
+// flattenFirmwareVersion returns the application version reported by the
+// device, or null if it does not report it.
+func flattenFirmwareVersion(in model.DeviceResponse) types.String {
+	if in.Versions == nil || in.Versions.App == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(in.Versions.App)
+}
+
+func addFirmwareNotConfirmedError(diags *diag.Diagnostics) {
+	diags.AddAttributeError(
+		path.Root("confirm"),
+		"Firmware Update Not Confirmed",
+		"Updating the firmware restarts the device, and a failed update may leave it unusable. "+
+			"Set confirm to true to update the firmware of the device.",
+	)
+}
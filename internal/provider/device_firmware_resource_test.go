@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceFirmwareResource_Create(t *testing.T) {
+	testCases := map[string]struct {
+		waitForCompletion bool
+		// versions are the app versions reported by the status checks, with
+		// an empty string for 503 Service Unavailable, and the last response
+		// repeated
+		versions               []string
+		expectedRequests       []string
+		expectedUpdating       types.Bool
+		expectedCurrentVersion types.String
+		expectError            bool
+	}{
+		"no-wait": {
+			versions:               []string{"1.0.0"},
+			expectedRequests:       []string{"GET /v1/device/status", "POST /v1/device/firmware"},
+			expectedUpdating:       types.BoolValue(true),
+			expectedCurrentVersion: types.StringValue("1.0.0"),
+		},
+		"already-updated": {
+			waitForCompletion:      true,
+			versions:               []string{"2.0.0"},
+			expectedRequests:       []string{"GET /v1/device/status"},
+			expectedUpdating:       types.BoolValue(false),
+			expectedCurrentVersion: types.StringValue("2.0.0"),
+		},
+		"wait": {
+			waitForCompletion: true,
+			versions:          []string{"1.0.0", "1.0.0", "", "2.0.0"},
+			expectedRequests: []string{
+				"GET /v1/device/status",
+				"POST /v1/device/firmware",
+				"GET /v1/device/status",
+				"GET /v1/device/status",
+				"GET /v1/device/status",
+			},
+			expectedUpdating:       types.BoolValue(true),
+			expectedCurrentVersion: types.StringValue("2.0.0"),
+		},
+		"never-updated": {
+			waitForCompletion:      true,
+			versions:               []string{"1.0.0"},
+			expectedUpdating:       types.BoolValue(true),
+			expectedCurrentVersion: types.StringValue("1.0.0"),
+			expectError:            true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			var updateReq model.DeviceFirmwareRequest
+			statusCount := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				if r.URL.Path == "/v1/device/firmware" {
+					body, _ := io.ReadAll(r.Body)
+					_ = json.Unmarshal(body, &updateReq)
+					_, _ = w.Write([]byte(`{"updating":true}`))
+					return
+				}
+
+				version := testCase.versions[min(statusCount, len(testCase.versions)-1)]
+				statusCount++
+
+				// The device is unavailable while applying the update.
+				if version == "" {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"name":"rover","versions":{"api":"v1","app":"` + version + `"}}`))
+			}))
+			defer server.Close()
+
+			client := testClient(t, server)
+			client.Config.PollInterval = time.Millisecond
+			client.Config.RetryMax = -1
+
+			plan := &DeviceFirmwareResourceModel{
+				Id:                types.StringUnknown(),
+				Version:           types.StringValue("2.0.0"),
+				Url:               types.StringValue("https://firmware.example.com/2.0.0.bin"),
+				Confirm:           types.BoolValue(true),
+				WaitForCompletion: types.BoolValue(testCase.waitForCompletion),
+				WaitTimeout:       types.StringValue("100ms"),
+				Updating:          types.BoolUnknown(),
+				CurrentVersion:    types.StringUnknown(),
+			}
+
+			resp := testCreateResource(t, NewDeviceFirmwareResource(), client, plan)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Firmware Update Not Completed" {
+					t.Errorf("expected Firmware Update Not Completed error, got: %s", summary)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			// The update was requested, so the resource is saved to state
+			// even when waiting for it failed.
+			var data DeviceFirmwareResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Updating.Equal(testCase.expectedUpdating) {
+				t.Errorf("expected updating %s, got %s", testCase.expectedUpdating, data.Updating)
+			}
+
+			if !data.CurrentVersion.Equal(testCase.expectedCurrentVersion) {
+				t.Errorf("expected current version %s, got %s", testCase.expectedCurrentVersion, data.CurrentVersion)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+
+			if slices.Contains(requests, "POST /v1/device/firmware") {
+				expected := model.DeviceFirmwareRequest{Version: "2.0.0", Url: "https://firmware.example.com/2.0.0.bin"}
+				if updateReq != expected {
+					t.Errorf("expected update request %+v, got %+v", expected, updateReq)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceFirmwareResource_Create_notConfirmed(t *testing.T) {
+	doer := &testDoer{}
+
+	resp := testCreateResource(t, NewDeviceFirmwareResource(), &clients.Client{HttpClient: doer}, &DeviceFirmwareResourceModel{
+		Id:             types.StringUnknown(),
+		Version:        types.StringValue("2.0.0"),
+		Confirm:        types.BoolValue(false),
+		Updating:       types.BoolUnknown(),
+		CurrentVersion: types.StringUnknown(),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+
+	if len(doer.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestDeviceFirmwareResource_Update(t *testing.T) {
+	testCases := map[string]struct {
+		version          string
+		expectedRequests []string
+	}{
+		"same-version": {
+			version: "1.0.0",
+		},
+		"new-version": {
+			version:          "2.0.0",
+			expectedRequests: []string{"GET /v1/device/status", "POST /v1/device/firmware"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doer := &testDoer{
+				responses: map[string]string{
+					"GET /v1/device/status":    `{"versions":{"app":"1.0.0"}}`,
+					"POST /v1/device/firmware": `{"updating":true}`,
+				},
+			}
+
+			state := &DeviceFirmwareResourceModel{
+				Id:             types.StringValue("2024-01-02T15:04:05Z"),
+				Version:        types.StringValue("1.0.0"),
+				Confirm:        types.BoolValue(true),
+				Updating:       types.BoolValue(true),
+				CurrentVersion: types.StringValue("1.0.0"),
+			}
+
+			plan := *state
+			plan.Version = types.StringValue(testCase.version)
+			plan.WaitTimeout = types.StringValue("1m")
+			plan.Updating = types.BoolUnknown()
+			plan.CurrentVersion = types.StringUnknown()
+
+			resp := testUpdateResource(t, NewDeviceFirmwareResource(), &clients.Client{HttpClient: doer}, state, &plan)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var requests []string
+			for _, req := range doer.requests {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+
+			var data DeviceFirmwareResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Id.Equal(state.Id) {
+				t.Errorf("expected id %s, got %s", state.Id, data.Id)
+			}
+		})
+	}
+}
+
+func TestDeviceFirmwareResource_Update_waitTimeout(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"GET /v1/device/status":    `{"versions":{"app":"1.0.0"}}`,
+			"POST /v1/device/firmware": `{"updating":true}`,
+		},
+	}
+
+	client := &clients.Client{
+		Config:     clients.ClientConfig{PollInterval: time.Millisecond},
+		HttpClient: doer,
+	}
+
+	state := &DeviceFirmwareResourceModel{
+		Id:             types.StringValue("2024-01-02T15:04:05Z"),
+		Version:        types.StringValue("1.0.0"),
+		Confirm:        types.BoolValue(true),
+		Updating:       types.BoolValue(false),
+		CurrentVersion: types.StringValue("1.0.0"),
+	}
+
+	plan := *state
+	plan.Version = types.StringValue("2.0.0")
+	plan.WaitForCompletion = types.BoolValue(true)
+	plan.WaitTimeout = types.StringValue("10ms")
+	plan.Updating = types.BoolUnknown()
+	plan.CurrentVersion = types.StringUnknown()
+
+	resp := testUpdateResource(t, NewDeviceFirmwareResource(), client, state, &plan)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Firmware Update Not Completed" {
+		t.Fatalf("expected Firmware Update Not Completed error, got: %v", resp.Diagnostics)
+	}
+
+	// The update was requested, so the new version is saved to state and the
+	// next apply does not request it again.
+	var data DeviceFirmwareResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if !data.Version.Equal(types.StringValue("2.0.0")) {
+		t.Errorf("expected version 2.0.0, got %s", data.Version)
+	}
+
+	if !data.Updating.Equal(types.BoolValue(true)) {
+		t.Errorf("expected updating to be true, got %s", data.Updating)
+	}
+
+	if !data.CurrentVersion.Equal(types.StringValue("1.0.0")) {
+		t.Errorf("expected current version 1.0.0, got %s", data.CurrentVersion)
+	}
+}
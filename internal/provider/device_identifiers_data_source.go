@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeviceIdentifiersDataSource{}
+
+func NewDeviceIdentifiersDataSource() datasource.DataSource {
+	return &DeviceIdentifiersDataSource{}
+}
+
+// DeviceIdentifiersDataSource defines the data source implementation.
+type DeviceIdentifiersDataSource struct {
+	client *clients.Client
+}
+
+// DeviceIdentifiersDataSourceModel describes the data source data model.
+type DeviceIdentifiersDataSourceModel struct {
+	Long  types.String `tfsdk:"long"`
+	Short types.String `tfsdk:"short"`
+}
+
+func (d *DeviceIdentifiersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_identifiers"
+}
+
+func (d *DeviceIdentifiersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the identifiers of the device, for example to name other resources after the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"long": schema.StringAttribute{
+				MarkdownDescription: "Long identifier of the device. Null if the device does not report identifiers.",
+				Computed:            true,
+			},
+			"short": schema.StringAttribute{
+				MarkdownDescription: "Short identifier of the device. Null if the device does not report identifiers.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeviceIdentifiersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DeviceIdentifiersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceIdentifiersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.DeviceResponse
+	err := d.client.GetJSON(ctx, "/v1/device/status", &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.Long = types.StringNull()
+	data.Short = types.StringNull()
+
+	if identifiers := expandDeviceResponseIdentifiersModel(readResp.Identifiers); identifiers != nil {
+		data.Long = identifiers.Long
+		data.Short = identifiers.Short
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
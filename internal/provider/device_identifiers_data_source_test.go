@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceIdentifiersDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		body          string
+		expectedLong  types.String
+		expectedShort types.String
+	}{
+		"identifiers": {
+			body:          `{"name":"rover","identifiers":{"long":"pathfinder-0a1b2c","short":"0a1b2c"}}`,
+			expectedLong:  types.StringValue("pathfinder-0a1b2c"),
+			expectedShort: types.StringValue("0a1b2c"),
+		},
+		"no-identifiers": {
+			body:          `{"name":"rover"}`,
+			expectedLong:  types.StringNull(),
+			expectedShort: types.StringNull(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewDeviceIdentifiersDataSource(), testClient(t, server), &DeviceIdentifiersDataSourceModel{})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data DeviceIdentifiersDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Long.Equal(testCase.expectedLong) {
+				t.Errorf("expected long %s, got %s", testCase.expectedLong, data.Long)
+			}
+
+			if !data.Short.Equal(testCase.expectedShort) {
+				t.Errorf("expected short %s, got %s", testCase.expectedShort, data.Short)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DevicePositionDataSource{}
+
+func NewDevicePositionDataSource() datasource.DataSource {
+	return &DevicePositionDataSource{}
+}
+
+// DevicePositionDataSource defines the data source implementation.
+type DevicePositionDataSource struct {
+	client *clients.Client
+}
+
+// DevicePositionDataSourceModel describes the data source data model.
+type DevicePositionDataSourceModel struct {
+	X       types.Float64 `tfsdk:"x"`
+	Y       types.Float64 `tfsdk:"y"`
+	Heading types.Float64 `tfsdk:"heading"`
+}
+
+func (d *DevicePositionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_position"
+}
+
+func (d *DevicePositionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the current position and heading of the device, using the same coordinates as the " +
+			"`pathfinder_movement_preview` data source so that predicted and actual positions can be compared. " +
+			"All attributes are null, with a warning, if the device does not support reporting its position.",
+
+		Attributes: map[string]schema.Attribute{
+			"x": schema.Float64Attribute{
+				MarkdownDescription: "Position of the device along the X axis in meters.",
+				Computed:            true,
+			},
+			"y": schema.Float64Attribute{
+				MarkdownDescription: "Position of the device along the Y axis in meters.",
+				Computed:            true,
+			},
+			"heading": schema.Float64Attribute{
+				MarkdownDescription: "Heading of the device in degrees clockwise from the positive Y axis.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DevicePositionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DevicePositionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DevicePositionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.PositionResponse
+	err := d.client.GetJSON(ctx, "/v1/device/position", &readResp)
+
+	// Treat HTTP 404 Not Found status as the device not supporting position
+	// reporting
+	if clients.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Device Position Unsupported",
+			"The device does not support reporting its position, so all attributes are null.",
+		)
+
+		data.X = types.Float64Null()
+		data.Y = types.Float64Null()
+		data.Heading = types.Float64Null()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.X = types.Float64Value(readResp.X)
+	data.Y = types.Float64Value(readResp.Y)
+	data.Heading = types.Float64Value(readResp.Heading)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
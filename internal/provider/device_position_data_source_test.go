@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDevicePositionDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		status          int
+		body            string
+		expectWarning   bool
+		expectedX       types.Float64
+		expectedY       types.Float64
+		expectedHeading types.Float64
+	}{
+		"position": {
+			status:          http.StatusOK,
+			body:            `{"x":1.5,"y":-2,"heading":90}`,
+			expectedX:       types.Float64Value(1.5),
+			expectedY:       types.Float64Value(-2),
+			expectedHeading: types.Float64Value(90),
+		},
+		"unsupported": {
+			status:          http.StatusNotFound,
+			expectWarning:   true,
+			expectedX:       types.Float64Null(),
+			expectedY:       types.Float64Null(),
+			expectedHeading: types.Float64Null(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/device/position" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewDevicePositionDataSource(), testClient(t, server), &DevicePositionDataSourceModel{})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if got := resp.Diagnostics.WarningsCount() > 0; got != testCase.expectWarning {
+				t.Errorf("expected warning: %t, got: %v", testCase.expectWarning, resp.Diagnostics)
+			}
+
+			var data DevicePositionDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.X.Equal(testCase.expectedX) {
+				t.Errorf("expected x %s, got %s", testCase.expectedX, data.X)
+			}
+
+			if !data.Y.Equal(testCase.expectedY) {
+				t.Errorf("expected y %s, got %s", testCase.expectedY, data.Y)
+			}
+
+			if !data.Heading.Equal(testCase.expectedHeading) {
+				t.Errorf("expected heading %s, got %s", testCase.expectedHeading, data.Heading)
+			}
+		})
+	}
+}
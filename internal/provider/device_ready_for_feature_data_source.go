@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeviceReadyForFeatureDataSource{}
+
+func NewDeviceReadyForFeatureDataSource() datasource.DataSource {
+	return &DeviceReadyForFeatureDataSource{}
+}
+
+// DeviceReadyForFeatureDataSource defines the data source implementation.
+type DeviceReadyForFeatureDataSource struct {
+	client *clients.Client
+}
+
+// DeviceReadyForFeatureDataSourceModel describes the data source data model.
+type DeviceReadyForFeatureDataSourceModel struct {
+	Feature        types.String `tfsdk:"feature"`
+	Ready          types.Bool   `tfsdk:"ready"`
+	FeaturePresent types.Bool   `tfsdk:"feature_present"`
+	FeatureEnabled types.Bool   `tfsdk:"feature_enabled"`
+	Healthy        types.Bool   `tfsdk:"healthy"`
+	DeviceReady    types.Bool   `tfsdk:"device_ready"`
+}
+
+func (d *DeviceReadyForFeatureDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_ready_for_feature"
+}
+
+func (d *DeviceReadyForFeatureDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get whether a feature of the device is usable, combining the device status, health, and " +
+			"readiness checks into a single `ready` attribute. The feature is usable when the device reports it as " +
+			"present and enabled, and the device is both healthy and ready. The individual conditions are also " +
+			"returned, to tell why a feature is not usable.",
+
+		Attributes: map[string]schema.Attribute{
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "Name of the feature to check, as reported in `features` of the `pathfinder_device_status` data source.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ready": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the feature is present and enabled, and the device is healthy and ready.",
+				Computed:            true,
+			},
+			"feature_present": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device reports the feature.",
+				Computed:            true,
+			},
+			"feature_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the feature is enabled. `false` when the feature is not present.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device and service report being healthy.",
+				Computed:            true,
+			},
+			"device_ready": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device and service are ready for use.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeviceReadyForFeatureDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DeviceReadyForFeatureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceReadyForFeatureDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		statusResp model.DeviceResponse
+		healthResp model.HealthzResponse
+		readyResp  model.ReadyzResponse
+
+		statusErr, healthErr, readyErr error
+	)
+
+	// Each request records its own error rather than returning it, so that
+	// every failure is reported together.
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		statusErr = d.client.GetJSON(gctx, "/v1/device/status", &statusResp)
+		return nil
+	})
+	g.Go(func() error {
+		// An unhealthy device responds with 503 Service Unavailable along
+		// with its health status
+		healthErr = d.client.SendJSON(gctx, clients.Request{
+			Method:         http.MethodGet,
+			Path:           "/v1/healthz",
+			ExpectedStatus: []int{http.StatusOK, http.StatusServiceUnavailable},
+		}, &healthResp)
+		return nil
+	})
+	g.Go(func() error {
+		readyErr = d.client.GetJSON(gctx, "/v1/readyz", &readyResp)
+		return nil
+	})
+	_ = g.Wait()
+
+	// Whether the feature is usable cannot be told without every check, so
+	// any failure is an error.
+	if err := errors.Join(statusErr, healthErr, readyErr); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Device Readiness",
+			"An unexpected error occurred while checking the device status, health, and readiness. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	enabled, present := statusResp.Features[data.Feature.ValueString()]
+
+	data.FeaturePresent = types.BoolValue(present)
+	data.FeatureEnabled = types.BoolValue(enabled)
+	data.Healthy = types.BoolValue(healthResp.Healthy)
+	data.DeviceReady = types.BoolValue(readyResp.Ready)
+	data.Ready = types.BoolValue(present && enabled && healthResp.Healthy && readyResp.Ready)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceReadyForFeatureDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		status      string
+		healthz     string
+		readyz      string
+		expected    DeviceReadyForFeatureDataSourceModel
+		expectError bool
+	}{
+		"ready": {
+			status:  `{"features":{"lidar":true,"arm":false}}`,
+			healthz: `{"healthy":true}`,
+			readyz:  `{"ready":true}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(true),
+				FeaturePresent: types.BoolValue(true),
+				FeatureEnabled: types.BoolValue(true),
+				Healthy:        types.BoolValue(true),
+				DeviceReady:    types.BoolValue(true),
+			},
+		},
+		"feature-disabled": {
+			status:  `{"features":{"lidar":false}}`,
+			healthz: `{"healthy":true}`,
+			readyz:  `{"ready":true}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(false),
+				FeaturePresent: types.BoolValue(true),
+				FeatureEnabled: types.BoolValue(false),
+				Healthy:        types.BoolValue(true),
+				DeviceReady:    types.BoolValue(true),
+			},
+		},
+		"feature-missing": {
+			status:  `{"features":{"arm":true}}`,
+			healthz: `{"healthy":true}`,
+			readyz:  `{"ready":true}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(false),
+				FeaturePresent: types.BoolValue(false),
+				FeatureEnabled: types.BoolValue(false),
+				Healthy:        types.BoolValue(true),
+				DeviceReady:    types.BoolValue(true),
+			},
+		},
+		"no-features": {
+			status:  `{"name":"rover"}`,
+			healthz: `{"healthy":true}`,
+			readyz:  `{"ready":true}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(false),
+				FeaturePresent: types.BoolValue(false),
+				FeatureEnabled: types.BoolValue(false),
+				Healthy:        types.BoolValue(true),
+				DeviceReady:    types.BoolValue(true),
+			},
+		},
+		"unhealthy": {
+			status:  `{"features":{"lidar":true}}`,
+			healthz: `{"healthy":false}`,
+			readyz:  `{"ready":true}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(false),
+				FeaturePresent: types.BoolValue(true),
+				FeatureEnabled: types.BoolValue(true),
+				Healthy:        types.BoolValue(false),
+				DeviceReady:    types.BoolValue(true),
+			},
+		},
+		"not-ready": {
+			status:  `{"features":{"lidar":true}}`,
+			healthz: `{"healthy":true}`,
+			readyz:  `{"ready":false}`,
+			expected: DeviceReadyForFeatureDataSourceModel{
+				Ready:          types.BoolValue(false),
+				FeaturePresent: types.BoolValue(true),
+				FeatureEnabled: types.BoolValue(true),
+				Healthy:        types.BoolValue(true),
+				DeviceReady:    types.BoolValue(false),
+			},
+		},
+		"readyz-unavailable": {
+			status:      `{"features":{"lidar":true}}`,
+			healthz:     `{"healthy":true}`,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			responses := map[string]string{
+				"GET /v1/device/status": testCase.status,
+				"GET /v1/healthz":       testCase.healthz,
+			}
+			if testCase.readyz != "" {
+				responses["GET /v1/readyz"] = testCase.readyz
+			}
+
+			client := &clients.Client{HttpClient: &testDoer{responses: responses}}
+
+			resp := testReadDataSource(t, NewDeviceReadyForFeatureDataSource(), client, &DeviceReadyForFeatureDataSourceModel{
+				Feature: types.StringValue("lidar"),
+			})
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected error diagnostics, got none")
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data DeviceReadyForFeatureDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			testCase.expected.Feature = types.StringValue("lidar")
+			if data != testCase.expected {
+				t.Errorf("expected %+v, got %+v", testCase.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceRebootResource{}
+
+func NewDeviceRebootResource() resource.Resource {
+	return &DeviceRebootResource{}
+}
+
+// DeviceRebootResource defines the resource implementation.
+type DeviceRebootResource struct {
+	client *clients.Client
+}
+
+// DeviceRebootResourceModel describes the resource data model.
+type DeviceRebootResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	WaitForOnline types.Bool   `tfsdk:"wait_for_online"`
+	WaitTimeout   types.String `tfsdk:"wait_timeout"`
+	Rebooting     types.Bool   `tfsdk:"rebooting"`
+}
+
+// defaultRebootWaitTimeout is the maximum time to wait for the device to be
+// back online when no wait_timeout is configured.
+const defaultRebootWaitTimeout = 5 * time.Minute
+
+// rebootOfflineGracePeriod is the time the device may keep reporting that it
+// is ready after the reboot was requested, before it is assumed to have
+// rebooted without being seen offline. It is a variable so that tests can
+// shorten it.
+var rebootOfflineGracePeriod = 10 * time.Second
+
+func (r *DeviceRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_reboot"
+}
+
+func (r *DeviceRebootResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reboots the device when the resource is created. " +
+			"Updating the resource does not reboot the device again, and destroying it does not change the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"wait_for_online": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the device to be back online after rebooting, by polling the readiness of the device " +
+					"until it has gone offline and is ready again, returning an error if it is not ready within `wait_timeout`. " +
+					"A device that is still ready 10 seconds after the reboot was requested is assumed to have rebooted already. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for the device to be back online, such as `5m`. Defaults to `5m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"rebooting": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device started rebooting, as reported by the device. " +
+					"Null if the device accepts the reboot without reporting it.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *DeviceRebootResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *DeviceRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data DeviceRebootResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rebootResp model.DeviceRebootResponse
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPost,
+		Path:   "/v1/device/reboot",
+	}, &rebootResp)
+
+	// Devices that accept the reboot without a response body do not report
+	// whether they started rebooting.
+	data.Rebooting = types.BoolValue(rebootResp.Rebooting)
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		data.Rebooting = types.BoolNull()
+		err = nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	// The device has been rebooted, so a failure to wait is reported after
+	// saving state, rather than leaving the resource untracked.
+	var waitDetail string
+	if data.WaitForOnline.ValueBool() {
+		timeout := defaultRebootWaitTimeout
+		if !data.WaitTimeout.IsNull() {
+			// The value has already been validated by the schema.
+			timeout, _ = time.ParseDuration(data.WaitTimeout.ValueString())
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// The device may still be ready until it starts rebooting, so it is
+		// only considered back online once it has been seen offline, or it is
+		// still ready after the grace period. Errors are expected while the
+		// device is rebooting, so they are logged and the readiness check is
+		// retried until the timeout.
+		start := time.Now()
+		offline := false
+
+		var lastErr error
+		err := clients.Poll(waitCtx, r.client.Config.PollInterval, func(ctx context.Context) (bool, error) {
+			var readyResp model.ReadyzResponse
+			lastErr = r.client.GetJSON(ctx, "/v1/readyz", &readyResp)
+
+			if lastErr != nil || !readyResp.Ready {
+				if !offline {
+					tflog.Debug(ctx, "Device went offline after reboot")
+				}
+
+				offline = true
+
+				return false, nil
+			}
+
+			return offline || time.Since(start) >= rebootOfflineGracePeriod, nil
+		})
+
+		if err != nil {
+			waitDetail = fmt.Sprintf("The device was rebooted, but was not back online within %s.", timeout)
+			if lastErr != nil {
+				waitDetail += "\n\nLast HTTP Error: " + lastErr.Error()
+			}
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if waitDetail != "" {
+		resp.Diagnostics.AddError("Device Not Back Online", waitDetail)
+	}
+}
+
+// Read keeps the resource as it is, as a reboot is a one-off action that has
+// no remote state to refresh.
+func (r *DeviceRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only stores the new configuration, so that changing it does not
+// reboot the device again.
+func (r *DeviceRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DeviceRebootResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = state.Id
+	data.Rebooting = state.Rebooting
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the resource from state, as a reboot cannot be undone.
+func (r *DeviceRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceRebootResource_Create(t *testing.T) {
+	testCases := map[string]struct {
+		waitForOnline bool
+		gracePeriod   time.Duration
+		// readyz are the responses to the readiness checks after the
+		// reboot, with an empty string for 503 Service Unavailable, and the
+		// last response repeated
+		readyz           []string
+		expectedRequests []string
+		expectError      bool
+	}{
+		"no-wait": {
+			expectedRequests: []string{"POST /v1/device/reboot"},
+		},
+		"offline-then-online": {
+			waitForOnline: true,
+			gracePeriod:   time.Hour,
+			readyz:        []string{`{"ready":true}`, "", `{"ready":false}`, `{"ready":true}`},
+			expectedRequests: []string{
+				"POST /v1/device/reboot",
+				"GET /v1/readyz",
+				"GET /v1/readyz",
+				"GET /v1/readyz",
+				"GET /v1/readyz",
+			},
+		},
+		"never-offline": {
+			waitForOnline: true,
+			gracePeriod:   0,
+			readyz:        []string{`{"ready":true}`},
+			expectedRequests: []string{
+				"POST /v1/device/reboot",
+				"GET /v1/readyz",
+			},
+		},
+		"never-online": {
+			waitForOnline: true,
+			gracePeriod:   time.Hour,
+			readyz:        []string{`{"ready":true}`, ""},
+			expectError:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defaultGracePeriod := rebootOfflineGracePeriod
+			rebootOfflineGracePeriod = testCase.gracePeriod
+			t.Cleanup(func() { rebootOfflineGracePeriod = defaultGracePeriod })
+
+			var requests []string
+			readyzCount := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				if r.URL.Path == "/v1/device/reboot" {
+					_, _ = w.Write([]byte(`{"rebooting":true}`))
+					return
+				}
+
+				body := testCase.readyz[min(readyzCount, len(testCase.readyz)-1)]
+				readyzCount++
+
+				// The device is offline while rebooting.
+				if body == "" {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := testClient(t, server)
+			client.Config.PollInterval = time.Millisecond
+
+			plan := &DeviceRebootResourceModel{
+				Id:            types.StringUnknown(),
+				WaitForOnline: types.BoolValue(testCase.waitForOnline),
+				WaitTimeout:   types.StringValue("100ms"),
+				Rebooting:     types.BoolUnknown(),
+			}
+
+			resp := testCreateResource(t, NewDeviceRebootResource(), client, plan)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Device Not Back Online" {
+					t.Errorf("expected Device Not Back Online error, got: %s", summary)
+				}
+
+				// The device was rebooted, so the resource is saved to
+				// state even though waiting failed.
+				if resp.State.Raw.IsNull() {
+					t.Error("expected the resource to be saved to state")
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+
+			var data DeviceRebootResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Rebooting.Equal(types.BoolValue(true)) {
+				t.Errorf("expected rebooting to be true, got %s", data.Rebooting)
+			}
+		})
+	}
+}
+
+func TestDeviceRebootResource_Create_readOnly(t *testing.T) {
+	doer := &testDoer{}
+
+	client := &clients.Client{
+		Config:     clients.ClientConfig{ReadOnly: true},
+		HttpClient: doer,
+	}
+
+	resp := testCreateResource(t, NewDeviceRebootResource(), client, &DeviceRebootResourceModel{
+		Id:        types.StringUnknown(),
+		Rebooting: types.BoolUnknown(),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+
+	if len(doer.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(doer.requests))
+	}
+}
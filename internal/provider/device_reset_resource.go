@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceResetResource{}
+var _ resource.ResourceWithValidateConfig = &DeviceResetResource{}
+
+func NewDeviceResetResource() resource.Resource {
+	return &DeviceResetResource{}
+}
+
+// DeviceResetResource defines the resource implementation.
+type DeviceResetResource struct {
+	client *clients.Client
+}
+
+// DeviceResetResourceModel describes the resource data model.
+type DeviceResetResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Confirm          types.Bool   `tfsdk:"confirm"`
+	DeviceIdentifier types.String `tfsdk:"device_identifier"`
+	Resetting        types.Bool   `tfsdk:"resetting"`
+}
+
+func (r *DeviceResetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_reset"
+}
+
+func (r *DeviceResetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resets the device to its factory settings when the resource is created. " +
+			"The reset only proceeds if `confirm` is `true` and `device_identifier` matches the short identifier reported by the device. " +
+			"Updating the resource does not reset the device again, and destroying it does not change the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"confirm": schema.BoolAttribute{
+				MarkdownDescription: "Confirms that the device should be reset. Must be `true`.",
+				Required:            true,
+			},
+			"device_identifier": schema.StringAttribute{
+				MarkdownDescription: "Short identifier of the device to reset. The reset fails if it does not match the identifier reported by the device.",
+				Required:            true,
+			},
+			"resetting": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device started resetting, as reported by the device. " +
+					"Null if the device accepts the reset without reporting it.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *DeviceResetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var confirm types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("confirm"), &confirm)...)
+
+	if resp.Diagnostics.HasError() || confirm.IsNull() || confirm.IsUnknown() {
+		return
+	}
+
+	if !confirm.ValueBool() {
+		addResetNotConfirmedError(&resp.Diagnostics)
+	}
+}
+
+func (r *DeviceResetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *DeviceResetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data DeviceResetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration is validated before planning, but values that were
+	// unknown at that point are only checked now.
+	if !data.Confirm.ValueBool() {
+		addResetNotConfirmedError(&resp.Diagnostics)
+		return
+	}
+
+	// Verify that the reset is sent to the intended device.
+	var statusResp model.DeviceResponse
+	err := r.client.GetJSON(ctx, "/v1/device/status", &statusResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to verify the device identifier. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	if statusResp.Identifiers == nil || statusResp.Identifiers.Short != data.DeviceIdentifier.ValueString() {
+		actual := "no identifier"
+		if statusResp.Identifiers != nil {
+			actual = fmt.Sprintf("%q", statusResp.Identifiers.Short)
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("device_identifier"),
+			"Device Identifier Mismatch",
+			fmt.Sprintf("The device was not reset, as device_identifier %q does not match the device, which reports %s. "+
+				"Check that the provider is configured with the address of the device to reset.", data.DeviceIdentifier.ValueString(), actual),
+		)
+
+		return
+	}
+
+	var resetResp model.DeviceResetResponse
+	err = r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPost,
+		Path:   "/v1/device/reset",
+	}, &resetResp)
+
+	// Devices that accept the reset without a response body do not report
+	// whether they started resetting.
+	data.Resetting = types.BoolValue(resetResp.Resetting)
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		data.Resetting = types.BoolNull()
+		err = nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Save data into Terraform state
+	data.Id = types.StringValue(data.DeviceIdentifier.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the resource as it is, as a reset is a one-off action that has
+// no remote state to refresh.
+func (r *DeviceResetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only stores the new configuration, so that changing it does not
+// reset the device again.
+func (r *DeviceResetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DeviceResetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = state.Id
+	data.Resetting = state.Resetting
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the resource from state, as a reset cannot be undone.
+func (r *DeviceResetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// addResetNotConfirmedError adds the error diagnostic for a device reset that
+// has not been confirmed.
+func addResetNotConfirmedError(diags *diag.Diagnostics) {
+	diags.AddAttributeError(
+		path.Root("confirm"),
+		"Device Reset Not Confirmed",
+		"Resetting the device erases its settings and movement plans. Set confirm to true to reset the device.",
+	)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceResetResource_Create(t *testing.T) {
+	testCases := map[string]struct {
+		confirm           bool
+		deviceIdentifier  string
+		statusBody        string
+		resetBody         string
+		expectError       bool
+		expectReset       bool
+		expectedResetting types.Bool
+	}{
+		"reset": {
+			confirm:           true,
+			deviceIdentifier:  "0a1b2c",
+			statusBody:        `{"name":"rover","identifiers":{"long":"pathfinder-0a1b2c","short":"0a1b2c"}}`,
+			resetBody:         `{"resetting":true}`,
+			expectReset:       true,
+			expectedResetting: types.BoolValue(true),
+		},
+		"reset-without-body": {
+			confirm:           true,
+			deviceIdentifier:  "0a1b2c",
+			statusBody:        `{"name":"rover","identifiers":{"long":"pathfinder-0a1b2c","short":"0a1b2c"}}`,
+			expectReset:       true,
+			expectedResetting: types.BoolNull(),
+		},
+		"not-confirmed": {
+			confirm:          false,
+			deviceIdentifier: "0a1b2c",
+			statusBody:       `{"name":"rover","identifiers":{"long":"pathfinder-0a1b2c","short":"0a1b2c"}}`,
+			expectError:      true,
+		},
+		"identifier-mismatch": {
+			confirm:          true,
+			deviceIdentifier: "ffffff",
+			statusBody:       `{"name":"rover","identifiers":{"long":"pathfinder-0a1b2c","short":"0a1b2c"}}`,
+			expectError:      true,
+		},
+		"no-identifiers": {
+			confirm:          true,
+			deviceIdentifier: "0a1b2c",
+			statusBody:       `{"name":"rover"}`,
+			expectError:      true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doer := &testDoer{
+				responses: map[string]string{
+					"GET /v1/device/status": testCase.statusBody,
+					"POST /v1/device/reset": testCase.resetBody,
+				},
+			}
+			client := &clients.Client{HttpClient: doer}
+
+			plan := &DeviceResetResourceModel{
+				Id:               types.StringUnknown(),
+				Confirm:          types.BoolValue(testCase.confirm),
+				DeviceIdentifier: types.StringValue(testCase.deviceIdentifier),
+				Resetting:        types.BoolUnknown(),
+			}
+
+			resp := testCreateResource(t, NewDeviceResetResource(), client, plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			reset := false
+			for _, req := range doer.requests {
+				if req.Method == http.MethodPost && req.URL.Path == "/v1/device/reset" {
+					reset = true
+				}
+			}
+
+			if reset != testCase.expectReset {
+				t.Fatalf("expected reset request: %t, got: %t", testCase.expectReset, reset)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			var data DeviceResetResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Resetting.Equal(testCase.expectedResetting) {
+				t.Errorf("expected resetting %s, got %s", testCase.expectedResetting, data.Resetting)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeviceStatusDataSource{}
+
+func NewDeviceStatusDataSource() datasource.DataSource {
+	return &DeviceStatusDataSource{}
+}
+
+// DeviceStatusDataSource defines the data source implementation.
+//
+// It reads the same device status as DeviceDataSource, but exposes the
+// identifiers and versions as nested attributes rather than nested blocks.
+type DeviceStatusDataSource struct {
+	client *clients.Client
+}
+
+// DeviceStatusDataSourceModel describes the data source data model.
+type DeviceStatusDataSourceModel struct {
+	Name            types.String                    `tfsdk:"name"`
+	Uptime          types.Float64                   `tfsdk:"uptime"`
+	Identifiers     *DeviceResponseIdentifiersModel `tfsdk:"identifiers"`
+	Versions        *DeviceResponseVersionsModel    `tfsdk:"versions"`
+	Features        types.Map                       `tfsdk:"features"`
+	EnabledFeatures types.List                      `tfsdk:"enabled_features"`
+}
+
+func (d *DeviceStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_status"
+}
+
+func (d *DeviceStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get information about the device. Unlike `pathfinder_device`, the identifiers and versions " +
+			"are nested attributes, so they are referenced and output as objects rather than blocks.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the device.",
+				Computed:            true,
+			},
+			"features": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				Computed:            true,
+				MarkdownDescription: "Features of the device, including whether they're enabled or not.",
+			},
+			"enabled_features": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the enabled features of the device, sorted alphabetically so the order is stable across reads.",
+			},
+			"uptime": schema.Float64Attribute{
+				MarkdownDescription: "Uptime (in seconds). Changes on every read, so referencing it in resource arguments causes a change to be planned on every run.",
+				Computed:            true,
+			},
+			"identifiers": schema.SingleNestedAttribute{
+				MarkdownDescription: "Identifiers of the device.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"long": schema.StringAttribute{
+						MarkdownDescription: "Long identifier of the device.",
+						Computed:            true,
+					},
+					"short": schema.StringAttribute{
+						MarkdownDescription: "Short identifier of the device.",
+						Computed:            true,
+					},
+				},
+			},
+			"versions": schema.SingleNestedAttribute{
+				MarkdownDescription: "Versions of the software running on the device.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"api": schema.StringAttribute{
+						MarkdownDescription: "Version of the API that's running.",
+						Computed:            true,
+					},
+					"app": schema.StringAttribute{
+						MarkdownDescription: "Version of the application that's running.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DeviceStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DeviceStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.DeviceResponse
+	err := d.client.GetJSON(ctx, "/v1/device/status", &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	features, diags := types.MapValueFrom(ctx, types.BoolType, readResp.Features)
+	resp.Diagnostics.Append(diags...)
+
+	enabledFeatures, diags := types.ListValueFrom(ctx, types.StringType, enabledFeatureNames(readResp.Features))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Name = types.StringValue(readResp.Name)
+	data.Uptime = types.Float64Value(float64(readResp.Uptime))
+	data.Identifiers = expandDeviceResponseIdentifiersModel(readResp.Identifiers)
+	data.Versions = expandDeviceResponseVersionsModel(readResp.Versions)
+	data.Features = features
+	data.EnabledFeatures = enabledFeatures
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// enabledFeatureNames returns the names of the enabled features, sorted so
+// that the list does not change between reads, as the order of map keys is
+// random.
+func enabledFeatureNames(features map[string]bool) []string {
+	names := make([]string, 0, len(features))
+	for name, enabled := range features {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceStatusDataSource_Read_featuresOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"rover","features":{"motors":true,"camera":false,"lidar":true,"arm":true,"speaker":true,"wifi":true}}`))
+	}))
+	defer server.Close()
+
+	client := testClient(t, server)
+	config := &DeviceStatusDataSourceModel{
+		Features:        types.MapNull(types.BoolType),
+		EnabledFeatures: types.ListNull(types.StringType),
+	}
+
+	var serialized []string
+
+	// Map iteration order is random, so a few reads are compared to catch
+	// lists built in iteration order.
+	for i := 0; i < 10; i++ {
+		resp := testReadDataSource(t, NewDeviceStatusDataSource(), client, config)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		serialized = append(serialized, resp.State.Raw.String())
+
+		if i > 0 && serialized[i] != serialized[0] {
+			t.Fatalf("expected reads of the same response to be identical, got:\n%s\n%s", serialized[0], serialized[i])
+		}
+
+		var data DeviceStatusDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+		var enabled []string
+		data.EnabledFeatures.ElementsAs(context.Background(), &enabled, false)
+
+		if expected := []string{"arm", "lidar", "motors", "speaker", "wifi"}; !slices.Equal(enabled, expected) {
+			t.Fatalf("expected enabled features %v, got %v", expected, enabled)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeviceTimeDataSource{}
+
+func NewDeviceTimeDataSource() datasource.DataSource {
+	return &DeviceTimeDataSource{}
+}
+
+// DeviceTimeDataSource defines the data source implementation.
+type DeviceTimeDataSource struct {
+	client *clients.Client
+}
+
+// DeviceTimeDataSourceModel describes the data source data model.
+type DeviceTimeDataSourceModel struct {
+	Time        types.String  `tfsdk:"time"`
+	HostTime    types.String  `tfsdk:"host_time"`
+	SkewSeconds types.Float64 `tfsdk:"skew_seconds"`
+}
+
+func (d *DeviceTimeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_time"
+}
+
+func (d *DeviceTimeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the current time of the device clock and its skew from the clock of the host running Terraform, " +
+			"such as to diagnose certificate expiry or scheduling issues.",
+
+		Attributes: map[string]schema.Attribute{
+			"time": schema.StringAttribute{
+				MarkdownDescription: "Current time of the device, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"host_time": schema.StringAttribute{
+				MarkdownDescription: "Time of the host that the device time is compared to, in RFC 3339 format. " +
+					"This is halfway between sending the request and receiving the response.",
+				Computed: true,
+			},
+			"skew_seconds": schema.Float64Attribute{
+				MarkdownDescription: "Seconds that the device clock is ahead of the host clock. Negative when the device clock is behind.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeviceTimeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DeviceTimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceTimeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.DeviceTimeResponse
+	sent := timeNow()
+	err := d.client.GetJSON(ctx, "/v1/device/time", &readResp)
+	received := timeNow()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	if readResp.Time.IsZero() {
+		resp.Diagnostics.AddError(
+			"Invalid Device Time",
+			"The device did not return its current time.",
+		)
+
+		return
+	}
+
+	hostTime, skew := clockSkew(readResp.Time.Time, sent, received)
+
+	data.Time = flattenTimestamp(readResp.Time)
+	data.HostTime = types.StringValue(hostTime.UTC().Format(time.RFC3339Nano))
+	data.SkewSeconds = types.Float64Value(skew.Seconds())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// clockSkew returns the host time that the device time was read at and how
+// far the device clock is ahead of it. The device is assumed to have read its
+// clock halfway between the request being sent and the response received.
+func clockSkew(deviceTime, sent, received time.Time) (time.Time, time.Duration) {
+	hostTime := sent.Add(received.Sub(sent) / 2)
+
+	return hostTime, deviceTime.Sub(hostTime)
+}
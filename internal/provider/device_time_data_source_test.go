@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+)
+
+func TestClockSkew(t *testing.T) {
+	sent := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	testCases := map[string]struct {
+		deviceTime       time.Time
+		received         time.Time
+		expectedHostTime time.Time
+		expectedSkew     time.Duration
+	}{
+		"in sync": {
+			deviceTime:       sent,
+			received:         sent,
+			expectedHostTime: sent,
+		},
+		"ahead": {
+			deviceTime:       sent.Add(3 * time.Second),
+			received:         sent,
+			expectedHostTime: sent,
+			expectedSkew:     3 * time.Second,
+		},
+		"behind": {
+			deviceTime:       sent.Add(-90 * time.Second),
+			received:         sent,
+			expectedHostTime: sent,
+			expectedSkew:     -90 * time.Second,
+		},
+		"round trip": {
+			deviceTime:       sent.Add(time.Second),
+			received:         sent.Add(2 * time.Second),
+			expectedHostTime: sent.Add(time.Second),
+		},
+		"time zone": {
+			deviceTime:       time.Date(2024, 1, 2, 16, 4, 6, 0, time.FixedZone("CET", 3600)),
+			received:         sent,
+			expectedHostTime: sent,
+			expectedSkew:     time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hostTime, skew := clockSkew(testCase.deviceTime, sent, testCase.received)
+
+			if !hostTime.Equal(testCase.expectedHostTime) {
+				t.Errorf("expected host time %s, got %s", testCase.expectedHostTime, hostTime)
+			}
+
+			if skew != testCase.expectedSkew {
+				t.Errorf("expected skew %s, got %s", testCase.expectedSkew, skew)
+			}
+		})
+	}
+}
+
+func TestDeviceTimeDataSource_Read(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	testCases := map[string]struct {
+		body         string
+		expectError  bool
+		expectedSkew float64
+	}{
+		"skew": {
+			body:         `{"time":"2024-01-02T15:04:07.5Z"}`,
+			expectedSkew: 2.5,
+		},
+		"epoch millis": {
+			body:         `{"time":1704207847500}`,
+			expectedSkew: 2.5,
+		},
+		"missing time": {
+			body:        `{}`,
+			expectError: true,
+		},
+		"invalid time": {
+			body:        `{"time":"yesterday"}`,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				HttpClient: &testDoer{
+					responses: map[string]string{"GET /v1/device/time": testCase.body},
+				},
+			}
+
+			resp := testReadDataSource(t, NewDeviceTimeDataSource(), client, &DeviceTimeDataSourceModel{})
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			var data DeviceTimeDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if got := data.SkewSeconds.ValueFloat64(); got != testCase.expectedSkew {
+				t.Errorf("expected skew %g, got %g", testCase.expectedSkew, got)
+			}
+
+			if got := data.HostTime.ValueString(); got != "2024-01-02T15:04:05Z" {
+				t.Errorf("expected host time 2024-01-02T15:04:05Z, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DeviceUrlFunction{}
+
+func NewDeviceUrlFunction() function.Function {
+	return &DeviceUrlFunction{}
+}
+
+// DeviceUrlFunction defines the function implementation.
+type DeviceUrlFunction struct{}
+
+func (f *DeviceUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "device_url"
+}
+
+func (f *DeviceUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the URL of a Pathfinder API endpoint.",
+		MarkdownDescription: "Builds the URL of a Pathfinder API endpoint the same way as the provider does for its own requests. " +
+			"Slashes between the address and the path are normalized, and any path of the address is kept.\n\n" +
+			"Provider functions do not have access to the provider configuration, so the address must be passed " +
+			"as an argument, usually the same value as the `address` of the provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "address",
+				MarkdownDescription: "Address of the Pathfinder API, for example `https://rover.local`.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the endpoint, including the API version, for example `/v1/device/status`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DeviceUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var address, path string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &address, &path))
+
+	if resp.Error != nil {
+		return
+	}
+
+	url, err := clients.JoinURL(address, path)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, url))
+}
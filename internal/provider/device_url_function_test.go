@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceUrlFunction(t *testing.T) {
+	testCases := map[string]struct {
+		address     string
+		path        string
+		expected    string
+		expectError bool
+	}{
+		"address":                {address: "https://rover.local", path: "/v1/device/status", expected: "https://rover.local/v1/device/status"},
+		"address-trailing-slash": {address: "https://rover.local/", path: "/v1/device/status", expected: "https://rover.local/v1/device/status"},
+		"address-with-path":      {address: "https://gateway.local/rovers/1", path: "v1/readyz", expected: "https://gateway.local/rovers/1/v1/readyz"},
+		"invalid-address":        {address: "http://rover\x7f.local", path: "/v1/readyz", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewDeviceUrlFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(testCase.address),
+					types.StringValue(testCase.path),
+				}),
+			}, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Errorf("expected error, got %s", resp.Result.Value())
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := types.StringValue(testCase.expected)
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
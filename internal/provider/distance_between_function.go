@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DistanceBetweenFunction{}
+
+func NewDistanceBetweenFunction() function.Function {
+	return &DistanceBetweenFunction{}
+}
+
+// DistanceBetweenFunction defines the function implementation.
+type DistanceBetweenFunction struct{}
+
+func (f *DistanceBetweenFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "distance_between"
+}
+
+func (f *DistanceBetweenFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the distance between two points.",
+		MarkdownDescription: "Computes the straight-line distance in meters between the point (`x1`, `y1`) and the " +
+			"point (`x2`, `y2`). Together with the `bearing` function, it computes the angle and distance of a " +
+			"step moving the device from one point to another.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "x1",
+				MarkdownDescription: "X coordinate of the first point, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "y1",
+				MarkdownDescription: "Y coordinate of the first point, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "x2",
+				MarkdownDescription: "X coordinate of the second point, in meters.",
+			},
+			function.Float64Parameter{
+				Name:                "y2",
+				MarkdownDescription: "Y coordinate of the second point, in meters.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *DistanceBetweenFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var x1, y1, x2, y2 float64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &x1, &y1, &x2, &y2))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, math.Hypot(x2-x1, y2-y1)))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDistanceBetweenFunction(t *testing.T) {
+	testCases := map[string]struct {
+		x1, y1, x2, y2 float64
+		expected       float64
+	}{
+		"identical":     {x1: 1, y1: 1, x2: 1, y2: 1, expected: 0},
+		"along-y":       {x2: 0, y2: 2, expected: 2},
+		"along-x":       {x2: -3, y2: 0, expected: 3},
+		"pythagorean":   {x2: 3, y2: 4, expected: 5},
+		"offset-origin": {x1: -1, y1: -1, x2: 2, y2: 3, expected: 5},
+		"diagonal":      {x2: 1, y2: 1, expected: math.Sqrt2},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.Float64Unknown()),
+			}
+
+			NewDistanceBetweenFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.Float64Value(testCase.x1),
+					types.Float64Value(testCase.y1),
+					types.Float64Value(testCase.x2),
+					types.Float64Value(testCase.y2),
+				}),
+			}, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got := resp.Result.Value().(types.Float64).ValueFloat64()
+			if math.Abs(got-testCase.expected) > 1e-9 {
+				t.Errorf("expected %g, got %g", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EncodePlanFunction{}
+
+func NewEncodePlanFunction() function.Function {
+	return &EncodePlanFunction{}
+}
+
+// EncodePlanFunction defines the function implementation.
+type EncodePlanFunction struct{}
+
+func (f *EncodePlanFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "encode_plan"
+}
+
+func (f *EncodePlanFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encode movement steps as a compact string.",
+		MarkdownDescription: "Encodes a list of movement steps as base64-encoded JSON, so that a movement plan can be " +
+			"stored compactly in a variable or output. The `decode_plan` function decodes the string back into " +
+			"the list of steps.\n\n" +
+			"Each step is encoded as a JSON object with the `angle`, `direction`, `distance`, `speed`, and " +
+			"`waypoint` keys, omitting the attributes that are `null`.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name: "steps",
+				MarkdownDescription: "Movement steps to encode, as objects with the `angle`, `direction`, `distance`, " +
+					"`speed`, and `waypoint` attributes. `distance`, `speed`, and `waypoint` may be `null`.",
+				ElementType: types.ObjectType{
+					AttrTypes: movementStepAttrTypes,
+				},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EncodePlanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var steps []MovementStepsModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &steps))
+
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := encodePlan(steps)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
+
+// encodedPlanStep is the JSON encoding of a movement step used by
+// encode_plan and decode_plan. Null attributes are omitted.
+type encodedPlanStep struct {
+	Angle     *int64   `json:"angle,omitempty"`
+	Direction *string  `json:"direction,omitempty"`
+	Distance  *float64 `json:"distance,omitempty"`
+	Speed     *float64 `json:"speed,omitempty"`
+	Waypoint  *string  `json:"waypoint,omitempty"`
+}
+
+// encodePlan encodes movement steps as base64-encoded JSON.
+func encodePlan(steps []MovementStepsModel) (string, error) {
+	encoded := make([]encodedPlanStep, 0, len(steps))
+
+	for _, step := range steps {
+		encoded = append(encoded, encodedPlanStep{
+			Angle:     step.Angle.ValueInt64Pointer(),
+			Direction: step.Direction.ValueStringPointer(),
+			Distance:  step.Distance.ValueFloat64Pointer(),
+			Speed:     step.Speed.ValueFloat64Pointer(),
+			Waypoint:  step.Waypoint.ValueStringPointer(),
+		})
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
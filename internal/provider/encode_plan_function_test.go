@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEncodePlanFunction(t *testing.T) {
+	fastStep := testLinearStep("forward", 1.5)
+	fastStep.Speed = types.Float64Value(2)
+
+	testCases := map[string]struct {
+		steps    []MovementStepsModel
+		expected string
+	}{
+		"empty": {
+			steps:    []MovementStepsModel{},
+			expected: `[]`,
+		},
+		"mixed": {
+			steps: []MovementStepsModel{fastStep, testRotationStep("right", 90), testWaypointStep("dock")},
+			expected: `[{"angle":0,"direction":"forward","distance":1.5,"speed":2},` +
+				`{"angle":90,"direction":"right"},` +
+				`{"waypoint":"dock"}]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			steps, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: movementStepAttrTypes}, testCase.steps)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewEncodePlanFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{steps}),
+			}, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := types.StringValue(base64.StdEncoding.EncodeToString([]byte(testCase.expected)))
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FeatureCatalogDataSource{}
+
+func NewFeatureCatalogDataSource() datasource.DataSource {
+	return &FeatureCatalogDataSource{}
+}
+
+// FeatureCatalogDataSource defines the data source implementation.
+type FeatureCatalogDataSource struct {
+	client *clients.Client
+}
+
+// FeatureCatalogDataSourceModel describes the data source data model.
+type FeatureCatalogDataSourceModel struct {
+	Features []FeatureCatalogItemModel `tfsdk:"features"`
+}
+
+type FeatureCatalogItemModel struct {
+	Key          types.String `tfsdk:"key"`
+	Description  types.String `tfsdk:"description"`
+	Experimental types.Bool   `tfsdk:"experimental"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+}
+
+func (d *FeatureCatalogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_catalog"
+}
+
+func (d *FeatureCatalogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the feature flags supported by the device, with a description of each feature and " +
+			"whether it is experimental. Devices without a feature catalog only report the `features` of the " +
+			"`pathfinder_device_status` data source, in which case `description` and `experimental` are null.",
+
+		Attributes: map[string]schema.Attribute{
+			"features": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "Name of the feature flag, as used by the pathfinder_device_feature resource.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the feature. Null if the device does not describe it.",
+							Computed:    true,
+						},
+						"experimental": schema.BoolAttribute{
+							Description: "Indicates if the feature is experimental. Null if the device does not report it.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Indicates if the feature is enabled.",
+							Computed:    true,
+						},
+					},
+				},
+				MarkdownDescription: "Features of the device, in the order returned by the device, or sorted by `key` " +
+					"without a feature catalog. Empty if the device has no features.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *FeatureCatalogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *FeatureCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FeatureCatalogDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp []model.DeviceFeatureCatalogItem
+	err := d.client.GetJSON(ctx, "/v1/device/features/catalog", &readResp)
+
+	// Treat an empty response body as a catalog without features
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		err = nil
+	}
+
+	// Devices without a feature catalog return HTTP 404 Not Found, so fall
+	// back to the feature flags of the device status
+	if clients.IsNotFound(err) {
+		tflog.Debug(ctx, "Device has no feature catalog, reading feature flags from the device status")
+
+		data.Features, err = d.readDeviceFeatures(ctx)
+	} else if err == nil {
+		// The list is never nil, so that a catalog without features is an
+		// empty list rather than null.
+		data.Features = make([]FeatureCatalogItemModel, len(readResp))
+		for i, item := range readResp {
+			data.Features[i] = FeatureCatalogItemModel{
+				Key:          types.StringValue(item.Key),
+				Description:  types.StringValue(item.Description),
+				Experimental: types.BoolValue(item.Experimental),
+				Enabled:      types.BoolValue(item.Enabled),
+			}
+		}
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readDeviceFeatures returns the feature flags of the device status, sorted
+// by key as the device reports them as a map.
+func (d *FeatureCatalogDataSource) readDeviceFeatures(ctx context.Context) ([]FeatureCatalogItemModel, error) {
+	var readResp model.DeviceResponse
+	if err := d.client.GetJSON(ctx, "/v1/device", &readResp); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(readResp.Features))
+	for key := range readResp.Features {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	features := make([]FeatureCatalogItemModel, len(keys))
+	for i, key := range keys {
+		features[i] = FeatureCatalogItemModel{
+			Key:          types.StringValue(key),
+			Description:  types.StringNull(),
+			Experimental: types.BoolNull(),
+			Enabled:      types.BoolValue(readResp.Features[key]),
+		}
+	}
+
+	return features, nil
+}
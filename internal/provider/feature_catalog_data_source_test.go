@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFeatureCatalogDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		responses   map[string]string
+		expected    []FeatureCatalogItemModel
+		expectError bool
+	}{
+		"catalog": {
+			responses: map[string]string{
+				"GET /v1/device/features/catalog": `[{"key":"lidar","description":"Obstacle detection","experimental":false,"enabled":true},` +
+					`{"key":"arm","description":"Robotic arm","experimental":true,"enabled":false}]`,
+			},
+			expected: []FeatureCatalogItemModel{
+				{Key: types.StringValue("lidar"), Description: types.StringValue("Obstacle detection"), Experimental: types.BoolValue(false), Enabled: types.BoolValue(true)},
+				{Key: types.StringValue("arm"), Description: types.StringValue("Robotic arm"), Experimental: types.BoolValue(true), Enabled: types.BoolValue(false)},
+			},
+		},
+		"empty-catalog": {
+			responses: map[string]string{
+				"GET /v1/device/features/catalog": `[]`,
+			},
+			expected: []FeatureCatalogItemModel{},
+		},
+		"no-catalog": {
+			responses: map[string]string{
+				"GET /v1/device": `{"name":"rover","features":{"wifi":true,"arm":false}}`,
+			},
+			expected: []FeatureCatalogItemModel{
+				{Key: types.StringValue("arm"), Description: types.StringNull(), Experimental: types.BoolNull(), Enabled: types.BoolValue(false)},
+				{Key: types.StringValue("wifi"), Description: types.StringNull(), Experimental: types.BoolNull(), Enabled: types.BoolValue(true)},
+			},
+		},
+		"no-catalog-no-features": {
+			responses: map[string]string{
+				"GET /v1/device": `{"name":"rover"}`,
+			},
+			expected: []FeatureCatalogItemModel{},
+		},
+		"no-device": {
+			responses:   map[string]string{},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{HttpClient: &testDoer{responses: testCase.responses}}
+
+			resp := testReadDataSource(t, NewFeatureCatalogDataSource(), client, &FeatureCatalogDataSourceModel{})
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected error diagnostics, got none")
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data FeatureCatalogDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.Features == nil {
+				t.Fatalf("expected features to be a list, got null")
+			}
+
+			if len(data.Features) != len(testCase.expected) {
+				t.Fatalf("expected %d features, got %d: %v", len(testCase.expected), len(data.Features), data.Features)
+			}
+
+			for i, expected := range testCase.expected {
+				if data.Features[i] != expected {
+					t.Errorf("expected feature %d to be %+v, got %+v", i, expected, data.Features[i])
+				}
+			}
+		})
+	}
+}
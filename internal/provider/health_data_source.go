@@ -5,16 +5,18 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -33,9 +35,17 @@ type HealthDataSource struct {
 
 // HealthDataSourceModel describes the data source data model.
 type HealthDataSourceModel struct {
-	Healthy types.Bool `tfsdk:"healthy"`
+	Healthy         types.Bool    `tfsdk:"healthy"`
+	CheckCount      types.Int64   `tfsdk:"check_count"`
+	CheckInterval   types.String  `tfsdk:"check_interval"`
+	HealthyFraction types.Float64 `tfsdk:"healthy_fraction"`
+	HealthyChecks   types.Int64   `tfsdk:"healthy_checks"`
 }
 
+// defaultHealthyFraction is the fraction of health checks that must report
+// healthy when no healthy_fraction is configured.
+const defaultHealthyFraction = 0.5
+
 func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_health"
 }
@@ -47,7 +57,35 @@ func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 
 		Attributes: map[string]schema.Attribute{
 			"healthy": schema.BoolAttribute{
-				MarkdownDescription: "Indicates if the device and service are healthy for use.",
+				MarkdownDescription: "Indicates if the device and service are healthy for use, " +
+					"which is when at least `healthy_fraction` of the health checks report healthy.",
+				Computed: true,
+			},
+			"check_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of health checks to perform, so that a single unhealthy check does not report the device " +
+					"and service as unhealthy. Defaults to `1`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"check_interval": schema.StringAttribute{
+				MarkdownDescription: "Interval between health checks, such as `2s`. Defaults to the `poll_interval` of the provider.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"healthy_fraction": schema.Float64Attribute{
+				MarkdownDescription: "Minimum fraction of the health checks that must report healthy, between `0` and `1`. " +
+					"Checks that fail with an error count as unhealthy. Defaults to `0.5`.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"healthy_checks": schema.Int64Attribute{
+				MarkdownDescription: "Number of health checks that reported healthy.",
 				Computed:            true,
 			},
 		},
@@ -80,67 +118,86 @@ func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/healthz", d.client.Config.Address),
-		io.NopCloser(strings.NewReader("")),
-	)
-
-	// Example of setting a custom header, such as an API key
-	// httpReq.Header.Set("x-api-key", d.client.Config.ApiKey)
-
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
+	count := data.CheckCount.ValueInt64()
+	if data.CheckCount.IsNull() {
+		count = 1
+	}
 
-	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
-		return
+	interval := d.client.Config.PollInterval
+	if !data.CheckInterval.IsNull() {
+		// The value has already been validated by the schema.
+		interval, _ = time.ParseDuration(data.CheckInterval.ValueString())
 	}
 
-	httpResp, err := d.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
+	fraction := defaultHealthyFraction
+	if !data.HealthyFraction.IsNull() {
+		fraction = data.HealthyFraction.ValueFloat64()
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+	var healthy, failed int64
+	var lastErr error
+
+	for i := int64(0); i < count; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				resp.Diagnostics.AddError(
+					"Unable to Refresh Resource",
+					fmt.Sprintf("The health checks were cancelled after %d of %d checks: %s", i, count, ctx.Err()),
+				)
+
+				return
+			case <-time.After(interval):
+			}
+		}
+
+		ok, err := d.checkHealth(ctx)
+		if err != nil {
+			// A failed check counts as unhealthy, as the errors may be
+			// transient.
+			tflog.Debug(ctx, "Health check failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+
+			failed++
+			lastErr = err
+
+			continue
+		}
+
+		if ok {
+			healthy++
+		}
+	}
 
-	if err != nil {
+	if failed == count {
 		resp.Diagnostics.AddError(
 			"Unable to Refresh Resource",
 			"An unexpected error occurred while attempting to refresh resource state. "+
 				"Please retry the operation or report this issue to the provider developers.\n\n"+
-				"HTTP Error: "+err.Error(),
+				"HTTP Error: "+lastErr.Error(),
 		)
 
 		return
 	}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	data.Healthy = types.BoolValue(float64(healthy) >= fraction*float64(count))
+	data.HealthyChecks = types.Int64Value(healthy)
 
-		return
-	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
+// checkHealth returns whether the device and service report being healthy.
+func (d *HealthDataSource) checkHealth(ctx context.Context) (bool, error) {
+	// An unhealthy device responds with 503 Service Unavailable along with
+	// its health status
 	var readResp model.HealthzResponse
-	err = json.NewDecoder(httpResp.Body).Decode(&readResp)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while parsing the resource read response. "+
-				"Please report this issue to the provider developers.\n\n"+
-				"JSON Error: "+err.Error(),
-		)
+	err := d.client.SendJSON(ctx, clients.Request{
+		Method:         http.MethodGet,
+		Path:           "/v1/healthz",
+		ExpectedStatus: []int{http.StatusOK, http.StatusServiceUnavailable},
+	}, &readResp)
 
-		return
-	}
-
-	data.Healthy = types.BoolValue(readResp.Healthy)
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return readResp.Healthy, err
 }
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHealthDataSource_Read_checkCount(t *testing.T) {
+	testCases := map[string]struct {
+		responses       []bool
+		healthyFraction types.Float64
+		expectedHealthy bool
+		expectedChecks  int64
+	}{
+		"single-healthy": {
+			responses:       []bool{true},
+			healthyFraction: types.Float64Null(),
+			expectedHealthy: true,
+			expectedChecks:  1,
+		},
+		"majority-healthy": {
+			responses:       []bool{true, false, true},
+			healthyFraction: types.Float64Null(),
+			expectedHealthy: true,
+			expectedChecks:  2,
+		},
+		"majority-unhealthy": {
+			responses:       []bool{false, true, false},
+			healthyFraction: types.Float64Null(),
+			expectedHealthy: false,
+			expectedChecks:  1,
+		},
+		"all-required": {
+			responses:       []bool{true, true, false},
+			healthyFraction: types.Float64Value(1),
+			expectedHealthy: false,
+			expectedChecks:  2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				healthy := testCase.responses[calls]
+				calls++
+
+				if !healthy {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = w.Write([]byte(`{"healthy":false}`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"healthy":true}`))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewHealthDataSource(), testClient(t, server), &HealthDataSourceModel{
+				CheckCount:      types.Int64Value(int64(len(testCase.responses))),
+				CheckInterval:   types.StringValue("1ms"),
+				HealthyFraction: testCase.healthyFraction,
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if calls != len(testCase.responses) {
+				t.Errorf("expected %d health checks, got %d", len(testCase.responses), calls)
+			}
+
+			var data HealthDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Healthy.Equal(types.BoolValue(testCase.expectedHealthy)) {
+				t.Errorf("expected healthy %t, got %s", testCase.expectedHealthy, data.Healthy)
+			}
+
+			if !data.HealthyChecks.Equal(types.Int64Value(testCase.expectedChecks)) {
+				t.Errorf("expected %d healthy checks, got %s", testCase.expectedChecks, data.HealthyChecks)
+			}
+		})
+	}
+}
+
+func TestHealthDataSource_Read_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cancel while waiting for the next check.
+		cancel()
+		_, _ = w.Write([]byte(`{"healthy":true}`))
+	}))
+	defer server.Close()
+
+	resp := testReadDataSourceContext(t, ctx, NewHealthDataSource(), testClient(t, server), &HealthDataSourceModel{
+		CheckCount:    types.Int64Value(3),
+		CheckInterval: types.StringValue("1h"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MovementBatchResource{}
+var _ resource.ResourceWithValidateConfig = &MovementBatchResource{}
+var _ resource.ResourceWithModifyPlan = &MovementBatchResource{}
+
+func NewMovementBatchResource() resource.Resource {
+	return &MovementBatchResource{}
+}
+
+// MovementBatchResource defines the resource implementation.
+type MovementBatchResource struct {
+	client *clients.Client
+}
+
+// MovementBatchResourceModel describes the resource data model.
+type MovementBatchResourceModel struct {
+	Id        types.String             `tfsdk:"id"`
+	Plans     []MovementBatchPlanModel `tfsdk:"plans"`
+	Submitted types.Map                `tfsdk:"submitted"`
+}
+
+type MovementBatchPlanModel struct {
+	Name  types.String         `tfsdk:"name"`
+	Steps []MovementStepsModel `tfsdk:"steps"`
+}
+
+func (r *MovementBatchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_batch"
+}
+
+func (r *MovementBatchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Submits several movement plans to the device, in order. " +
+			"If a plan fails to submit, the apply fails and the plans submitted before it are recorded in `submitted`. " +
+			"When the resource is updated, the next apply only submits the failed and remaining plans. " +
+			"When it is created, Terraform marks it as tainted, and the next apply replaces it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"submitted": schema.MapAttribute{
+				MarkdownDescription: "Indicates for each plan, keyed by name, if it has been submitted to the device.",
+				ElementType:         types.BoolType,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"plans": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the movement plan to execute. Must be unique within the batch.",
+							Required:            true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"steps": movementStepsBlock(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *MovementBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The plans are read as values, as the steps may not be known yet.
+	var plans types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plans"), &plans)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range plans.Elements() {
+		plan, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		// The batch does not define waypoints for steps to move to.
+		if steps, ok := plan.Attributes()["steps"].(types.List); ok {
+			for j, element := range steps.Elements() {
+				step, ok := element.(types.Object)
+				if !ok {
+					continue
+				}
+
+				if waypoint, ok := step.Attributes()["waypoint"].(types.String); ok && !waypoint.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("plans").AtListIndex(i).AtName("steps").AtListIndex(j).AtName("waypoint"),
+						"Unsupported Movement Step Waypoint",
+						"Steps of a movement batch cannot move to waypoints, use the pathfinder_movement resource instead.",
+					)
+				}
+			}
+		}
+
+		name, ok := plan.Attributes()["name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+
+		if seen[name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("plans").AtListIndex(i).AtName("name"),
+				"Duplicate Movement Plan Name",
+				fmt.Sprintf("The name %q is used by more than one plan, names must be unique within the batch.", name.ValueString()),
+			)
+		}
+
+		seen[name.ValueString()] = true
+	}
+}
+
+func (r *MovementBatchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// ModifyPlan plans an update when a plan recorded in state has not been
+// submitted, so that the next apply retries the plans that failed or were not
+// reached.
+func (r *MovementBatchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to retry when the resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var submitted map[string]bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("submitted"), &submitted)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, done := range submitted {
+		if !done {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("submitted"), types.MapUnknown(types.BoolType))...)
+			return
+		}
+	}
+}
+
+func (r *MovementBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data MovementBatchResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	submitted, err := r.submitPlans(ctx, data.Plans, nil, &resp.Diagnostics)
+	if err != nil && !anySubmitted(submitted) {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// The plans that were submitted are saved in state before the error is
+	// reported, so that the apply fails while state records which plans the
+	// device was sent. Terraform taints the resource, so the next apply
+	// replaces it.
+	r.setState(ctx, &data, submitted, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Movement Batch Partially Submitted",
+			"Some movement plans were not submitted, the plans that were submitted are recorded in submitted. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+	}
+}
+
+// Read keeps the resource as it is, as the device does not report which
+// movement plans it has been sent.
+func (r *MovementBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *MovementBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	var data, state MovementBatchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	submitted, err := r.submitPlans(ctx, data.Plans, &state, &resp.Diagnostics)
+
+	// The state of an update is saved even when it fails, so the plans that
+	// were submitted are not submitted again.
+	r.setState(ctx, &data, submitted, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			"An unexpected error occurred while attempting to update the resource, the plans that were not submitted "+
+				"are retried by the next apply. Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *MovementBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "delete")
+		return
+	}
+
+	// Transient errors are retried, if the final attempt fails the resource
+	// is kept in state so that the deletion can be retried.
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodDelete,
+		Path:   r.client.MovementPath(),
+		Retry:  true,
+	}, nil)
+
+	// Treat HTTP 404 Not Found status as the resource already being deleted
+	// and return early
+	if clients.IsNotFound(err) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			"An unexpected error occurred while attempting to delete the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+	}
+}
+
+// submitPlans submits the plans in order, stopping at the first plan that
+// fails to submit. Plans that were submitted according to state, and whose
+// steps have not changed since, are skipped. It returns whether each plan,
+// keyed by name, has been submitted. Steps clamped by safe mode are warned
+// about in diags.
+func (r *MovementBatchResource) submitPlans(ctx context.Context, plans []MovementBatchPlanModel, state *MovementBatchResourceModel, diags *diag.Diagnostics) (map[string]bool, error) {
+	submitted := make(map[string]bool, len(plans))
+	for _, plan := range plans {
+		submitted[plan.Name.ValueString()] = false
+	}
+
+	previous := map[string]MovementBatchPlanModel{}
+	var previousSubmitted map[string]bool
+
+	if state != nil {
+		for _, plan := range state.Plans {
+			previous[plan.Name.ValueString()] = plan
+		}
+
+		// The map is always known in state, so converting it cannot fail.
+		state.Submitted.ElementsAs(ctx, &previousSubmitted, false)
+	}
+
+	for _, plan := range plans {
+		name := plan.Name.ValueString()
+
+		if previousSubmitted[name] && movementStepsEqual(previous[name].Steps, plan.Steps) {
+			submitted[name] = true
+			continue
+		}
+
+		submitReq := expandMovementRequest(MovementResourceModel{
+			Name:    plan.Name,
+			Persist: types.BoolNull(),
+			Steps:   plan.Steps,
+		})
+		if r.client.Config.SafeMode {
+			clampSafeModeSteps(name, submitReq.Steps, diags)
+		}
+
+		err := r.client.SendJSON(ctx, clients.Request{
+			Method: http.MethodPost,
+			Path:   r.client.MovementPath(),
+			Body:   submitReq,
+		}, nil)
+
+		if err != nil {
+			return submitted, fmt.Errorf("movement plan %q: %w", name, err)
+		}
+
+		submitted[name] = true
+	}
+
+	return submitted, nil
+}
+
+// setState sets the computed attributes of the model from the submitted
+// plans.
+func (r *MovementBatchResource) setState(ctx context.Context, data *MovementBatchResourceModel, submitted map[string]bool, diags *diag.Diagnostics) {
+	names := make([]string, len(data.Plans))
+	for i, plan := range data.Plans {
+		names[i] = plan.Name.ValueString()
+	}
+
+	var d diag.Diagnostics
+	data.Id = types.StringValue(strings.Join(names, ","))
+	data.Submitted, d = types.MapValueFrom(ctx, types.BoolType, submitted)
+	diags.Append(d...)
+}
+
+// anySubmitted returns true if at least one plan has been submitted.
+func anySubmitted(submitted map[string]bool) bool {
+	for _, done := range submitted {
+		if done {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testMovementBatchResourceModel(names ...string) *MovementBatchResourceModel {
+	data := &MovementBatchResourceModel{
+		Id:        types.StringUnknown(),
+		Submitted: types.MapUnknown(types.BoolType),
+	}
+
+	for _, name := range names {
+		data.Plans = append(data.Plans, MovementBatchPlanModel{
+			Name:  types.StringValue(name),
+			Steps: []MovementStepsModel{testLinearStep("forward", 1)},
+		})
+	}
+
+	return data
+}
+
+func TestMovementBatchResource_partialApply(t *testing.T) {
+	var received []string
+	failing := "third"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req model.MovementRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		received = append(received, req.Name)
+
+		if req.Name == failing {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"device is busy"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"moving":true}`))
+	}))
+	defer server.Close()
+
+	client := testClient(t, server)
+	plan := testMovementBatchResourceModel("first", "second", "third", "fourth", "fifth")
+
+	// The third plan fails, so the apply fails with the first two recorded
+	// as submitted.
+	createResp := testCreateResource(t, NewMovementBatchResource(), client, plan)
+
+	if createResp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("expected a partial submission error, got: %v", createResp.Diagnostics)
+	}
+
+	if createResp.State.Raw.IsNull() {
+		t.Fatal("expected the submitted plans to be saved in state")
+	}
+
+	if expected := []string{"first", "second", "third"}; !slices.Equal(received, expected) {
+		t.Errorf("expected plans %v to be sent, got %v", expected, received)
+	}
+
+	var state MovementBatchResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(context.Background(), &state)...)
+
+	var submitted map[string]bool
+	state.Submitted.ElementsAs(context.Background(), &submitted, false)
+
+	expectedSubmitted := map[string]bool{"first": true, "second": true, "third": false, "fourth": false, "fifth": false}
+	for name, expected := range expectedSubmitted {
+		if submitted[name] != expected {
+			t.Errorf("expected plan %q submitted: %t, got: %t", name, expected, submitted[name])
+		}
+	}
+
+	// Once the device recovers, an update only submits the remaining plans.
+	received = nil
+	failing = ""
+
+	updateResp := testUpdateResource(t, NewMovementBatchResource(), client, &state, plan)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if expected := []string{"third", "fourth", "fifth"}; !slices.Equal(received, expected) {
+		t.Errorf("expected plans %v to be sent, got %v", expected, received)
+	}
+
+	updateResp.Diagnostics.Append(updateResp.State.Get(context.Background(), &state)...)
+	state.Submitted.ElementsAs(context.Background(), &submitted, false)
+
+	for name, done := range submitted {
+		if !done {
+			t.Errorf("expected plan %q to be submitted", name)
+		}
+	}
+}
+
+func TestMovementBatchResource_Create_firstPlanFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	resp := testCreateResource(t, NewMovementBatchResource(), testClient(t, server), testMovementBatchResourceModel("first", "second"))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state to be saved, got: %s", resp.State.Raw)
+	}
+}
+
+func TestMovementBatchResource_ValidateConfig_waypoint(t *testing.T) {
+	ctx := context.Background()
+
+	config := testMovementBatchResourceModel("first", "second")
+	config.Id = types.StringNull()
+	config.Submitted = types.MapNull(types.BoolType)
+	config.Plans[1].Steps = append(config.Plans[1].Steps, testWaypointStep("dock"))
+
+	schemaResp := testConfigureResource(t, &MovementBatchResource{}, nil)
+	resp := &resource.ValidateConfigResponse{}
+	(&MovementBatchResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    testResourceState(t, schemaResp, config).Raw,
+		},
+	}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+
+	expectedPath := path.Root("plans").AtListIndex(1).AtName("steps").AtListIndex(1).AtName("waypoint")
+	if errPath := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(expectedPath) {
+		t.Errorf("expected error at %s, got %s", expectedPath, errPath)
+	}
+}
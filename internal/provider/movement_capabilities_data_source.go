@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MovementCapabilitiesDataSource{}
+
+func NewMovementCapabilitiesDataSource() datasource.DataSource {
+	return &MovementCapabilitiesDataSource{}
+}
+
+// MovementCapabilitiesDataSource defines the data source implementation.
+type MovementCapabilitiesDataSource struct {
+	client *clients.Client
+}
+
+// MovementCapabilitiesDataSourceModel describes the data source data model.
+type MovementCapabilitiesDataSourceModel struct {
+	Directions  types.List    `tfsdk:"directions"`
+	MinDistance types.Float64 `tfsdk:"min_distance"`
+	MaxDistance types.Float64 `tfsdk:"max_distance"`
+	MinAngle    types.Int64   `tfsdk:"min_angle"`
+	MaxAngle    types.Int64   `tfsdk:"max_angle"`
+	Refresh     types.Bool    `tfsdk:"refresh"`
+}
+
+func (d *MovementCapabilitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_capabilities"
+}
+
+func (d *MovementCapabilitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the movements supported by the device, which can be used to build movement plans " +
+			"that the device accepts. When the provider sets `cache_capabilities`, the capabilities read when the provider " +
+			"was configured are returned without another request.",
+
+		Attributes: map[string]schema.Attribute{
+			"refresh": schema.BoolAttribute{
+				MarkdownDescription: "Discard the capabilities cached by the provider with `cache_capabilities` and read them " +
+					"from the device again, such as after a firmware update. Defaults to `false`.",
+				Optional: true,
+			},
+			"directions": schema.ListAttribute{
+				MarkdownDescription: "Directions the device can move in, such as `forward` or `left`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"min_distance": schema.Float64Attribute{
+				MarkdownDescription: "Minimum distance of a `forward` or `backward` step in meters.",
+				Computed:            true,
+			},
+			"max_distance": schema.Float64Attribute{
+				MarkdownDescription: "Maximum distance of a `forward` or `backward` step in meters.",
+				Computed:            true,
+			},
+			"min_angle": schema.Int64Attribute{
+				MarkdownDescription: "Minimum angle of a step in degrees.",
+				Computed:            true,
+			},
+			"max_angle": schema.Int64Attribute{
+				MarkdownDescription: "Maximum angle of a step in degrees.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *MovementCapabilitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *MovementCapabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MovementCapabilitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Refresh.ValueBool() {
+		d.client.InvalidateCapabilities()
+	}
+
+	readResp, err := d.client.MovementCapabilities(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	directions, diags := types.ListValueFrom(ctx, types.StringType, readResp.Directions)
+	resp.Diagnostics.Append(diags...)
+
+	data.Directions = directions
+	data.MinDistance = types.Float64Value(readResp.MinDistance)
+	data.MaxDistance = types.Float64Value(readResp.MaxDistance)
+	data.MinAngle = types.Int64Value(readResp.MinAngle)
+	data.MaxAngle = types.Int64Value(readResp.MaxAngle)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
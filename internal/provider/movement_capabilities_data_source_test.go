@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMovementCapabilitiesDataSource_Read(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/movement/capabilities" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{"directions":["forward","left","right"],"min_distance":0.5,"max_distance":20,"min_angle":0,"max_angle":180}`))
+	}))
+	defer server.Close()
+
+	resp := testReadDataSource(t, NewMovementCapabilitiesDataSource(), testClient(t, server), &MovementCapabilitiesDataSourceModel{
+		Directions: types.ListNull(types.StringType),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data MovementCapabilitiesDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	var directions []string
+	data.Directions.ElementsAs(context.Background(), &directions, false)
+
+	if expected := []string{"forward", "left", "right"}; !slices.Equal(directions, expected) {
+		t.Errorf("expected directions %v, got %v", expected, directions)
+	}
+
+	if !data.MinDistance.Equal(types.Float64Value(0.5)) || !data.MaxDistance.Equal(types.Float64Value(20)) {
+		t.Errorf("expected distance between 0.5 and 20, got %s and %s", data.MinDistance, data.MaxDistance)
+	}
+
+	if !data.MinAngle.Equal(types.Int64Value(0)) || !data.MaxAngle.Equal(types.Int64Value(180)) {
+		t.Errorf("expected angle between 0 and 180, got %s and %s", data.MinAngle, data.MaxAngle)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MovementLockResource{}
+
+func NewMovementLockResource() resource.Resource {
+	return &MovementLockResource{}
+}
+
+// MovementLockResource defines the resource implementation.
+type MovementLockResource struct {
+	client *clients.Client
+}
+
+// MovementLockResourceModel describes the resource data model.
+type MovementLockResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Ttl       types.String `tfsdk:"ttl"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// movementLockPath is the path of the movement lock endpoint.
+const movementLockPath = "/v1/movement/lock"
+
+// movementLockId is the ID of the movement lock, as a device has one.
+const movementLockId = "movement-lock"
+
+func (r *MovementLockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_lock"
+}
+
+func (r *MovementLockResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Acquires the movement lock of the device, which is released when the resource is destroyed. " +
+			"The resource is removed from state when the lock is released outside of Terraform or expires.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "Time after which the device releases the lock automatically, such as `30m`, " +
+					"so that the lock is not held forever if an apply is interrupted before the resource is destroyed. " +
+					"Rounded down to whole seconds, with a minimum of one second. Changing it acquires the lock again. The lock is held until released when omitted.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Time the lock expires at, as an RFC 3339 timestamp reported by the device. " +
+					"Null if the lock does not expire, or the device does not report it.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *MovementLockResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *MovementLockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data MovementLockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var createResp model.MovementLockResponse
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPost,
+		Path:   movementLockPath,
+		Body:   expandMovementLockRequest(data),
+	}, &createResp)
+
+	// Devices that acquire the lock without a response body do not report
+	// when it expires.
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		err = nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Save data into Terraform state
+	data.Id = types.StringValue(movementLockId)
+	data.ExpiresAt = flattenTimestamp(createResp.ExpiresAt)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MovementLockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MovementLockResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp model.MovementLockResponse
+	err := r.client.GetJSON(ctx, movementLockPath, &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Treat a released lock, such as one that expired, as a signal to
+	// acquire it again and return early
+	if !readResp.Locked {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Devices that do not report the expiry keep the value from state.
+	if !readResp.ExpiresAt.IsZero() {
+		data.ExpiresAt = flattenTimestamp(readResp.ExpiresAt)
+	}
+
+	data.Id = types.StringValue(movementLockId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only saves the plan, as changing ttl replaces the resource and the
+// other attributes are computed.
+func (r *MovementLockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	var data MovementLockResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MovementLockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "delete")
+		return
+	}
+
+	// Releasing the lock again has no effect, so transient errors are
+	// retried.
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodDelete,
+		Path:   movementLockPath,
+		Retry:  true,
+	}, nil)
+
+	// Treat HTTP 404 Not Found status as the lock already being released
+	// and return early
+	if clients.IsNotFound(err) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			"An unexpected error occurred while attempting to delete the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+}
+
+func expandMovementLockRequest(in MovementLockResourceModel) model.MovementLockRequest {
+	var out model.MovementLockRequest
+
+	// The duration is validated by the schema. Durations shorter than a
+	// second are rounded up, as omitting the TTL would hold the lock forever.
+	if ttl, err := time.ParseDuration(in.Ttl.ValueString()); err == nil {
+		out.TTLSeconds = max(int64(ttl/time.Second), 1)
+	}
+
+	return out
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandMovementLockRequest(t *testing.T) {
+	testCases := map[string]struct {
+		ttl      types.String
+		expected string
+	}{
+		"no-ttl":       {ttl: types.StringNull(), expected: `{}`},
+		"minutes":      {ttl: types.StringValue("30m"), expected: `{"ttl_seconds":1800}`},
+		"fractional":   {ttl: types.StringValue("90.5s"), expected: `{"ttl_seconds":90}`},
+		"sub-second":   {ttl: types.StringValue("500ms"), expected: `{"ttl_seconds":1}`},
+		"combined":     {ttl: types.StringValue("1h30m"), expected: `{"ttl_seconds":5400}`},
+		"unknown":      {ttl: types.StringUnknown(), expected: `{}`},
+		"non-duration": {ttl: types.StringValue("forever"), expected: `{}`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(expandMovementLockRequest(MovementLockResourceModel{Ttl: testCase.ttl}))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(body) != testCase.expected {
+				t.Errorf("expected body %s, got %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestMovementLockResource_Create(t *testing.T) {
+	testCases := map[string]struct {
+		body              string
+		expectedExpiresAt types.String
+	}{
+		"expiry": {
+			body:              `{"locked":true,"expires_at":"2024-01-02T15:30:00Z"}`,
+			expectedExpiresAt: types.StringValue("2024-01-02T15:30:00Z"),
+		},
+		"no-expiry": {
+			body:              `{"locked":true}`,
+			expectedExpiresAt: types.StringNull(),
+		},
+		"empty-response": {
+			body:              "",
+			expectedExpiresAt: types.StringNull(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doer := &testDoer{
+				responses: map[string]string{
+					"POST /v1/movement/lock": testCase.body,
+				},
+			}
+
+			resp := testCreateResource(t, NewMovementLockResource(), &clients.Client{HttpClient: doer}, &MovementLockResourceModel{
+				Id:        types.StringUnknown(),
+				Ttl:       types.StringValue("30m"),
+				ExpiresAt: types.StringUnknown(),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			body, _ := io.ReadAll(doer.requests[0].Body)
+			if string(body) != `{"ttl_seconds":1800}` {
+				t.Errorf("unexpected request body %s", body)
+			}
+
+			var data MovementLockResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.ExpiresAt.Equal(testCase.expectedExpiresAt) {
+				t.Errorf("expected expires_at %s, got %s", testCase.expectedExpiresAt, data.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestMovementLockResource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		body              string
+		expectRemoved     bool
+		expectedExpiresAt types.String
+	}{
+		"extended": {
+			body:              `{"locked":true,"expires_at":"2024-01-02T16:00:00Z"}`,
+			expectedExpiresAt: types.StringValue("2024-01-02T16:00:00Z"),
+		},
+		"not-reported": {
+			body:              `{"locked":true}`,
+			expectedExpiresAt: types.StringValue("2024-01-02T15:30:00Z"),
+		},
+		"expired": {
+			body:          `{"locked":false}`,
+			expectRemoved: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				HttpClient: &testDoer{
+					responses: map[string]string{
+						"GET /v1/movement/lock": testCase.body,
+					},
+				},
+			}
+
+			resp := testReadResource(t, NewMovementLockResource(), client, &MovementLockResourceModel{
+				Id:        types.StringValue("movement-lock"),
+				Ttl:       types.StringValue("30m"),
+				ExpiresAt: types.StringValue("2024-01-02T15:30:00Z"),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if testCase.expectRemoved {
+				if !resp.State.Raw.IsNull() {
+					t.Error("expected resource to be removed from state")
+				}
+
+				return
+			}
+
+			var data MovementLockResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.ExpiresAt.Equal(testCase.expectedExpiresAt) {
+				t.Errorf("expected expires_at %s, got %s", testCase.expectedExpiresAt, data.ExpiresAt)
+			}
+		})
+	}
+}
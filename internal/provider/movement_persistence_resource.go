@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MovementPersistenceResource{}
+
+func NewMovementPersistenceResource() resource.Resource {
+	return &MovementPersistenceResource{}
+}
+
+// MovementPersistenceResource defines the resource implementation.
+type MovementPersistenceResource struct {
+	client *clients.Client
+}
+
+// MovementPersistenceResourceModel describes the resource data model.
+type MovementPersistenceResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Persisted types.Bool   `tfsdk:"persisted"`
+}
+
+func (r *MovementPersistenceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_persistence"
+}
+
+func (r *MovementPersistenceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Saves an existing movement plan to the filesystem of the device, or removes it from the filesystem, " +
+			"without submitting its steps again. This manages persistence separately from the content of the plan, such as a plan " +
+			"submitted by a `pathfinder_movement` resource without `persist`. " +
+			"Destroying the resource leaves the movement plan as it is.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the existing movement plan. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"persisted": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the movement plan is saved to the filesystem of the device. " +
+					"Changes made outside of Terraform are detected from the persisted movement plans the device lists.",
+				Required: true,
+			},
+		},
+	}
+}
+
+func (r *MovementPersistenceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *MovementPersistenceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
+	var data MovementPersistenceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPersistence(ctx, data); err != nil {
+		addMovementPersistenceError(&resp.Diagnostics, data, "Unable to Create Resource", err)
+		return
+	}
+
+	// Save data into Terraform state
+	data.Id = types.StringValue(data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MovementPersistenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MovementPersistenceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp []model.MovementPlanItem
+	err := r.client.GetJSON(ctx, "/v1/movement/plans", &readResp)
+
+	// Devices that do not list their persisted movement plans do not allow
+	// drift to be detected, so the value from state is kept.
+	if clients.IsNotFound(err) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	persisted := slices.ContainsFunc(readResp, func(item model.MovementPlanItem) bool {
+		return item.Name == data.Name.ValueString()
+	})
+
+	data.Persisted = types.BoolValue(persisted)
+	data.Id = types.StringValue(data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MovementPersistenceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	var data MovementPersistenceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPersistence(ctx, data); err != nil {
+		addMovementPersistenceError(&resp.Diagnostics, data, "Unable to Update Resource", err)
+		return
+	}
+
+	data.Id = types.StringValue(data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the resource from state, as the persistence the
+// movement plan had before the resource was created is not known.
+func (r *MovementPersistenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// setPersistence saves the movement plan of the model to the filesystem, or
+// removes it from the filesystem. The request is retried, as repeating it
+// leaves the movement plan in the same state.
+func (r *MovementPersistenceResource) setPersistence(ctx context.Context, data MovementPersistenceResourceModel) error {
+	return r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPut,
+		Path:   r.client.MovementPath() + "/" + url.PathEscape(data.Name.ValueString()) + "/persistence",
+		Body:   model.MovementPersistenceRequest{Persist: data.Persisted.ValueBool()},
+		Retry:  true,
+	}, nil)
+}
+
+// addMovementPersistenceError adds an error for a failed persistence change,
+// explaining a HTTP 404 Not Found status as a missing movement plan.
+func addMovementPersistenceError(diags *diag.Diagnostics, data MovementPersistenceResourceModel, summary string, err error) {
+	if clients.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Movement Plan Not Found",
+			fmt.Sprintf("The device does not have a movement plan named %q. "+
+				"Submit the movement plan, such as with a pathfinder_movement resource, before managing its persistence.", data.Name.ValueString()),
+		)
+
+		return
+	}
+
+	diags.AddError(
+		summary,
+		"An unexpected error occurred while attempting to change the persistence of the movement plan. "+
+			"Please retry the operation or report this issue to the provider developers.\n\n"+
+			"HTTP Error: "+err.Error(),
+	)
+}
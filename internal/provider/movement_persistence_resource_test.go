@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMovementPersistenceResource_Create(t *testing.T) {
+	testCases := map[string]struct {
+		persisted     bool
+		expectedBody  string
+		missing       bool
+		expectedError string
+	}{
+		"persist": {
+			persisted:    true,
+			expectedBody: `{"persist":true}`,
+		},
+		"unpersist": {
+			persisted:    false,
+			expectedBody: `{"persist":false}`,
+		},
+		"missing-plan": {
+			persisted:     true,
+			expectedBody:  `{"persist":true}`,
+			missing:       true,
+			expectedError: "Movement Plan Not Found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			responses := map[string]string{}
+			if !testCase.missing {
+				responses["PUT /v1/movement-plan/patrol route/persistence"] = ""
+			}
+
+			doer := &testDoer{responses: responses}
+
+			resp := testCreateResource(t, NewMovementPersistenceResource(), &clients.Client{HttpClient: doer}, &MovementPersistenceResourceModel{
+				Id:        types.StringUnknown(),
+				Name:      types.StringValue("patrol route"),
+				Persisted: types.BoolValue(testCase.persisted),
+			})
+
+			if testCase.expectedError != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != testCase.expectedError {
+					t.Errorf("expected %s error, got: %s", testCase.expectedError, summary)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if len(doer.requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(doer.requests))
+			}
+
+			req := doer.requests[0]
+			if req.Method != http.MethodPut || req.URL.EscapedPath() != "/v1/movement-plan/patrol%20route/persistence" {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL.EscapedPath())
+			}
+
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != testCase.expectedBody {
+				t.Errorf("expected request body %s, got %s", testCase.expectedBody, body)
+			}
+
+			if testCase.expectedError != "" {
+				return
+			}
+
+			var data MovementPersistenceResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.Id.ValueString() != "patrol route" {
+				t.Errorf("expected id patrol route, got %s", data.Id)
+			}
+		})
+	}
+}
+
+func TestMovementPersistenceResource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		responses         map[string]string
+		expectedPersisted types.Bool
+	}{
+		"persisted": {
+			responses:         map[string]string{"GET /v1/movement/plans": `[{"name":"square"},{"name":"patrol"}]`},
+			expectedPersisted: types.BoolValue(true),
+		},
+		"drift": {
+			responses:         map[string]string{"GET /v1/movement/plans": `[{"name":"square"}]`},
+			expectedPersisted: types.BoolValue(false),
+		},
+		"no-plans": {
+			responses:         map[string]string{"GET /v1/movement/plans": `[]`},
+			expectedPersisted: types.BoolValue(false),
+		},
+		"listing-unsupported": {
+			responses:         map[string]string{},
+			expectedPersisted: types.BoolValue(true),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{HttpClient: &testDoer{responses: testCase.responses}}
+
+			resp := testReadResource(t, NewMovementPersistenceResource(), client, &MovementPersistenceResourceModel{
+				Id:        types.StringValue("patrol"),
+				Name:      types.StringValue("patrol"),
+				Persisted: types.BoolValue(true),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data MovementPersistenceResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Persisted.Equal(testCase.expectedPersisted) {
+				t.Errorf("expected persisted %s, got %s", testCase.expectedPersisted, data.Persisted)
+			}
+		})
+	}
+}
+
+func TestMovementPersistenceResource_Update(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"PUT /v1/movement-plan/patrol/persistence": "",
+		},
+	}
+
+	state := &MovementPersistenceResourceModel{
+		Id:        types.StringValue("patrol"),
+		Name:      types.StringValue("patrol"),
+		Persisted: types.BoolValue(true),
+	}
+	plan := &MovementPersistenceResourceModel{
+		Id:        types.StringValue("patrol"),
+		Name:      types.StringValue("patrol"),
+		Persisted: types.BoolValue(false),
+	}
+
+	resp := testUpdateResource(t, NewMovementPersistenceResource(), &clients.Client{HttpClient: doer}, state, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(doer.requests))
+	}
+
+	body, _ := io.ReadAll(doer.requests[0].Body)
+	if string(body) != `{"persist":false}` {
+		t.Errorf("unexpected request body %s", body)
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// movementPosition is the position and heading of the device, relative to
+// where it was when a movement plan started.
+//
+// The device starts at the origin facing along the positive Y axis. Headings
+// are in degrees clockwise from the positive Y axis, in the range [0, 360),
+// so that a heading of 90 faces along the positive X axis.
+type movementPosition struct {
+	X       float64
+	Y       float64
+	Heading float64
+}
+
+// predictMovementPosition computes the position of the device after executing
+// the steps by dead-reckoning, assuming each step is executed exactly.
+//
+// Forward and backward steps turn by the step angle and then move along the
+// resulting heading, while left and right steps only rotate the device.
+func predictMovementPosition(steps []MovementStepsModel) movementPosition {
+	var position movementPosition
+
+	for _, step := range steps {
+		position = position.advance(step, false)
+	}
+
+	return position
+}
+
+// advance returns the position of the device after executing the step. When
+// absolute is true, the angle of the step is the heading to face, as in the
+// absolute coordinate mode, rather than the angle to turn by.
+func (p movementPosition) advance(step MovementStepsModel, absolute bool) movementPosition {
+	angle := float64(step.Angle.ValueInt64())
+
+	switch direction := step.Direction.ValueString(); {
+	case absolute:
+		p.Heading = angle
+	case direction == "left":
+		p.Heading -= angle
+	case direction == "right", validators.IsLinearDirection(direction):
+		p.Heading += angle
+	}
+
+	if validators.IsLinearDirection(step.Direction.ValueString()) {
+		distance := step.Distance.ValueFloat64()
+		if step.Direction.ValueString() == "backward" {
+			distance = -distance
+		}
+
+		radians := p.Heading * math.Pi / 180
+		p.X += distance * math.Sin(radians)
+		p.Y += distance * math.Cos(radians)
+	}
+
+	p.Heading = normalizeHeading(p.Heading)
+
+	return p
+}
+
+// MovementWaypointModel describes a named position that movement steps can
+// move the device to, relative to where it was when the movement plan
+// started.
+type MovementWaypointModel struct {
+	X types.Float64 `tfsdk:"x"`
+	Y types.Float64 `tfsdk:"y"`
+}
+
+// movementWaypointAttrTypes are the attribute types of MovementWaypointModel.
+var movementWaypointAttrTypes = map[string]attr.Type{
+	"x": types.Float64Type,
+	"y": types.Float64Type,
+}
+
+// waypointError is returned when a step referencing a waypoint cannot be
+// resolved.
+type waypointError struct {
+	// Index of the step in the movement plan, starting at 0
+	Step    int
+	Summary string
+	Detail  string
+}
+
+func (e *waypointError) Error() string {
+	return fmt.Sprintf("step %d: %s", e.Step, e.Detail)
+}
+
+// addWaypointError adds err to diags, as an error on the waypoint of the step
+// when it is a waypointError.
+func addWaypointError(diags *diag.Diagnostics, err error) {
+	var waypointErr *waypointError
+	if !errors.As(err, &waypointErr) {
+		diags.AddAttributeError(path.Root("steps"), "Unable to Resolve Waypoints", err.Error())
+		return
+	}
+
+	diags.AddAttributeError(path.Root("steps").AtListIndex(waypointErr.Step).AtName("waypoint"), waypointErr.Summary, waypointErr.Detail)
+}
+
+// resolveMovementWaypoints returns the steps with each step that references
+// a waypoint replaced by forward steps moving the device to the waypoint, so
+// that the steps can be sent to the device. When absolute is true, the steps
+// are resolved for the absolute coordinate mode.
+//
+// The position of the device is predicted by dead-reckoning from the start
+// of the plan. The device turns to face the waypoint, rounded to a whole
+// degree as the device only accepts whole angles, and then moves the
+// distance to the waypoint, split into several steps when it is further than
+// a single step can move. Steps referencing a waypoint the device is already
+// at are dropped.
+func resolveMovementWaypoints(steps []MovementStepsModel, waypoints map[string]MovementWaypointModel, absolute bool) ([]MovementStepsModel, error) {
+	var position movementPosition
+
+	resolved := make([]MovementStepsModel, 0, len(steps))
+	for i, step := range steps {
+		if step.Waypoint.IsNull() {
+			resolved = append(resolved, step)
+			position = position.advance(step, absolute)
+
+			continue
+		}
+
+		waypoint, ok := waypoints[step.Waypoint.ValueString()]
+		if !ok {
+			return nil, &waypointError{
+				Step:    i,
+				Summary: "Undefined Waypoint",
+				Detail:  fmt.Sprintf("The waypoint %q is not defined in waypoints.", step.Waypoint.ValueString()),
+			}
+		}
+
+		deltaX := waypoint.X.ValueFloat64() - position.X
+		deltaY := waypoint.Y.ValueFloat64() - position.Y
+		// Distances are rounded to micrometers, hiding floating point noise
+		// like roundPosition.
+		distance := roundPosition(math.Hypot(deltaX, deltaY))
+		if distance == 0 {
+			continue
+		}
+
+		if distance < validators.MinMovementStepDistance {
+			return nil, &waypointError{
+				Step:    i,
+				Summary: "Waypoint Too Close",
+				Detail: fmt.Sprintf("The waypoint %q is predicted to be %.2f meters away, which is less than the minimum distance "+
+					"of a step of %g meters.", step.Waypoint.ValueString(), distance, validators.MinMovementStepDistance),
+			}
+		}
+
+		heading := normalizeHeading(math.Round(bearing(position.X, position.Y, waypoint.X.ValueFloat64(), waypoint.Y.ValueFloat64())))
+
+		angle := heading
+		if !absolute {
+			angle = normalizeHeading(heading - position.Heading)
+		}
+
+		count := math.Ceil(distance / validators.MaxMovementStepDistance)
+		for j := 0; j < int(count); j++ {
+			moveStep := MovementStepsModel{
+				Angle:     types.Int64Value(int64(angle)),
+				Direction: types.StringValue("forward"),
+				Distance:  types.Float64Value(roundPosition(distance / count)),
+				Speed:     step.Speed,
+				Waypoint:  types.StringNull(),
+			}
+
+			resolved = append(resolved, moveStep)
+			position = position.advance(moveStep, absolute)
+
+			// Only the first step turns in the relative coordinate mode.
+			if !absolute {
+				angle = 0
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// normalizeHeading returns the heading in the range [0, 360).
+func normalizeHeading(heading float64) float64 {
+	heading = math.Mod(heading, 360)
+	if heading < 0 {
+		heading += 360
+	}
+
+	return heading
+}
+
+// defaultMovementSpeed is the speed in meters per second assumed for steps
+// that do not set a speed, matching the default speed of the device.
+const defaultMovementSpeed = 0.5
+
+// estimateMovementDuration returns the time in seconds the device takes to
+// execute the steps. With safeMode, the steps are estimated as they are sent
+// to the device, clamped by clampSafeModeSteps.
+//
+// Forward and backward steps are assumed to move at a constant speed, without
+// accelerating or decelerating, while rotating is assumed to be instant. The
+// estimate is therefore a lower bound of the actual duration.
+func estimateMovementDuration(steps []MovementStepsModel, safeMode bool) float64 {
+	var duration float64
+
+	for _, step := range steps {
+		if !validators.IsLinearDirection(step.Direction.ValueString()) {
+			continue
+		}
+
+		distance := step.Distance.ValueFloat64()
+		speed := defaultMovementSpeed
+		if !step.Speed.IsNull() {
+			speed = step.Speed.ValueFloat64()
+		}
+
+		if safeMode {
+			distance = min(distance, safeModeMaxStepDistance)
+			speed = min(speed, safeModeMaxStepSpeed)
+		}
+
+		duration += distance / speed
+	}
+
+	return duration
+}
+
+// movementStepsKnown returns true if all values of the steps that affect the
+// movement of the device are known.
+func movementStepsKnown(steps []MovementStepsModel) bool {
+	for _, step := range steps {
+		if step.Angle.IsUnknown() || step.Direction.IsUnknown() || step.Distance.IsUnknown() || step.Speed.IsUnknown() || step.Waypoint.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// movementStepsEqual returns true if the steps have the same values. The
+// values are compared with Equal, as framework values hold internal state,
+// such as the precision of numbers, that reflect.DeepEqual would compare.
+func movementStepsEqual(a, b []MovementStepsModel) bool {
+	return slices.EqualFunc(a, b, func(x, y MovementStepsModel) bool {
+		return x.Angle.Equal(y.Angle) &&
+			x.Direction.Equal(y.Direction) &&
+			x.Distance.Equal(y.Distance) &&
+			x.Speed.Equal(y.Speed) &&
+			x.Waypoint.Equal(y.Waypoint)
+	})
+}
+
+// Limits of movement plans accepted by the device.
+const (
+	maxMovementSteps        = 50
+	maxMovementPlanDistance = 1000.0
+)
+
+// Limits of movement steps sent to the device when the provider is
+// configured with safe_mode.
+const (
+	safeModeMaxStepDistance = 2.0
+	safeModeMaxStepSpeed    = 0.25
+)
+
+// clampSafeModeSteps clamps the distance and speed of the steps of the named
+// movement plan to the safe mode limits, adding a warning for every step
+// that is clamped. Steps that do not set a speed are sent the safe mode
+// speed without a warning, as the default speed of the device exceeds it.
+func clampSafeModeSteps(name string, steps []model.MovementStepItem, diags *diag.Diagnostics) {
+	for i := range steps {
+		if steps[i].Distance > safeModeMaxStepDistance {
+			diags.AddWarning(
+				"Movement Step Clamped by Safe Mode",
+				fmt.Sprintf("Step %d of the movement plan %q has a distance of %g meters, which is sent as %g meters, "+
+					"as the provider is configured with safe_mode set to true.", i, name, steps[i].Distance, safeModeMaxStepDistance),
+			)
+
+			steps[i].Distance = safeModeMaxStepDistance
+		}
+
+		switch {
+		case steps[i].Speed == 0:
+			steps[i].Speed = safeModeMaxStepSpeed
+		case steps[i].Speed > safeModeMaxStepSpeed:
+			diags.AddWarning(
+				"Movement Step Clamped by Safe Mode",
+				fmt.Sprintf("Step %d of the movement plan %q has a speed of %g meters per second, which is sent as %g meters "+
+					"per second, as the provider is configured with safe_mode set to true.", i, name, steps[i].Speed, safeModeMaxStepSpeed),
+			)
+
+			steps[i].Speed = safeModeMaxStepSpeed
+		}
+	}
+}
+
+// movementPlanDistanceError returns a message when the forward and backward
+// steps move the device further than maxMovementPlanDistance in total, or an
+// empty string otherwise.
+//
+// Unknown distances, and the distances of steps moving to a waypoint before
+// they are resolved, are not counted. The total is then a lower bound, which
+// is still too long if it exceeds the limit.
+func movementPlanDistanceError(steps []MovementStepsModel) string {
+	var total float64
+
+	for _, step := range steps {
+		if validators.IsLinearDirection(step.Direction.ValueString()) && !step.Distance.IsUnknown() {
+			total += step.Distance.ValueFloat64()
+		}
+	}
+
+	if total <= maxMovementPlanDistance {
+		return ""
+	}
+
+	return fmt.Sprintf("The movement plan moves %g meters in total, at most %g meters are allowed.", total, maxMovementPlanDistance)
+}
+
+// validateMovementSteps validates the steps of a movement plan against the
+// rules enforced by the schema of the movement resource, returning a message
+// for each rule that is broken. Unknown values are not validated.
+func validateMovementSteps(steps []MovementStepsModel) []string {
+	var errs []string
+
+	if len(steps) == 0 {
+		errs = append(errs, "The movement plan must have at least one step.")
+	}
+
+	if len(steps) > maxMovementSteps {
+		errs = append(errs, fmt.Sprintf("The movement plan has %d steps, at most %d are allowed.", len(steps), maxMovementSteps))
+	}
+
+	if detail := movementPlanDistanceError(steps); detail != "" {
+		errs = append(errs, detail)
+	}
+
+	for i, step := range steps {
+		// Steps are numbered by their index in the list, like the steps of
+		// waypointError and clampSafeModeSteps.
+		add := func(detail string) {
+			if detail != "" {
+				errs = append(errs, fmt.Sprintf("Step %d: %s", i, detail))
+			}
+		}
+
+		// The angle, direction, and distance of steps moving to a waypoint
+		// are computed from the waypoint, which is only known to the
+		// resource.
+		if !step.Waypoint.IsUnknown() {
+			for _, attribute := range []struct {
+				name  string
+				value attr.Value
+			}{{"angle", step.Angle}, {"direction", step.Direction}, {"distance", step.Distance}} {
+				add(validators.MovementStepWaypointError(attribute.name, !step.Waypoint.IsNull(), !attribute.value.IsNull()))
+			}
+		}
+
+		if step.Waypoint.IsNull() || step.Waypoint.IsUnknown() {
+			if !step.Angle.IsNull() && !step.Angle.IsUnknown() {
+				add(validators.MovementAngleError(step.Angle.ValueInt64()))
+			}
+
+			if !step.Direction.IsNull() && !step.Direction.IsUnknown() {
+				if detail := validators.MovementDirectionError(step.Direction.ValueString()); detail != "" {
+					add(detail)
+				} else if !step.Distance.IsUnknown() {
+					add(validators.MovementStepDistanceError(step.Direction.ValueString(), !step.Distance.IsNull()))
+				}
+			}
+		}
+
+		if !step.Distance.IsNull() && !step.Distance.IsUnknown() {
+			add(validators.MovementStepDistanceRangeError(step.Distance.ValueFloat64()))
+		}
+
+		if !step.Speed.IsNull() && !step.Speed.IsUnknown() {
+			add(validators.MovementStepSpeedError(step.Speed.ValueFloat64()))
+		}
+	}
+
+	return errs
+}
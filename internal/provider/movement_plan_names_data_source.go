@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MovementPlanNamesDataSource{}
+
+func NewMovementPlanNamesDataSource() datasource.DataSource {
+	return &MovementPlanNamesDataSource{}
+}
+
+// MovementPlanNamesDataSource defines the data source implementation.
+type MovementPlanNamesDataSource struct {
+	client *clients.Client
+}
+
+// MovementPlanNamesDataSourceModel describes the data source data model.
+type MovementPlanNamesDataSourceModel struct {
+	Names []types.String `tfsdk:"names"`
+}
+
+func (d *MovementPlanNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_plan_names"
+}
+
+func (d *MovementPlanNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the names of the movement plans persisted to the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the persisted movement plans. Empty if no movement plans have been persisted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *MovementPlanNamesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *MovementPlanNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MovementPlanNamesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp []model.MovementPlanItem
+	err := d.client.GetJSON(ctx, "/v1/movement/plans", &readResp)
+
+	// Treat HTTP 404 Not Found status, or an empty response body, as no
+	// movement plans having been persisted yet
+	if clients.IsNotFound(err) || errors.Is(err, clients.ErrEmptyResponse) {
+		err = nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.Names = make([]types.String, len(readResp))
+	for i, plan := range readResp {
+		data.Names[i] = types.StringValue(plan.Name)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
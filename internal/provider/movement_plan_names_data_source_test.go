@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovementPlanNamesDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		status   int
+		body     string
+		expected []string
+	}{
+		"plans": {
+			status:   http.StatusOK,
+			body:     `[{"name":"patrol","persist":true},{"name":"square"}]`,
+			expected: []string{"patrol", "square"},
+		},
+		"empty-list": {
+			status:   http.StatusOK,
+			body:     `[]`,
+			expected: []string{},
+		},
+		"null": {
+			status:   http.StatusOK,
+			body:     `null`,
+			expected: []string{},
+		},
+		"empty-body": {
+			status:   http.StatusOK,
+			expected: []string{},
+		},
+		"not-found": {
+			status:   http.StatusNotFound,
+			body:     `{"message":"no plans","status":404}`,
+			expected: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/movement/plans" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewMovementPlanNamesDataSource(), testClient(t, server), &MovementPlanNamesDataSourceModel{})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data MovementPlanNamesDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.Names == nil {
+				t.Fatalf("expected names to be a list, got null")
+			}
+
+			if len(data.Names) != len(testCase.expected) {
+				t.Fatalf("expected %d names, got %d", len(testCase.expected), len(data.Names))
+			}
+
+			for i, name := range testCase.expected {
+				if data.Names[i].ValueString() != name {
+					t.Errorf("expected name %q at %d, got %s", name, i, data.Names[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMovementPlanNamesDataSource_Read_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	resp := testReadDataSource(t, NewMovementPlanNamesDataSource(), testClient(t, server), &MovementPlanNamesDataSourceModel{})
+
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected error diagnostics, got none")
+	}
+}
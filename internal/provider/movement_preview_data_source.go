@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MovementPreviewDataSource{}
+
+func NewMovementPreviewDataSource() datasource.DataSource {
+	return &MovementPreviewDataSource{}
+}
+
+// MovementPreviewDataSource defines the data source implementation.
+type MovementPreviewDataSource struct{}
+
+// MovementPreviewDataSourceModel describes the data source data model.
+type MovementPreviewDataSourceModel struct {
+	Steps        []MovementStepsModel             `tfsdk:"steps"`
+	Waypoints    map[string]MovementWaypointModel `tfsdk:"waypoints"`
+	FinalX       types.Float64                    `tfsdk:"final_x"`
+	FinalY       types.Float64                    `tfsdk:"final_y"`
+	FinalHeading types.Float64                    `tfsdk:"final_heading"`
+}
+
+func (d *MovementPreviewDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_movement_preview"
+}
+
+func (d *MovementPreviewDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Predict the position and heading of the device after executing movement steps, without sending them to the device. " +
+			"The device starts at `x = 0` and `y = 0` facing along the positive Y axis, with headings in degrees clockwise from the positive Y axis. " +
+			"Forward and backward steps turn by their angle and then move along the resulting heading, while left and right steps rotate in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"waypoints": schema.MapNestedAttribute{
+				MarkdownDescription: "Named positions that steps can move the device to with `waypoint`, in meters relative to " +
+					"where the device starts.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.Float64Attribute{
+							MarkdownDescription: "Position of the waypoint along the X axis in meters.",
+							Required:            true,
+						},
+						"y": schema.Float64Attribute{
+							MarkdownDescription: "Position of the waypoint along the Y axis in meters.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"final_x": schema.Float64Attribute{
+				MarkdownDescription: "Predicted position of the device along the X axis in meters.",
+				Computed:            true,
+			},
+			"final_y": schema.Float64Attribute{
+				MarkdownDescription: "Predicted position of the device along the Y axis in meters.",
+				Computed:            true,
+			},
+			"final_heading": schema.Float64Attribute{
+				MarkdownDescription: "Predicted heading of the device in degrees, from 0 up to but excluding 360.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"steps": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					// At maximum, we can have 50 steps.
+					listvalidator.SizeAtMost(50),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"angle": schema.Int64Attribute{
+							MarkdownDescription: "Angle to move the device in degrees, between 0 and 360. Required unless `waypoint` is set.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								validators.MovementAngle(),
+							},
+						},
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "Direction to move the device in. `forward` and `backward` move the device in a line, " +
+								"`left` and `right` rotate the device in place. Required unless `waypoint` is set.",
+							Optional: true,
+							Validators: []validator.String{
+								validators.MovementDirection(),
+							},
+						},
+						"distance": schema.Float64Attribute{
+							MarkdownDescription: "Distance to move the device in meters. Required for `forward` and `backward` steps, " +
+								"must not be set for `left` and `right` steps.",
+							Optional: true,
+							Validators: []validator.Float64{
+								float64validator.Between(1.0, 100),
+							},
+						},
+						"speed": schema.Float64Attribute{
+							MarkdownDescription: "Speed to move the device at in meters per second. Does not affect the predicted position.",
+							Optional:            true,
+						},
+						"waypoint": schema.StringAttribute{
+							MarkdownDescription: "Name of a waypoint in `waypoints` to move the device to, instead of setting `angle`, `direction`, " +
+								"and `distance`. The device turns to face the waypoint and moves forward to it.",
+							Optional: true,
+						},
+					},
+					Validators: []validator.Object{
+						validators.MovementStepDistance(),
+						validators.MovementStepWaypoint(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MovementPreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MovementPreviewDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	steps, err := resolveMovementWaypoints(data.Steps, data.Waypoints, false)
+	if err != nil {
+		addWaypointError(&resp.Diagnostics, err)
+		return
+	}
+
+	position := predictMovementPosition(steps)
+
+	data.FinalX = types.Float64Value(roundPosition(position.X))
+	data.FinalY = types.Float64Value(roundPosition(position.Y))
+	data.FinalHeading = types.Float64Value(roundPosition(position.Heading))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// roundPosition rounds to micrometer precision, hiding floating point noise
+// from the trigonometric functions, such as 1e-16 instead of 0. Adding zero
+// turns a negative zero into a positive zero.
+func roundPosition(value float64) float64 {
+	return math.Round(value*1e6)/1e6 + 0
+}
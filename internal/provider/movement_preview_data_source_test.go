@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMovementPreviewDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		steps     []MovementStepsModel
+		waypoints map[string]MovementWaypointModel
+		x         float64
+		y         float64
+		heading   float64
+	}{
+		"forward": {
+			steps:   []MovementStepsModel{testLinearStep("forward", 1)},
+			x:       0,
+			y:       1,
+			heading: 0,
+		},
+		"backward": {
+			steps:   []MovementStepsModel{testLinearStep("backward", 2)},
+			x:       0,
+			y:       -2,
+			heading: 0,
+		},
+		"right then forward": {
+			steps:   []MovementStepsModel{testRotationStep("right", 90), testLinearStep("forward", 2)},
+			x:       2,
+			y:       0,
+			heading: 90,
+		},
+		"left then forward": {
+			steps:   []MovementStepsModel{testRotationStep("left", 90), testLinearStep("forward", 1)},
+			x:       -1,
+			y:       0,
+			heading: 270,
+		},
+		"forward with angle": {
+			steps: []MovementStepsModel{{
+				Angle:     types.Int64Value(180),
+				Direction: types.StringValue("forward"),
+				Distance:  types.Float64Value(3),
+				Speed:     types.Float64Null(),
+			}},
+			x:       0,
+			y:       -3,
+			heading: 180,
+		},
+		"waypoint": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 1),
+				testWaypointStep("dock"),
+			},
+			waypoints: map[string]MovementWaypointModel{
+				"dock": testWaypoint(3, 1),
+			},
+			x:       3,
+			y:       1,
+			heading: 90,
+		},
+		"square returns to origin": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 1),
+				testRotationStep("right", 90),
+				testLinearStep("forward", 1),
+				testRotationStep("right", 90),
+				testLinearStep("forward", 1),
+				testRotationStep("right", 90),
+				testLinearStep("forward", 1),
+				testRotationStep("right", 90),
+			},
+			x:       0,
+			y:       0,
+			heading: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := testReadDataSource(t, NewMovementPreviewDataSource(), nil, MovementPreviewDataSourceModel{
+				Steps:        testCase.steps,
+				Waypoints:    testCase.waypoints,
+				FinalX:       types.Float64Null(),
+				FinalY:       types.Float64Null(),
+				FinalHeading: types.Float64Null(),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data MovementPreviewDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if got := data.FinalX.ValueFloat64(); got != testCase.x {
+				t.Errorf("expected final_x %v, got %v", testCase.x, got)
+			}
+
+			if got := data.FinalY.ValueFloat64(); got != testCase.y {
+				t.Errorf("expected final_y %v, got %v", testCase.y, got)
+			}
+
+			if got := data.FinalHeading.ValueFloat64(); got != testCase.heading {
+				t.Errorf("expected final_heading %v, got %v", testCase.heading, got)
+			}
+		})
+	}
+}
@@ -7,26 +7,42 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/planmodifiers"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MovementResource{}
+var _ resource.ResourceWithModifyPlan = &MovementResource{}
+var _ resource.ResourceWithValidateConfig = &MovementResource{}
 
 func NewMovementResource() resource.Resource {
 	return &MovementResource{}
@@ -35,20 +51,66 @@ func NewMovementResource() resource.Resource {
 // MovementResource defines the resource implementation.
 type MovementResource struct {
 	client *clients.Client
+
+	// marshal marshals the body of the create request, json.Marshal when
+	// nil. It is set by tests to simulate marshal errors, which valid plans
+	// cannot produce.
+	marshal func(v any) ([]byte, error)
 }
 
 // MoveForwardResourceModel describes the resource data model.
 type MovementResourceModel struct {
-	Id      types.String         `tfsdk:"id"`
-	Name    types.String         `tfsdk:"name"`
-	Persist types.Bool           `tfsdk:"persist"`
-	Steps   []MovementStepsModel `tfsdk:"steps"`
+	Id                       types.String                     `tfsdk:"id"`
+	Name                     types.String                     `tfsdk:"name"`
+	Persist                  types.Bool                       `tfsdk:"persist"`
+	Moving                   types.Bool                       `tfsdk:"moving"`
+	EstimatedDurationSeconds types.Float64                    `tfsdk:"estimated_duration_seconds"`
+	StepResults              types.List                       `tfsdk:"step_results"`
+	WaitForCompletion        types.Bool                       `tfsdk:"wait_for_completion"`
+	StreamProgress           types.Bool                       `tfsdk:"stream_progress"`
+	SupportedDirections      types.List                       `tfsdk:"supported_directions"`
+	QueueMode                types.String                     `tfsdk:"queue_mode"`
+	CoordinateMode           types.String                     `tfsdk:"coordinate_mode"`
+	At                       types.String                     `tfsdk:"at"`
+	StopOnError              types.Bool                       `tfsdk:"stop_on_error"`
+	AvoidObstacles           types.Bool                       `tfsdk:"avoid_obstacles"`
+	RespectLock              types.Bool                       `tfsdk:"respect_lock"`
+	MinBattery               types.Int64                      `tfsdk:"min_battery"`
+	Scheduled                types.Bool                       `tfsdk:"scheduled"`
+	Waypoints                map[string]MovementWaypointModel `tfsdk:"waypoints"`
+	Steps                    []MovementStepsModel             `tfsdk:"steps"`
 }
 
 type MovementStepsModel struct {
 	Angle     types.Int64   `tfsdk:"angle"`
 	Direction types.String  `tfsdk:"direction"`
 	Distance  types.Float64 `tfsdk:"distance"`
+	Speed     types.Float64 `tfsdk:"speed"`
+	Waypoint  types.String  `tfsdk:"waypoint"`
+}
+
+// movementStepAttrTypes are the attribute types of MovementStepsModel, used
+// when a movement step is represented as an object value.
+var movementStepAttrTypes = map[string]attr.Type{
+	"angle":     types.Int64Type,
+	"direction": types.StringType,
+	"distance":  types.Float64Type,
+	"speed":     types.Float64Type,
+	"waypoint":  types.StringType,
+}
+
+type MovementStepResultModel struct {
+	Index   types.Int64  `tfsdk:"index"`
+	Status  types.String `tfsdk:"status"`
+	Message types.String `tfsdk:"message"`
+}
+
+// movementStepResultAttrTypes are the attribute types of
+// MovementStepResultModel.
+var movementStepResultAttrTypes = map[string]attr.Type{
+	"index":   types.Int64Type,
+	"status":  types.StringType,
+	"message": types.StringType,
 }
 
 func (r *MovementResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,47 +127,221 @@ func (r *MovementResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed: true,
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the movement plan to execute.",
-				Required:            true,
+				MarkdownDescription: "Name of the movement plan to execute. Changing the name replaces the resource, " +
+					"as the device identifies the movement plan by its name.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.RequiresReplaceIfRenamed(),
+				},
 			},
 			"persist": schema.BoolAttribute{
-				MarkdownDescription: "Indicates if the movement plan should be persisted to the device.",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Indicates if the movement plan should be persisted to the device. " +
+					"Defaults to the provider `default_persist` value, or `true` when it is not set. " +
+					"Changes made outside of Terraform are only detected if the device reports the persistence of the movement plan.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
-		},
-		Blocks: map[string]schema.Block{
-			"steps": schema.ListNestedBlock{
-				Validators: []validator.List{
-					listvalidator.IsRequired(),
-					// At maximum, we can have 50 steps.
-					listvalidator.SizeAtMost(50),
+			"stop_on_error": schema.BoolAttribute{
+				MarkdownDescription: "Halt the movement plan when a step fails, skipping the remaining steps, rather than continuing " +
+					"with the next step. Failed steps are reported as errors when `true`, and as warnings when `false` as the plan " +
+					"continues on a best-effort basis. The outcome of each step is available in `step_results`. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"avoid_obstacles": schema.BoolAttribute{
+				MarkdownDescription: "Use the onboard obstacle avoidance of the device while executing the movement plan, " +
+					"stopping or steering around obstacles in the way. Setting it to `false` disables a safety feature, " +
+					"so the device drives into anything in its path, and is reported with a warning. Ignored by devices " +
+					"without obstacle avoidance. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"respect_lock": schema.BoolAttribute{
+				MarkdownDescription: "Check the movement lock of the device before submitting the movement plan, and fail with an error " +
+					"instead of submitting it if the device is locked. Set it to `false` to submit the plan regardless, such as when " +
+					"the lock is held by a `pathfinder_movement_lock` resource of the same configuration. Devices without a movement " +
+					"lock are never considered locked. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"min_battery": schema.Int64Attribute{
+				MarkdownDescription: "Minimum battery value, between 0 and 100, that the device must report before the movement plan " +
+					"is submitted, in the unit of the `pathfinder_battery` data source. If the battery is below it, the movement plan " +
+					"is not submitted and an error is returned, so that the device does not strand itself partway through the plan. " +
+					"The battery is not checked when omitted.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"queue_mode": schema.StringAttribute{
+				MarkdownDescription: "Behavior when the movement plan is submitted while the device is executing another movement plan. " +
+					"`replace` interrupts the running plan, `queue` executes the plan after the running plan completes, " +
+					"and `reject` fails with an error. Uses the device default when omitted.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(movementQueueModes...),
+				},
+			},
+			"coordinate_mode": schema.StringAttribute{
+				MarkdownDescription: "How the `angle` of each step is interpreted. With `relative`, the device turns by the angle " +
+					"from its current heading. With `absolute`, the device turns to face the angle as a heading, in degrees clockwise " +
+					"from the heading the device had when the plan started, which must be between 0 and 359. " +
+					"The `distance` is moved along the resulting heading in both modes. Uses the device default, `relative`, when omitted.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(movementCoordinateModes...),
 				},
-				NestedObject: schema.NestedBlockObject{
+			},
+			"at": schema.StringAttribute{
+				MarkdownDescription: "Time to execute the movement plan at, as an RFC 3339 timestamp such as `2024-01-02T15:04:05Z`. " +
+					"The plan is submitted to the device immediately, and executed by the device at this time. " +
+					"Must be in the future when the resource is created or the time is changed, which replaces the resource. " +
+					"Executes the plan immediately when omitted.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.RFC3339(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scheduled": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device is waiting for the time set by `at` to execute the movement plan, " +
+					"as reported by the device when the plan is submitted and whenever the resource is refreshed. " +
+					"Null if the device accepts the plan without reporting it.",
+				Computed: true,
+			},
+			"waypoints": schema.MapNestedAttribute{
+				MarkdownDescription: "Named positions that steps can move the device to with `waypoint`, in meters relative to " +
+					"where the device is when the movement plan starts, facing along the positive Y axis.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"angle": schema.Int64Attribute{
-							MarkdownDescription: "Angle to move the device in degrees.",
+						"x": schema.Float64Attribute{
+							MarkdownDescription: "Position of the waypoint along the X axis in meters.",
 							Required:            true,
 						},
-						"direction": schema.StringAttribute{
-							MarkdownDescription: "Direction to move the device in.",
+						"y": schema.Float64Attribute{
+							MarkdownDescription: "Position of the waypoint along the Y axis in meters.",
 							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.Any(
-									stringvalidator.OneOf("forward", "backward"),
-								),
-							},
 						},
-						"distance": schema.Float64Attribute{
-							MarkdownDescription: "Distance to move the device in meters.",
-							Required:            true,
-							Validators: []validator.Float64{
-								float64validator.Between(1.0, 100),
-							},
+					},
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the device to finish executing the movement plan after submitting or updating it, " +
+					"by polling until the device reports that it is no longer moving or waiting for the time set by `at`. Defaults to `false`.",
+				Optional: true,
+			},
+			"stream_progress": schema.BoolAttribute{
+				MarkdownDescription: "Follow the progress of the movement plan over the event stream of the device while waiting for completion, " +
+					"instead of polling, and log each progress event. Falls back to polling if the device does not support the event stream, " +
+					"or the stream closes before the plan completes. Only used when `wait_for_completion` is `true`. Defaults to `false`.",
+				Optional: true,
+			},
+			"supported_directions": schema.ListAttribute{
+				MarkdownDescription: "Directions supported by the device, such as the `directions` of the `pathfinder_movement_capabilities` " +
+					"data source. Steps moving in other directions fail validation, or planning when the value is only known once " +
+					"the data source is read. Only narrows the directions accepted by `steps`, as the provider cannot plan other directions. " +
+					"Defaults to `forward`, `backward`, `left`, and `right`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"moving": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device is executing the movement plan, as reported by the device when the plan " +
+					"is submitted and whenever the resource is refreshed. Null if the device accepts the plan without reporting it.",
+				Computed: true,
+			},
+			"step_results": schema.ListNestedAttribute{
+				MarkdownDescription: "Results of the steps of the movement plan, as reported by the device when the plan " +
+					"is submitted and whenever the resource is refreshed. Null if the device does not report them.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.Int64Attribute{
+							MarkdownDescription: "Index of the step in `steps`, starting at 0.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the step, such as `pending`, `completed`, `failed`, or `skipped` when an earlier step failed and `stop_on_error` is `true`.",
+							Computed:            true,
 						},
+						"message": schema.StringAttribute{
+							MarkdownDescription: "Reason for the status, such as why the step failed. Null if the device does not report one.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"estimated_duration_seconds": schema.Float64Attribute{
+				MarkdownDescription: "Estimated time in seconds for the device to execute the movement plan. " +
+					"Assumes that the device moves at a constant speed, or 0.5 meters per second for steps without a `speed`, " +
+					"and that rotating is instant, so the actual duration is usually longer. " +
+					"With the provider's `safe_mode` enabled, the estimate uses the clamped steps sent to the device.",
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"steps": movementStepsBlock(),
+		},
+	}
+}
+
+// movementStepsBlock returns the steps block of the resources submitting
+// movement plans.
+func movementStepsBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Validators: []validator.List{
+			listvalidator.IsRequired(),
+			// At maximum, we can have 50 steps.
+			listvalidator.SizeAtMost(maxMovementSteps),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"angle": schema.Int64Attribute{
+					MarkdownDescription: "Angle to move the device in degrees, between 0 and 360. Required unless `waypoint` is set.",
+					Optional:            true,
+					Validators: []validator.Int64{
+						validators.MovementAngle(),
+					},
+				},
+				"direction": schema.StringAttribute{
+					MarkdownDescription: "Direction to move the device in. `forward` and `backward` move the device in a line, " +
+						"`left` and `right` rotate the device in place. Required unless `waypoint` is set.",
+					Optional: true,
+					Validators: []validator.String{
+						validators.MovementDirection(),
+					},
+				},
+				"distance": schema.Float64Attribute{
+					MarkdownDescription: "Distance to move the device in meters. Required for `forward` and `backward` steps, " +
+						"must not be set for `left` and `right` steps.",
+					Optional: true,
+					Validators: []validator.Float64{
+						float64validator.Between(validators.MinMovementStepDistance, validators.MaxMovementStepDistance),
 					},
 				},
+				"speed": schema.Float64Attribute{
+					MarkdownDescription: "Speed to move the device at in meters per second. Uses the device default when omitted.",
+					Optional:            true,
+					Validators: []validator.Float64{
+						float64validator.Between(validators.MinMovementStepSpeed, validators.MaxMovementStepSpeed),
+					},
+				},
+				"waypoint": schema.StringAttribute{
+					MarkdownDescription: "Name of a waypoint in `waypoints` to move the device to, instead of setting `angle`, `direction`, " +
+						"and `distance`. The device turns to face the waypoint and moves forward to it, as predicted from the previous steps.",
+					Optional: true,
+				},
+			},
+			Validators: []validator.Object{
+				validators.MovementStepDistance(),
+				validators.MovementStepWaypoint(),
 			},
 		},
 	}
@@ -127,7 +363,132 @@ func (r *MovementResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ValidateConfig checks the directions of the steps against
+// supported_directions, the angles of the steps against coordinate_mode, and
+// the waypoints referenced by the steps against waypoints, when they are
+// known. It also warns when obstacle avoidance is disabled.
+func (r *MovementResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateSupportedDirections(ctx, req.Config.GetAttribute)...)
+	resp.Diagnostics.Append(validateCoordinateMode(ctx, req.Config.GetAttribute)...)
+	resp.Diagnostics.Append(validateMovementWaypoints(ctx, req.Config.GetAttribute)...)
+
+	var avoidObstacles types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avoid_obstacles"), &avoidObstacles)...)
+
+	if !avoidObstacles.IsNull() && !avoidObstacles.IsUnknown() && !avoidObstacles.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("avoid_obstacles"),
+			"Obstacle Avoidance Disabled",
+			"The device will not stop or steer around obstacles while executing this movement plan, "+
+				"and may collide with people or objects in its path. Only disable obstacle avoidance "+
+				"when the path is known to be clear.",
+		)
+	}
+}
+
+// ModifyPlan computes the estimated duration of the movement plan, so that it
+// is known during planning, which resolves the waypoints of the steps. It also replaces the static default of persist
+// with the provider default_persist value when persist is not set in the
+// configuration, which cannot be a schema plan modifier as those do not have
+// access to the configured provider.
+//
+// The directions of the steps are checked against supported_directions again,
+// as a reference to the movement_capabilities data source is unknown while
+// the configuration is validated, and only known once it is read. The same
+// applies to the angles of the steps and coordinate_mode.
+//
+// The time set by at is checked to be in the future whenever it is planned
+// to be submitted, which cannot be done while validating the configuration
+// as that also happens for resources that already exist.
+func (r *MovementResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSupportedDirections(ctx, req.Plan.GetAttribute)...)
+	resp.Diagnostics.Append(validateCoordinateMode(ctx, req.Plan.GetAttribute)...)
+	resp.Diagnostics.Append(validateScheduledTime(ctx, req.Plan, req.State)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stepsList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("steps"), &stepsList)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !stepsList.IsUnknown() {
+		var steps []MovementStepsModel
+		resp.Diagnostics.Append(stepsList.ElementsAs(ctx, &steps, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Capabilities are only checked when they are cached, so that
+		// planning does not read them for every resource.
+		if r.client != nil && r.client.Config.CacheCapabilities {
+			capabilities, err := r.client.MovementCapabilities(ctx)
+			if err != nil {
+				tflog.Debug(ctx, "Skipping movement capabilities validation", map[string]interface{}{"error": err.Error()})
+			} else {
+				resp.Diagnostics.Append(validateMovementCapabilities(steps, capabilities)...)
+			}
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		waypoints, known, diags := plannedMovementWaypoints(ctx, req.Plan)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var coordinateMode types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("coordinate_mode"), &coordinateMode)...)
+
+		// The steps resolve differently in each coordinate mode, so nothing
+		// derived from them is known until the mode is.
+		if coordinateMode.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_duration_seconds"), types.Float64Unknown())...)
+		} else if movementStepsKnown(steps) && known {
+			steps, err := resolveMovementWaypoints(steps, waypoints, coordinateMode.ValueString() == movementCoordinateModeAbsolute)
+			if err != nil {
+				addWaypointError(&resp.Diagnostics, err)
+				return
+			}
+
+			// The total distance is checked once waypoints are resolved, as
+			// the distance to a waypoint is only known then.
+			if detail := movementPlanDistanceError(steps); detail != "" {
+				resp.Diagnostics.AddAttributeError(path.Root("steps"), "Movement Plan Too Long", detail)
+				return
+			}
+
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_duration_seconds"), r.estimateDuration(steps))...)
+		}
+	}
+
+	if r.client == nil || r.client.Config.DefaultPersist == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(planmodifiers.ModifyBool(ctx, planmodifiers.BoolDefault(*r.client.Config.DefaultPersist), path.Root("persist"), req, resp)...)
+}
+
 func (r *MovementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "create")
+		return
+	}
+
 	var data MovementResourceModel
 
 	// Read Terraform plan data into the model
@@ -137,23 +498,26 @@ func (r *MovementResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Convert from Terraform data model into API data model
-	createReq := model.MovementRequest{
-		Name:    data.Name.ValueString(),
-		Persist: data.Persist.ValueBool(),
-		Steps:   make([]model.MovementStepItem, len(data.Steps)),
+	steps, err := resolvedMovementSteps(data)
+	if err != nil {
+		addWaypointError(&resp.Diagnostics, err)
+		return
+	}
+
+	// Convert from Terraform data model into API data model, with the steps
+	// moving to waypoints resolved
+	createReq := expandMovementRequest(data)
+	createReq.Steps = expandMovementSteps(steps)
+	if r.client.Config.SafeMode {
+		clampSafeModeSteps(createReq.Name, createReq.Steps, &resp.Diagnostics)
 	}
 
-	// Convert steps from MovementResourceModel to MovementRequest
-	for i, step := range data.Steps {
-		createReq.Steps[i] = model.MovementStepItem{
-			Angle:     step.Angle.ValueInt64(),
-			Direction: step.Direction.ValueString(),
-			Distance:  step.Distance.ValueFloat64(),
-		}
+	marshal := r.marshal
+	if marshal == nil {
+		marshal = json.Marshal
 	}
 
-	httpReqBody, err := json.Marshal(createReq)
+	httpReqBody, err := marshal(createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Resource",
@@ -161,38 +525,53 @@ func (r *MovementResource) Create(ctx context.Context, req resource.CreateReques
 				"Please report this issue to the provider developers.\n\n"+
 				"JSON Error: "+err.Error(),
 		)
+
+		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/v1/movement-plan", r.client.Config.Address),
-		bytes.NewBuffer(httpReqBody),
-	)
+	if data.RespectLock.ValueBool() {
+		r.checkMovementLock(ctx, &resp.Diagnostics)
 
-	// Example of setting a custom header, such as an API key
-	// httpReq.Header.Set("x-api-key", d.client.Config.ApiKey)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s with body: %s", httpReq.Method, httpReq.URL.String(), httpReqBody))
+	if !data.MinBattery.IsNull() {
+		r.checkMinBattery(ctx, data.MinBattery.ValueInt64(), &resp.Diagnostics)
 
-	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
-		return
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	httpResp, err := r.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
+	// The device may accept the movement plan before it starts moving, so
+	// any of the default POST status codes indicate success.
+	var createResp model.MovementResponse
+	err = r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPost,
+		Path:   r.client.MovementPath(),
+		Body:   json.RawMessage(httpReqBody),
+	}, &createResp)
+
+	// Devices that accept the plan without a response body do not report
+	// whether they started moving.
+	data.Moving = types.BoolValue(createResp.Moving)
+	data.Scheduled = types.BoolValue(createResp.Scheduled)
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		data.Moving = types.BoolNull()
+		data.Scheduled = types.BoolNull()
+		err = nil
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+	if addMovementBusyError(&resp.Diagnostics, data, err) || addMovementValidationErrors(&resp.Diagnostics, err) {
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while attempting to refresh resource state. "+
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource. "+
 				"Please retry the operation or report this issue to the provider developers.\n\n"+
 				"HTTP Error: "+err.Error(),
 		)
@@ -200,31 +579,33 @@ func (r *MovementResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	// var readResp model.MovementResponse
-	// err = json.NewDecoder(httpResp.Body).Decode(&readResp)
-
-	// if err != nil {
-	// 	resp.Diagnostics.AddError(
-	// 		"Unable to Refresh Resource",
-	// 		"An unexpected error occurred while parsing the resource read response. "+
-	// 			"Please report this issue to the provider developers.\n\n"+
-	// 			"JSON Error: "+err.Error(),
-	// 	)
+	var diags diag.Diagnostics
+	data.StepResults, diags = flattenMovementStepResults(ctx, createResp.Steps)
+	resp.Diagnostics.Append(diags...)
 
-	// 	return
-	// }
+	estimate := r.estimateDuration(steps)
+	waitTimeout := movementWaitTimeoutFor(estimate)
+
+	// The plan has been submitted, so a failure to wait is reported after
+	// saving state, rather than leaving the resource untracked.
+	var waitErr error
+	if data.WaitForCompletion.ValueBool() && (!data.Moving.Equal(types.BoolValue(false)) || data.Scheduled.ValueBool()) {
+		waitErr = r.waitForCompletion(ctx, data.StreamProgress.ValueBool(), waitTimeout)
+		if waitErr == nil {
+			data.Moving = types.BoolValue(false)
+			data.Scheduled = types.BoolValue(false)
+		}
+	}
 
-	// Save data into Terraform state
+	// Save data into Terraform state, even when a step failed, so that the
+	// results record which steps completed.
 
 	data.Id = types.StringValue(data.Name.ValueString())
+	data.EstimatedDurationSeconds = types.Float64Value(estimate)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+
+	addMovementWaitError(&resp.Diagnostics, waitTimeout, waitErr)
+	addMovementStepDiagnostics(&resp.Diagnostics, data.StopOnError.ValueBool(), createResp.Steps)
 }
 
 func (r *MovementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -237,82 +618,214 @@ func (r *MovementResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	// Create an empty HTTP GET request
-	// httpReq, err := http.NewRequestWithContext(
-	// 	ctx,
-	// 	http.MethodGet,
-	// 	fmt.Sprintf("%s/v1/movement-plan", r.client.Config.Address),
-	// 	io.NopCloser(strings.NewReader("")),
-	// )
-
-	// ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	// ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	// tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
-
-	// if err != nil {
-	// 	// handle error
-	// 	fmt.Println("Error creating request:", err)
-	// 	return
-	// }
-
-	// httpResp, err := r.client.HttpClient.Do(httpReq)
-	// defer httpReq.Body.Close()
-
-	// tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
-
-	// if err != nil {
-	// 	resp.Diagnostics.AddError(
-	// 		"Unable to Create Resource",
-	// 		"An unexpected error occurred while attempting to refresh resource state. "+
-	// 			"Please retry the operation or report this issue to the provider developers.\n\n"+
-	// 			"HTTP Error: "+err.Error(),
-	// 	)
-
-	// 	return
-	// }
+	var readResp model.MovementResponse
+	err := r.client.GetJSON(ctx, r.client.MovementPath(), &readResp)
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	// if httpResp.StatusCode == http.StatusNotFound {
-	// 	resp.State.RemoveResource(ctx)
-	// 	return
-	// }
+	// Treat HTTP 404 Not Found status, or an empty response body, as a
+	// signal to recreate resource and return early
+	if clients.IsNotFound(err) || errors.Is(err, clients.ErrEmptyResponse) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	// var readResp model.MovementResponse
-	// err = json.NewDecoder(httpResp.Body).Decode(&readResp)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
 
-	// if err != nil {
-	// 	resp.Diagnostics.AddError(
-	// 		"Unable to Create Resource",
-	// 		"An unexpected error occurred while parsing the resource read response. "+
-	// 			"Please report this issue to the provider developers.\n\n"+
-	// 			"JSON Error: "+err.Error(),
-	// 	)
+		return
+	}
 
-	// 	return
-	// }
+	// Only devices that report persistence allow drift to be detected,
+	// otherwise the value from state is kept.
+	if readResp.Persist != nil {
+		data.Persist = types.BoolPointerValue(readResp.Persist)
+	}
 
+	data.Moving = types.BoolValue(readResp.Moving)
+	data.Scheduled = types.BoolValue(readResp.Scheduled)
+	data.StepResults, diags = flattenMovementStepResults(ctx, readResp.Steps)
+	resp.Diagnostics.Append(diags...)
+	// Waypoints that cannot be resolved keep the estimate from state, the
+	// error is reported when the plan is next submitted.
+	if steps, err := resolvedMovementSteps(data); err == nil {
+		data.EstimatedDurationSeconds = types.Float64Value(r.estimateDuration(steps))
+	}
 	data.Id = types.StringValue(data.Name.ValueString())
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *MovementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data MovementResourceModel
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "update")
+		return
+	}
+
+	var data, state MovementResourceModel
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Only the fields of the movement plan that changed are sent to the
+	// device, so that an edit does not interrupt the rest of the plan.
+	steps, err := resolvedMovementSteps(data)
+	if err != nil {
+		addWaypointError(&resp.Diagnostics, err)
+		return
+	}
+
+	updateReq := expandMovementRequest(data)
+	updateReq.Steps = expandMovementSteps(steps)
+
+	// The steps in state were resolved when they were submitted, so they
+	// resolve again unless the plan was changed outside of Terraform.
+	stateReq := expandMovementRequest(state)
+	if stateSteps, err := resolvedMovementSteps(state); err == nil {
+		stateReq.Steps = expandMovementSteps(stateSteps)
+	}
+
+	// The steps in state are clamped only to compare them, so their
+	// warnings are discarded.
+	if r.client.Config.SafeMode {
+		clampSafeModeSteps(updateReq.Name, updateReq.Steps, &resp.Diagnostics)
+		clampSafeModeSteps(stateReq.Name, stateReq.Steps, &diag.Diagnostics{})
+	}
+
+	patch, err := movementRequestPatch(stateReq, updateReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			"An unexpected error occurred while marshalling the resource update request. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"JSON Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Without changes to the movement plan, such as when only
+	// wait_for_completion changed, the device is still in the state it was
+	// last seen in.
+	if len(patch) == 0 {
+		data.Moving = state.Moving
+		data.Scheduled = state.Scheduled
+		data.StepResults = state.StepResults
+		data.EstimatedDurationSeconds = types.Float64Value(r.estimateDuration(steps))
+
+		data.Id = types.StringValue(data.Name.ValueString())
+		diags = resp.State.Set(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	if data.RespectLock.ValueBool() {
+		r.checkMovementLock(ctx, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.MinBattery.IsNull() {
+		r.checkMinBattery(ctx, data.MinBattery.ValueInt64(), &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var updateResp model.MovementResponse
+	err = r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodPatch,
+		Path:   r.client.MovementPath() + "/" + url.PathEscape(state.Id.ValueString()),
+		Body:   patch,
+	}, &updateResp)
+
+	// Devices that do not support partial updates are sent the full plan,
+	// which replaces the plan on the device.
+	if isPatchUnsupported(err) {
+		tflog.Debug(ctx, "Device does not support partial updates of movement plans, submitting the full plan", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		updateResp = model.MovementResponse{}
+		err = r.client.SendJSON(ctx, clients.Request{
+			Method: http.MethodPost,
+			Path:   r.client.MovementPath(),
+			Body:   updateReq,
+		}, &updateResp)
+	}
+
+	data.Moving = types.BoolValue(updateResp.Moving)
+	data.Scheduled = types.BoolValue(updateResp.Scheduled)
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		data.Moving = types.BoolNull()
+		data.Scheduled = types.BoolNull()
+		err = nil
+	}
+
+	// err is the error of the PATCH request, or of the full plan sent when
+	// the device does not support partial updates.
+	if addMovementBusyError(&resp.Diagnostics, data, err) || addMovementValidationErrors(&resp.Diagnostics, err) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			"An unexpected error occurred while attempting to update the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.StepResults, diags = flattenMovementStepResults(ctx, updateResp.Steps)
+	resp.Diagnostics.Append(diags...)
+
+	estimate := r.estimateDuration(steps)
+	waitTimeout := movementWaitTimeoutFor(estimate)
+
+	// The updated plan has been sent, so a failure to wait is reported after
+	// saving state, as in Create.
+	var waitErr error
+	if data.WaitForCompletion.ValueBool() && (!data.Moving.Equal(types.BoolValue(false)) || data.Scheduled.ValueBool()) {
+		waitErr = r.waitForCompletion(ctx, data.StreamProgress.ValueBool(), waitTimeout)
+		if waitErr == nil {
+			data.Moving = types.BoolValue(false)
+			data.Scheduled = types.BoolValue(false)
+		}
+	}
+
+	data.EstimatedDurationSeconds = types.Float64Value(estimate)
+
 	data.Id = types.StringValue(data.Name.ValueString())
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+
+	addMovementWaitError(&resp.Diagnostics, waitTimeout, waitErr)
+	addMovementStepDiagnostics(&resp.Diagnostics, data.StopOnError.ValueBool(), updateResp.Steps)
 }
 
 func (r *MovementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client.Config.ReadOnly {
+		addReadOnlyError(&resp.Diagnostics, "delete")
+		return
+	}
+
 	var data MovementResourceModel
 
 	diags := req.State.Get(ctx, &data)
@@ -322,58 +835,691 @@ func (r *MovementResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// Set up an empty HTTP DELETE request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodDelete,
-		fmt.Sprintf("%s/v1/movement-plan", r.client.Config.Address),
-		io.NopCloser(strings.NewReader("")),
-	)
+	// Transient errors are retried, if the final attempt fails the resource
+	// is kept in state so that the deletion can be retried.
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodDelete,
+		Path:   r.client.MovementPath(),
+		Retry:  true,
+	}, nil)
 
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
+	// Treat HTTP 404 Not Found status as the resource already being deleted
+	// and return early
+	if clients.IsNotFound(err) {
+		return
+	}
 
 	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			"An unexpected error occurred while attempting to delete the resource. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
 		return
 	}
+}
+
+func expandMovementRequest(in MovementResourceModel) model.MovementRequest {
+	out := model.MovementRequest{
+		At:             in.At.ValueString(),
+		Name:           in.Name.ValueString(),
+		Persist:        knownBoolPointer(in.Persist),
+		QueueMode:      in.QueueMode.ValueString(),
+		StopOnError:    knownBoolPointer(in.StopOnError),
+		AvoidObstacles: knownBoolPointer(in.AvoidObstacles),
+		CoordinateMode: in.CoordinateMode.ValueString(),
+		Steps:          expandMovementSteps(in.Steps),
+	}
+
+	return out
+}
+
+// expandMovementSteps converts steps from MovementStepsModel to
+// MovementStepItem. Steps moving to waypoints must be resolved first.
+func expandMovementSteps(in []MovementStepsModel) []model.MovementStepItem {
+	out := make([]model.MovementStepItem, len(in))
+	for i, step := range in {
+		out[i] = model.MovementStepItem{
+			Angle:     step.Angle.ValueInt64(),
+			Direction: step.Direction.ValueString(),
+			Speed:     step.Speed.ValueFloat64(),
+		}
+
+		// Rotation steps turn in place, so the distance is omitted.
+		if validators.IsLinearDirection(step.Direction.ValueString()) {
+			out[i].Distance = step.Distance.ValueFloat64()
+		}
+	}
+
+	return out
+}
+
+// resolvedMovementSteps returns the steps of the movement plan, with the
+// steps moving to waypoints resolved for its coordinate mode.
+func resolvedMovementSteps(in MovementResourceModel) ([]MovementStepsModel, error) {
+	return resolveMovementWaypoints(in.Steps, in.Waypoints, in.CoordinateMode.ValueString() == movementCoordinateModeAbsolute)
+}
+
+// plannedMovementWaypoints returns the waypoints of the plan, and whether
+// they are known, including the coordinates of each waypoint.
+func plannedMovementWaypoints(ctx context.Context, plan tfsdk.Plan) (map[string]MovementWaypointModel, bool, diag.Diagnostics) {
+	var waypoints types.Map
+
+	diags := plan.GetAttribute(ctx, path.Root("waypoints"), &waypoints)
+	if diags.HasError() || waypoints.IsUnknown() {
+		return nil, false, diags
+	}
+
+	var out map[string]MovementWaypointModel
+	diags.Append(waypoints.ElementsAs(ctx, &out, false)...)
+
+	for _, waypoint := range out {
+		if waypoint.X.IsUnknown() || waypoint.Y.IsUnknown() {
+			return nil, false, diags
+		}
+	}
+
+	return out, !diags.HasError(), diags
+}
+
+// validateMovementWaypoints returns an error for each step referencing a
+// waypoint that is not defined in waypoints, reading both with get like
+// validateSupportedDirections. Nothing is checked while either is unknown.
+func validateMovementWaypoints(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) diag.Diagnostics {
+	var waypoints types.Map
+	var steps types.List
+
+	diags := get(ctx, path.Root("waypoints"), &waypoints)
+	diags.Append(get(ctx, path.Root("steps"), &steps)...)
+
+	if diags.HasError() || waypoints.IsUnknown() || steps.IsNull() || steps.IsUnknown() {
+		return diags
+	}
+
+	for i, element := range steps.Elements() {
+		step, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		waypoint, ok := step.Attributes()["waypoint"].(types.String)
+		if !ok || waypoint.IsNull() || waypoint.IsUnknown() {
+			continue
+		}
+
+		if _, ok := waypoints.Elements()[waypoint.ValueString()]; !ok {
+			diags.AddAttributeError(
+				path.Root("steps").AtListIndex(i).AtName("waypoint"),
+				"Undefined Waypoint",
+				fmt.Sprintf("The waypoint %q is not defined in waypoints.", waypoint.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// movementQueueModeReject is the queue mode that rejects a movement plan
+// submitted while the device is executing another movement plan.
+const movementQueueModeReject = "reject"
+
+// movementQueueModes are the values of queue_mode accepted by the device.
+var movementQueueModes = []string{"replace", "queue", movementQueueModeReject}
+
+// movementCoordinateModeAbsolute is the coordinate mode in which the angle
+// of a step is the heading to face, rather than the angle to turn by.
+const movementCoordinateModeAbsolute = "absolute"
+
+// movementCoordinateModes are the values of coordinate_mode accepted by the
+// device.
+var movementCoordinateModes = []string{"relative", movementCoordinateModeAbsolute}
+
+// maxMovementHeading is the largest angle of a step in the absolute
+// coordinate mode, as headings wrap around at 360 degrees.
+const maxMovementHeading = 359
+
+// movementStepStatusFailed is the status of a movement step that the device
+// failed to execute.
+const movementStepStatusFailed = "failed"
+
+// flattenMovementStepResults converts the step results reported by the device
+// into a list value, which is null when the device does not report them.
+func flattenMovementStepResults(ctx context.Context, in []model.MovementStepResult) (types.List, diag.Diagnostics) {
+	elementType := types.ObjectType{AttrTypes: movementStepResultAttrTypes}
+
+	if in == nil {
+		return types.ListNull(elementType), nil
+	}
+
+	results := make([]MovementStepResultModel, len(in))
+	for i, step := range in {
+		results[i] = MovementStepResultModel{
+			Index:   types.Int64Value(step.Index),
+			Status:  types.StringValue(step.Status),
+			Message: types.StringNull(),
+		}
+
+		if step.Message != "" {
+			results[i].Message = types.StringValue(step.Message)
+		}
+	}
+
+	return types.ListValueFrom(ctx, elementType, results)
+}
+
+// addMovementStepDiagnostics adds a diagnostic for each step that the device
+// failed to execute. Plans that continue after a failed step are best-effort,
+// so failed steps are only warnings unless stopOnError is true.
+func addMovementStepDiagnostics(diags *diag.Diagnostics, stopOnError bool, steps []model.MovementStepResult) {
+	addStepDiagnostic := diags.AddAttributeWarning
+	if stopOnError {
+		addStepDiagnostic = diags.AddAttributeError
+	}
+
+	for _, step := range steps {
+		if step.Status != movementStepStatusFailed {
+			continue
+		}
+
+		addStepDiagnostic(
+			path.Root("steps").AtListIndex(int(step.Index)),
+			"Movement Step Failed",
+			fmt.Sprintf("The device failed to execute the step at index %d of the movement plan: %s", step.Index, step.Message),
+		)
+	}
+}
+
+// movementRequestAttributes are the attributes of the resource that are sent
+// as top-level fields of the movement request, under the same name.
+var movementRequestAttributes = []string{"at", "avoid_obstacles", "coordinate_mode", "name", "persist", "queue_mode", "stop_on_error"}
+
+// addMovementValidationErrors adds an error for each field the device
+// rejected in a 422 Unprocessable Entity response, on the attribute of the
+// field when it has one. It returns false, adding nothing, if err is not
+// such a response.
+func addMovementValidationErrors(diags *diag.Diagnostics, err error) bool {
+	var statusErr *clients.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnprocessableEntity || len(statusErr.Fields) == 0 {
+		return false
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(statusErr.Fields)) {
+		if attrPath, ok := movementFieldPath(field); ok {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid Movement Plan",
+				fmt.Sprintf("The device rejected %s of the movement plan: %s", field, statusErr.Fields[field]),
+			)
+
+			continue
+		}
+
+		diags.AddError(
+			"Invalid Movement Plan",
+			fmt.Sprintf("The device rejected %s of the movement plan: %s", field, statusErr.Fields[field]),
+		)
+	}
+
+	return true
+}
+
+// movementFieldPath returns the path of the attribute that a field of the
+// movement request is set from, such as steps[1].distance or
+// steps.1.distance, or false if the field has no attribute. Step indexes are
+// those of the steps blocks, like addMovementStepDiagnostics.
+func movementFieldPath(field string) (path.Path, bool) {
+	parts := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(field), ".")
+
+	if len(parts) == 1 && slices.Contains(movementRequestAttributes, parts[0]) {
+		return path.Root(parts[0]), true
+	}
+
+	if parts[0] != "steps" || len(parts) < 2 || len(parts) > 3 {
+		return path.Empty(), false
+	}
+
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return path.Empty(), false
+	}
 
-	httpResp, err := r.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
+	stepPath := path.Root("steps").AtListIndex(index)
+	if len(parts) == 2 {
+		return stepPath, true
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+	if _, ok := movementStepAttrTypes[parts[2]]; !ok {
+		return path.Empty(), false
+	}
 
+	return stepPath.AtName(parts[2]), true
+}
+
+// movementRequestPatch returns a JSON merge patch (RFC 7396) containing the
+// fields of the movement request that differ between from and to. Fields that
+// are omitted from to are set to null, so that the device default applies
+// again. The patch is empty when no field changed.
+func movementRequestPatch(from, to model.MovementRequest) (map[string]json.RawMessage, error) {
+	fromFields, err := movementRequestFields(from)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Delete Resource",
-			"An unexpected error occurred while attempting to refresh resource state. "+
-				"Please retry the operation or report this issue to the provider developers.\n\n"+
+		return nil, err
+	}
+
+	toFields, err := movementRequestFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := map[string]json.RawMessage{}
+	for name, value := range toFields {
+		if !bytes.Equal(fromFields[name], value) {
+			patch[name] = value
+		}
+	}
+
+	for name := range fromFields {
+		if _, ok := toFields[name]; !ok {
+			patch[name] = json.RawMessage("null")
+		}
+	}
+
+	return patch, nil
+}
+
+// movementRequestFields returns the JSON encoding of each field of the
+// movement request, keyed by field name.
+func movementRequestFields(in model.MovementRequest) (map[string]json.RawMessage, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// checkMovementLock adds an error to diags if the device has a movement lock,
+// so that the movement plan is not submitted only to be rejected. Devices
+// without a movement lock endpoint are never considered locked.
+func (r *MovementResource) checkMovementLock(ctx context.Context, diags *diag.Diagnostics) {
+	var lockResp model.MovementLockResponse
+	err := r.client.GetJSON(ctx, movementLockPath, &lockResp)
+
+	if clients.IsNotFound(err) || errors.Is(err, clients.ErrEmptyResponse) {
+		return
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Check Movement Lock",
+			"An unexpected error occurred while attempting to check the movement lock before submitting the movement plan. "+
+				"Please retry the operation, set respect_lock to false to skip the check, or report this issue to the provider developers.\n\n"+
 				"HTTP Error: "+err.Error(),
 		)
 
 		return
 	}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	if !lockResp.Locked {
 		return
 	}
 
-	var readResp model.MovementResponse
-	err = json.NewDecoder(httpResp.Body).Decode(&readResp)
+	detail := "The device has a movement lock, so the movement plan was not submitted. " +
+		"Release the lock, or set respect_lock to false to submit the movement plan regardless."
+	if !lockResp.ExpiresAt.IsZero() {
+		detail += fmt.Sprintf(" The lock expires at %s.", lockResp.ExpiresAt.Format(time.RFC3339))
+	}
+
+	diags.AddAttributeError(path.Root("respect_lock"), "Device Movement Locked", detail)
+}
+
+// checkMinBattery adds an error to diags if the battery of the device is
+// below minBattery, so that the device does not strand itself partway through the
+// movement plan. The battery is read with retries, as it is idempotent.
+func (r *MovementResource) checkMinBattery(ctx context.Context, minBattery int64, diags *diag.Diagnostics) {
+	var batteryResp model.BatteryResponse
+	err := r.client.SendJSON(ctx, clients.Request{
+		Method: http.MethodGet,
+		Path:   "/v1/device/battery",
+		Retry:  true,
+	}, &batteryResp)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Delete Resource",
-			"An unexpected error occurred while parsing the resource read response. "+
-				"Please report this issue to the provider developers.\n\n"+
-				"JSON Error: "+err.Error(),
+		diags.AddError(
+			"Unable to Check Battery",
+			"An unexpected error occurred while attempting to check the battery before submitting the movement plan. "+
+				"Please retry the operation, remove min_battery to skip the check, or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
 		)
 
 		return
 	}
+
+	if int64(batteryResp.Value) >= minBattery {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("min_battery"),
+		"Insufficient Battery",
+		fmt.Sprintf("The battery of the device is %d%s, below the min_battery of %d, so the movement plan was not submitted. "+
+			"Charge the device, or lower min_battery to submit the movement plan regardless.", batteryResp.Value, batteryResp.Unit, minBattery),
+	)
+}
+
+// addMovementWaitError adds an error when waiting for the submitted movement
+// plan to complete within timeout failed, if err is not nil.
+func addMovementWaitError(diags *diag.Diagnostics, timeout time.Duration, err error) {
+	if err == nil {
+		return
+	}
+
+	diags.AddError(
+		"Unable to Wait for Movement Plan",
+		fmt.Sprintf("The movement plan was submitted, but the device did not report that it completed within %s.\n\n", timeout)+
+			"Error: "+err.Error(),
+	)
+}
+
+// addMovementBusyError adds an error on queue_mode when the device rejected
+// the movement plan with a 409 Conflict status, as it is busy and the queue
+// mode is reject. It reports whether the error was added.
+func addMovementBusyError(diags *diag.Diagnostics, data MovementResourceModel, err error) bool {
+	var statusErr *clients.StatusError
+	if data.QueueMode.ValueString() != movementQueueModeReject || !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusConflict {
+		return false
+	}
+
+	diags.AddAttributeError(
+		path.Root("queue_mode"),
+		"Device Busy",
+		"The device rejected the movement plan, as it is executing another movement plan and queue_mode is \"reject\". "+
+			"Retry once the running plan completes, or set queue_mode to \"queue\" or \"replace\".\n\n"+
+			"HTTP Error: "+err.Error(),
+	)
+
+	return true
+}
+
+// isPatchUnsupported returns true if the error indicates that the device does
+// not support PATCH requests for movement plans, as older devices respond to
+// unknown routes and methods with these status codes.
+func isPatchUnsupported(err error) bool {
+	var statusErr *clients.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+// knownBoolPointer returns a pointer to the value, or nil when the value is
+// null or unknown so that it is omitted from the request.
+func knownBoolPointer(in types.Bool) *bool {
+	if in.IsNull() || in.IsUnknown() {
+		return nil
+	}
+
+	return in.ValueBoolPointer()
+}
+
+// validateSupportedDirections returns an error for each step moving in a
+// direction that is not in supported_directions, reading both with get, such
+// as the GetAttribute method of the configuration or plan. Nothing is checked
+// while either is unknown, or when supported_directions is null, as the
+// directions of the schema apply then.
+func validateSupportedDirections(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) diag.Diagnostics {
+	var supported, steps types.List
+
+	diags := get(ctx, path.Root("supported_directions"), &supported)
+	diags.Append(get(ctx, path.Root("steps"), &steps)...)
+
+	if diags.HasError() || supported.IsNull() || supported.IsUnknown() || steps.IsNull() || steps.IsUnknown() {
+		return diags
+	}
+
+	var directions []types.String
+	diags.Append(supported.ElementsAs(ctx, &directions, false)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, direction := range directions {
+		if direction.IsUnknown() {
+			return diags
+		}
+	}
+
+	for i, element := range steps.Elements() {
+		step, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		direction, ok := step.Attributes()["direction"].(types.String)
+		if !ok || direction.IsNull() || direction.IsUnknown() || slices.Contains(directions, direction) {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root("steps").AtListIndex(i).AtName("direction"),
+			"Unsupported Movement Direction",
+			fmt.Sprintf("The direction %q is not one of the directions supported by the device: %s.", direction.ValueString(), supported),
+		)
+	}
+
+	return diags
+}
+
+// validateMovementCapabilities returns an error for each step with a
+// direction, distance, or angle outside of the capabilities of the device.
+// Limits the device does not report, such as a zero maximum, are not checked.
+func validateMovementCapabilities(steps []MovementStepsModel, capabilities model.MovementCapabilitiesResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, step := range steps {
+		stepPath := path.Root("steps").AtListIndex(i)
+
+		if !step.Waypoint.IsNull() || step.Direction.IsNull() || step.Direction.IsUnknown() {
+			continue
+		}
+
+		direction := step.Direction.ValueString()
+
+		if len(capabilities.Directions) > 0 && !slices.Contains(capabilities.Directions, direction) {
+			diags.AddAttributeError(
+				stepPath.AtName("direction"),
+				"Unsupported Movement Direction",
+				fmt.Sprintf("The direction %q is not one of the directions supported by the device: %s.",
+					direction, strings.Join(capabilities.Directions, ", ")),
+			)
+
+			continue
+		}
+
+		if validators.IsLinearDirection(direction) {
+			distance := step.Distance
+			if capabilities.MaxDistance > 0 && !distance.IsNull() && !distance.IsUnknown() &&
+				(distance.ValueFloat64() < capabilities.MinDistance || distance.ValueFloat64() > capabilities.MaxDistance) {
+				diags.AddAttributeError(
+					stepPath.AtName("distance"),
+					"Unsupported Movement Distance",
+					fmt.Sprintf("The distance %g is not between the minimum distance %g and maximum distance %g supported by the device.",
+						distance.ValueFloat64(), capabilities.MinDistance, capabilities.MaxDistance),
+				)
+			}
+
+			continue
+		}
+
+		angle := step.Angle
+		if capabilities.MaxAngle > 0 && !angle.IsNull() && !angle.IsUnknown() &&
+			(angle.ValueInt64() < capabilities.MinAngle || angle.ValueInt64() > capabilities.MaxAngle) {
+			diags.AddAttributeError(
+				stepPath.AtName("angle"),
+				"Unsupported Movement Angle",
+				fmt.Sprintf("The angle %d is not between the minimum angle %d and maximum angle %d supported by the device.",
+					angle.ValueInt64(), capabilities.MinAngle, capabilities.MaxAngle),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateCoordinateMode returns an error for each step with an angle that is
+// not a heading between 0 and maxMovementHeading when coordinate_mode is
+// absolute, reading both with get like validateSupportedDirections. Any angle
+// is a valid turn in the relative coordinate mode.
+func validateCoordinateMode(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) diag.Diagnostics {
+	var mode types.String
+	var steps types.List
+
+	diags := get(ctx, path.Root("coordinate_mode"), &mode)
+	diags.Append(get(ctx, path.Root("steps"), &steps)...)
+
+	if diags.HasError() || mode.ValueString() != movementCoordinateModeAbsolute || steps.IsNull() || steps.IsUnknown() {
+		return diags
+	}
+
+	for i, element := range steps.Elements() {
+		step, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		angle, ok := step.Attributes()["angle"].(types.Int64)
+		if !ok || angle.IsNull() || angle.IsUnknown() || (angle.ValueInt64() >= 0 && angle.ValueInt64() <= maxMovementHeading) {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root("steps").AtListIndex(i).AtName("angle"),
+			"Invalid Movement Heading",
+			fmt.Sprintf("The angle must be a heading between 0 and %d degrees when coordinate_mode is %q, got: %d.",
+				maxMovementHeading, movementCoordinateModeAbsolute, angle.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
+// timeNow returns the current time. It is replaced in tests to check
+// scheduled times against a fixed time.
+var timeNow = time.Now
+
+// validateScheduledTime returns an error if at is planned to be submitted
+// with a time that is not in the future, which is when the resource is
+// created or at changes. Unknown and invalid times are not checked, as the
+// latter are reported by the validator of the attribute.
+func validateScheduledTime(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) diag.Diagnostics {
+	var at, priorAt types.String
+
+	diags := plan.GetAttribute(ctx, path.Root("at"), &at)
+
+	if diags.HasError() || at.IsNull() || at.IsUnknown() {
+		return diags
+	}
+
+	if !state.Raw.IsNull() {
+		diags.Append(state.GetAttribute(ctx, path.Root("at"), &priorAt)...)
+
+		if diags.HasError() || priorAt.Equal(at) {
+			return diags
+		}
+	}
+
+	scheduledAt, err := time.Parse(time.RFC3339, at.ValueString())
+	if err != nil || scheduledAt.After(timeNow()) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("at"),
+		"Scheduled Time In The Past",
+		fmt.Sprintf("The movement plan is scheduled at %s, which is not in the future. "+
+			"Set at to a future time, or remove it to execute the movement plan immediately.", at.ValueString()),
+	)
+
+	return diags
+}
+
+// movementWaitTimeout is the minimum time to wait for the device to complete
+// a movement plan, see movementWaitTimeoutFor.
+const movementWaitTimeout = 10 * time.Minute
+
+// movementWaitTimeoutFor returns the time to wait for the device to complete
+// a movement plan with the estimated duration in seconds. It is twice the
+// estimate when that exceeds movementWaitTimeout, as the estimate is a lower
+// bound of the actual duration.
+func movementWaitTimeoutFor(estimate float64) time.Duration {
+	return max(movementWaitTimeout, time.Duration(2*estimate*float64(time.Second)))
+}
+
+// estimateDuration returns the estimated duration in seconds of the steps,
+// as they are sent to the device by the client of the resource.
+func (r *MovementResource) estimateDuration(steps []MovementStepsModel) float64 {
+	return estimateMovementDuration(steps, r.client != nil && r.client.Config.SafeMode)
+}
+
+// movementStreamPath is the path of the event stream reporting the progress
+// of the movement plan being executed.
+const movementStreamPath = "/v1/movement/stream"
+
+// waitForCompletion waits until the device has completed the movement plan,
+// either by following the event stream of the device, falling back to polling
+// if the device does not support it, or by polling.
+func (r *MovementResource) waitForCompletion(ctx context.Context, stream bool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if stream {
+		err := r.client.StreamEvents(ctx, movementStreamPath, func(event clients.Event) (bool, error) {
+			tflog.Info(ctx, "Movement plan progress", map[string]interface{}{
+				"event": event.Type,
+				"data":  event.Data,
+			})
+
+			switch event.Type {
+			case "complete":
+				return true, nil
+			case "failed":
+				return false, fmt.Errorf("device reported the movement plan failed: %s", event.Data)
+			}
+
+			return false, nil
+		})
+
+		// A stream closed before the plan completed, such as by a proxy or
+		// an idle timeout, does not mean the plan failed, so polling
+		// continues where the stream left off.
+		if !clients.IsNotFound(err) && !errors.Is(err, clients.ErrStreamUnsupported) && !errors.Is(err, clients.ErrStreamClosed) {
+			return err
+		}
+
+		tflog.Debug(ctx, "Movement progress stream is unavailable, polling instead", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return clients.Poll(ctx, r.client.Config.PollInterval, func(ctx context.Context) (bool, error) {
+		var readResp model.MovementResponse
+		if err := r.client.GetJSON(ctx, r.client.MovementPath(), &readResp); err != nil {
+			return false, err
+		}
+
+		return !readResp.Moving && !readResp.Scheduled, nil
+	})
 }
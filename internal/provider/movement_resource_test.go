@@ -0,0 +1,2599 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExpandMovementRequest_speed(t *testing.T) {
+	testCases := map[string]struct {
+		speed    types.Float64
+		expected string
+	}{
+		"present": {
+			speed:    types.Float64Value(0.5),
+			expected: `"speed":0.5`,
+		},
+		"omitted": {
+			speed: types.Float64Null(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := MovementResourceModel{
+				Name:    types.StringValue("example"),
+				Persist: types.BoolValue(true),
+				Steps: []MovementStepsModel{
+					{
+						Angle:     types.Int64Value(0),
+						Direction: types.StringValue("forward"),
+						Distance:  types.Float64Value(1),
+						Speed:     testCase.speed,
+					},
+				},
+			}
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"speed"`) {
+					t.Errorf("expected speed to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_omitsUnsetOptionals(t *testing.T) {
+	testCases := map[string]struct {
+		persist    types.Bool
+		present    []string
+		notPresent []string
+	}{
+		"persist-null": {
+			persist:    types.BoolNull(),
+			notPresent: []string{`"persist"`, `"speed"`},
+		},
+		"persist-unknown": {
+			persist:    types.BoolUnknown(),
+			notPresent: []string{`"persist"`, `"speed"`},
+		},
+		"persist-false": {
+			persist:    types.BoolValue(false),
+			present:    []string{`"persist":false`},
+			notPresent: []string{`"speed"`},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := MovementResourceModel{
+				Name:    types.StringValue("example"),
+				Persist: testCase.persist,
+				Steps: []MovementStepsModel{
+					{
+						Angle:     types.Int64Value(0),
+						Direction: types.StringValue("forward"),
+						Distance:  types.Float64Value(1),
+						Speed:     types.Float64Null(),
+					},
+				},
+			}
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for _, field := range testCase.present {
+				if !strings.Contains(string(body), field) {
+					t.Errorf("expected %s in body, got: %s", field, body)
+				}
+			}
+
+			for _, field := range testCase.notPresent {
+				if strings.Contains(string(body), field) {
+					t.Errorf("expected %s to be omitted, got: %s", field, body)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_rotationOmitsDistance(t *testing.T) {
+	data := MovementResourceModel{
+		Name:    types.StringValue("example"),
+		Persist: types.BoolValue(true),
+		Steps: []MovementStepsModel{
+			{
+				Angle:     types.Int64Value(90),
+				Direction: types.StringValue("right"),
+				Distance:  types.Float64Null(),
+				Speed:     types.Float64Null(),
+			},
+		},
+	}
+
+	body, err := json.Marshal(expandMovementRequest(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(string(body), `"distance"`) {
+		t.Errorf("expected distance to be omitted, got: %s", body)
+	}
+}
+
+// testMovementResourceModel returns a movement resource model with a single
+// forward step.
+func testMovementResourceModel() *MovementResourceModel {
+	return &MovementResourceModel{
+		Id:                  types.StringValue("example"),
+		Name:                types.StringValue("example"),
+		Persist:             types.BoolValue(true),
+		StopOnError:         types.BoolValue(true),
+		AvoidObstacles:      types.BoolValue(true),
+		StepResults:         types.ListNull(types.ObjectType{AttrTypes: movementStepResultAttrTypes}),
+		SupportedDirections: types.ListNull(types.StringType),
+		Steps: []MovementStepsModel{
+			testLinearStep("forward", 1),
+		},
+		// The movement lock preflight is covered by its own tests, so that
+		// it does not add a request to every other test.
+		RespectLock: types.BoolValue(false),
+	}
+}
+
+func TestMovementResource_Read_removed(t *testing.T) {
+	testCases := map[string]http.HandlerFunc{
+		"not-found": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"empty-body": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+		"whitespace-body": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("  \n"))
+		},
+	}
+
+	for name, handler := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			resp := testReadResource(t, NewMovementResource(), testClient(t, server), testMovementResourceModel())
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.State.Raw.IsNull() {
+				t.Errorf("expected resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestMovementResource_Read_exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"moving":false}`))
+	}))
+	defer server.Close()
+
+	resp := testReadResource(t, NewMovementResource(), testClient(t, server), testMovementResourceModel())
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+
+	if resp.State.Raw.IsNull() {
+		t.Errorf("expected resource to remain in state")
+	}
+}
+
+func TestMovementResource_Read_persistDrift(t *testing.T) {
+	testCases := map[string]struct {
+		body     string
+		expected bool
+	}{
+		"reported": {
+			body:     `{"moving":false,"persist":false}`,
+			expected: false,
+		},
+		"not-reported": {
+			body:     `{"moving":false}`,
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			resp := testReadResource(t, NewMovementResource(), testClient(t, server), testMovementResourceModel())
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.Persist.ValueBool() != testCase.expected {
+				t.Errorf("expected persist %t, got %s", testCase.expected, data.Persist)
+			}
+		})
+	}
+}
+
+func TestMovementResource_stubDoer(t *testing.T) {
+	client := &clients.Client{
+		Config: clients.ClientConfig{Address: "http://rover.test"},
+		HttpClient: &testDoer{
+			responses: map[string]string{
+				"POST /v1/movement-plan": `{"moving":true}`,
+				"GET /v1/movement-plan":  `{"moving":false}`,
+			},
+		},
+	}
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created MovementResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(context.Background(), &created)...)
+
+	if created.Id.ValueString() != "example" {
+		t.Errorf("expected id example, got %s", created.Id)
+	}
+
+	if !created.Moving.Equal(types.BoolValue(true)) {
+		t.Errorf("expected moving true after create, got %s", created.Moving)
+	}
+
+	readResp := testReadResource(t, NewMovementResource(), client, &created)
+
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if readResp.State.Raw.IsNull() {
+		t.Fatalf("expected resource to remain in state")
+	}
+
+	var read MovementResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(context.Background(), &read)...)
+
+	if !read.Moving.Equal(types.BoolValue(false)) {
+		t.Errorf("expected moving false after read, got %s", read.Moving)
+	}
+}
+
+func TestMovementResource_Create_status(t *testing.T) {
+	testCases := map[string]struct {
+		status      int
+		expectError bool
+	}{
+		"ok":           {status: http.StatusOK},
+		"created":      {status: http.StatusCreated},
+		"accepted":     {status: http.StatusAccepted},
+		"not-found":    {status: http.StatusNotFound, expectError: true},
+		"conflict":     {status: http.StatusConflict, expectError: true},
+		"server-error": {status: http.StatusInternalServerError, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			resp := testCreateResource(t, NewMovementResource(), testClient(t, server), testMovementResourceModel())
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("expected error diagnostics, got none")
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			// The device does not report whether it is moving without a
+			// response body.
+			if !data.Moving.IsNull() {
+				t.Errorf("expected moving to be null, got %s", data.Moving)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Create_marshalError(t *testing.T) {
+	doer := &testDoer{}
+	client := &clients.Client{
+		Config:     clients.ClientConfig{Address: "http://rover.test"},
+		HttpClient: doer,
+	}
+
+	// The plan cannot hold values that fail to marshal, such as NaN, as the
+	// framework rejects them first.
+	r := &MovementResource{
+		marshal: func(v any) ([]byte, error) {
+			return nil, errors.New("marshal failed")
+		},
+	}
+
+	resp := testCreateResource(t, r, client, testMovementResourceModel())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected error diagnostics, got none")
+	}
+
+	if len(doer.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestMovementResource_invalidAddress(t *testing.T) {
+	client := &clients.Client{
+		Config:     clients.ClientConfig{Address: testInvalidAddress},
+		HttpClient: &testDoer{},
+	}
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if !createResp.Diagnostics.HasError() {
+		t.Errorf("expected create error diagnostics, got none")
+	}
+
+	deleteResp := testDeleteResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if !deleteResp.Diagnostics.HasError() {
+		t.Errorf("expected delete error diagnostics, got none")
+	}
+}
+
+func TestMovementResource_doerError(t *testing.T) {
+	client := &clients.Client{
+		Config:     clients.ClientConfig{Address: "http://rover.test"},
+		HttpClient: testErrorDoer{err: errors.New("connection refused")},
+	}
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if createResp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("expected 1 create error diagnostic, got: %v", createResp.Diagnostics)
+	}
+
+	deleteResp := testDeleteResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if deleteResp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("expected 1 delete error diagnostic, got: %v", deleteResp.Diagnostics)
+	}
+}
+
+func TestMovementResource_readOnly(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"POST /v1/movement-plan":   `{"moving":true}`,
+			"DELETE /v1/movement-plan": `{"moving":false}`,
+		},
+	}
+	client := &clients.Client{
+		Config:     clients.ClientConfig{Address: "http://rover.test", ReadOnly: true},
+		HttpClient: doer,
+	}
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if !createResp.Diagnostics.HasError() {
+		t.Errorf("expected create error diagnostics, got none")
+	}
+
+	if !createResp.State.Raw.IsNull() {
+		t.Errorf("expected no resource to be saved to state")
+	}
+
+	deleteResp := testDeleteResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if !deleteResp.Diagnostics.HasError() {
+		t.Errorf("expected delete error diagnostics, got none")
+	}
+
+	if len(doer.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(doer.requests))
+	}
+}
+
+func TestMovementResource_Delete(t *testing.T) {
+	testCases := map[string]struct {
+		statuses    []int
+		expectError bool
+		expectCalls int
+	}{
+		"success": {
+			statuses:    []int{http.StatusOK},
+			expectCalls: 1,
+		},
+		"already-deleted": {
+			statuses:    []int{http.StatusNotFound},
+			expectCalls: 1,
+		},
+		"transient-then-success": {
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectCalls: 3,
+		},
+		"transient-exhausted": {
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectError: true,
+			expectCalls: 4,
+		},
+		"permanent-failure": {
+			statuses:    []int{http.StatusInternalServerError},
+			expectError: true,
+			expectCalls: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected DELETE request, got %s", r.Method)
+				}
+
+				w.WriteHeader(testCase.statuses[min(calls, len(testCase.statuses)-1)])
+				_, _ = w.Write([]byte(`{"moving":false}`))
+				calls++
+			}))
+			defer server.Close()
+
+			resp := testDeleteResource(t, NewMovementResource(), testClient(t, server), testMovementResourceModel())
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if calls != testCase.expectCalls {
+				t.Errorf("expected %d calls, got %d", testCase.expectCalls, calls)
+			}
+		})
+	}
+}
+
+func TestMovementResource_ModifyPlan_defaultPersist(t *testing.T) {
+	testCases := map[string]struct {
+		defaultPersist *bool
+		persist        types.Bool
+		expected       types.Bool
+	}{
+		"provider-default-unset": {
+			persist:  types.BoolNull(),
+			expected: types.BoolValue(true),
+		},
+		"provider-default-false": {
+			defaultPersist: types.BoolValue(false).ValueBoolPointer(),
+			persist:        types.BoolNull(),
+			expected:       types.BoolValue(false),
+		},
+		"provider-default-true": {
+			defaultPersist: types.BoolValue(true).ValueBoolPointer(),
+			persist:        types.BoolNull(),
+			expected:       types.BoolValue(true),
+		},
+		"resource-value-overrides": {
+			defaultPersist: types.BoolValue(false).ValueBoolPointer(),
+			persist:        types.BoolValue(true),
+			expected:       types.BoolValue(true),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				Config: clients.ClientConfig{DefaultPersist: testCase.defaultPersist},
+			}
+
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.Moving = types.BoolNull()
+			config.Persist = testCase.persist
+
+			// The static schema default is applied to the plan before
+			// ModifyPlan is called.
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Moving = types.BoolUnknown()
+			if testCase.persist.IsNull() {
+				plan.Persist = types.BoolValue(true)
+			} else {
+				plan.Persist = testCase.persist
+			}
+
+			resp := testModifyPlanResource(t, &MovementResource{}, client, config, plan)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got types.Bool
+			resp.Plan.GetAttribute(context.Background(), path.Root("persist"), &got)
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected persist %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestEstimateMovementDuration(t *testing.T) {
+	withSpeed := func(step MovementStepsModel, speed float64) MovementStepsModel {
+		step.Speed = types.Float64Value(speed)
+		return step
+	}
+
+	testCases := map[string]struct {
+		steps    []MovementStepsModel
+		safeMode bool
+		expected float64
+	}{
+		"no-steps": {
+			expected: 0,
+		},
+		"default-speed": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 2),
+			},
+			expected: 4,
+		},
+		"step-speed": {
+			steps: []MovementStepsModel{
+				withSpeed(testLinearStep("backward", 3), 2),
+			},
+			expected: 1.5,
+		},
+		"rotation-is-instant": {
+			steps: []MovementStepsModel{
+				testRotationStep("left", 90),
+				testRotationStep("right", 180),
+			},
+			expected: 0,
+		},
+		"mixed": {
+			steps: []MovementStepsModel{
+				withSpeed(testLinearStep("forward", 10), 1),
+				testRotationStep("right", 90),
+				testLinearStep("forward", 1),
+			},
+			expected: 12,
+		},
+		"safe-mode-clamped": {
+			steps: []MovementStepsModel{
+				testLinearStep("forward", 10),
+			},
+			safeMode: true,
+			expected: 8,
+		},
+		"safe-mode-within-limits": {
+			steps: []MovementStepsModel{
+				withSpeed(testLinearStep("forward", 1), 0.1),
+			},
+			safeMode: true,
+			expected: 10,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := estimateMovementDuration(testCase.steps, testCase.safeMode); got != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestMovementWaitTimeoutFor(t *testing.T) {
+	testCases := map[string]struct {
+		estimate float64
+		expected time.Duration
+	}{
+		"short": {
+			estimate: 8,
+			expected: movementWaitTimeout,
+		},
+		"long": {
+			estimate: 600,
+			expected: 20 * time.Minute,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := movementWaitTimeoutFor(testCase.estimate); got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestMovementResource_ModifyPlan_estimatedDuration(t *testing.T) {
+	testCases := map[string]struct {
+		speed    types.Float64
+		safeMode bool
+		expected types.Float64
+	}{
+		"known": {
+			speed:    types.Float64Value(0.25),
+			expected: types.Float64Value(4),
+		},
+		"unknown-speed": {
+			speed:    types.Float64Unknown(),
+			expected: types.Float64Unknown(),
+		},
+		"safe-mode": {
+			speed:    types.Float64Value(1),
+			safeMode: true,
+			expected: types.Float64Value(4),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.Persist = types.BoolNull()
+			config.Moving = types.BoolNull()
+			config.EstimatedDurationSeconds = types.Float64Null()
+			config.Steps[0].Speed = testCase.speed
+
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Moving = types.BoolUnknown()
+			plan.EstimatedDurationSeconds = types.Float64Unknown()
+			plan.Steps[0].Speed = testCase.speed
+
+			client := &clients.Client{
+				Config: clients.ClientConfig{SafeMode: testCase.safeMode},
+			}
+
+			resp := testModifyPlanResource(t, &MovementResource{}, client, config, plan)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got types.Float64
+			resp.Plan.GetAttribute(context.Background(), path.Root("estimated_duration_seconds"), &got)
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected estimated_duration_seconds %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Delete_methodOverride(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"POST /v1/movement-plan": `{}`,
+		},
+	}
+	client := &clients.Client{
+		Config:     clients.ClientConfig{MethodOverride: true},
+		HttpClient: doer,
+	}
+
+	resp := testDeleteResource(t, NewMovementResource(), client, testMovementResourceModel())
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(doer.requests))
+	}
+
+	req := doer.requests[0]
+	if req.Method != http.MethodPost {
+		t.Errorf("expected POST request, got %s", req.Method)
+	}
+
+	if got := req.Header.Get(clients.MethodOverrideHeader); got != http.MethodDelete {
+		t.Errorf("expected %s header %q, got %q", clients.MethodOverrideHeader, http.MethodDelete, got)
+	}
+}
+
+func TestMovementResource_Create_stepResults(t *testing.T) {
+	testCases := map[string]struct {
+		body            string
+		continueOnError bool
+		expectError     bool
+		expectWarning   bool
+		expectedResults []MovementStepResultModel
+	}{
+		"not-reported": {
+			body: `{"moving":true}`,
+		},
+		"completed": {
+			body: `{"moving":false,"steps":[{"index":0,"status":"completed"},{"index":1,"status":"completed"}]}`,
+			expectedResults: []MovementStepResultModel{
+				{Index: types.Int64Value(0), Status: types.StringValue("completed"), Message: types.StringNull()},
+				{Index: types.Int64Value(1), Status: types.StringValue("completed"), Message: types.StringNull()},
+			},
+		},
+		"failed": {
+			body:        `{"moving":false,"steps":[{"index":0,"status":"completed"},{"index":1,"status":"failed","message":"obstacle detected"}]}`,
+			expectError: true,
+			expectedResults: []MovementStepResultModel{
+				{Index: types.Int64Value(0), Status: types.StringValue("completed"), Message: types.StringNull()},
+				{Index: types.Int64Value(1), Status: types.StringValue("failed"), Message: types.StringValue("obstacle detected")},
+			},
+		},
+		"failed-continue": {
+			body:            `{"moving":false,"steps":[{"index":0,"status":"failed","message":"obstacle detected"},{"index":1,"status":"completed"}]}`,
+			continueOnError: true,
+			expectWarning:   true,
+			expectedResults: []MovementStepResultModel{
+				{Index: types.Int64Value(0), Status: types.StringValue("failed"), Message: types.StringValue("obstacle detected")},
+				{Index: types.Int64Value(1), Status: types.StringValue("completed"), Message: types.StringNull()},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				HttpClient: &testDoer{
+					responses: map[string]string{
+						"POST /v1/movement-plan": testCase.body,
+					},
+				},
+			}
+
+			plan := testMovementResourceModel()
+			plan.StopOnError = types.BoolValue(!testCase.continueOnError)
+			plan.Steps = append(plan.Steps, testRotationStep("right", 90))
+
+			resp := testCreateResource(t, NewMovementResource(), client, plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if hasWarning := resp.Diagnostics.WarningsCount() > 0; hasWarning != testCase.expectWarning {
+				t.Errorf("expected warning: %t, got: %v", testCase.expectWarning, resp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "index 1") || !strings.Contains(detail, "obstacle detected") {
+					t.Errorf("expected error detail to name the failed step, got: %s", detail)
+				}
+			}
+
+			// The results are saved to state even when a step failed.
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if testCase.expectedResults == nil {
+				if !data.StepResults.IsNull() {
+					t.Errorf("expected step_results to be null, got %s", data.StepResults)
+				}
+
+				return
+			}
+
+			var results []MovementStepResultModel
+			data.StepResults.ElementsAs(context.Background(), &results, false)
+
+			if len(results) != len(testCase.expectedResults) {
+				t.Fatalf("expected %d step results, got %d", len(testCase.expectedResults), len(results))
+			}
+
+			for i, expected := range testCase.expectedResults {
+				if results[i] != expected {
+					t.Errorf("expected step result %d to be %+v, got %+v", i, expected, results[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMovementResource_Create_waitForCompletion(t *testing.T) {
+	testCases := map[string]struct {
+		streamProgress bool
+		streamStatus   int
+		streamClosed   bool
+		expectedPaths  []string
+		expectError    bool
+	}{
+		"poll": {
+			expectedPaths: []string{"POST /v1/movement-plan", "GET /v1/movement-plan"},
+		},
+		"stream": {
+			streamProgress: true,
+			streamStatus:   http.StatusOK,
+			expectedPaths:  []string{"POST /v1/movement-plan", "GET /v1/movement/stream"},
+		},
+		"stream-unavailable": {
+			streamProgress: true,
+			streamStatus:   http.StatusNotFound,
+			expectedPaths:  []string{"POST /v1/movement-plan", "GET /v1/movement/stream", "GET /v1/movement-plan"},
+		},
+		"stream-closed": {
+			streamProgress: true,
+			streamStatus:   http.StatusOK,
+			streamClosed:   true,
+			expectedPaths:  []string{"POST /v1/movement-plan", "GET /v1/movement/stream", "GET /v1/movement-plan"},
+		},
+		"stream-failed": {
+			streamProgress: true,
+			streamStatus:   http.StatusInternalServerError,
+			expectedPaths:  []string{"POST /v1/movement-plan", "GET /v1/movement/stream"},
+			expectError:    true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.Method+" "+r.URL.Path)
+
+				switch r.Method + " " + r.URL.Path {
+				case "POST /v1/movement-plan":
+					_, _ = w.Write([]byte(`{"moving":true}`))
+				case "GET /v1/movement-plan":
+					_, _ = w.Write([]byte(`{"moving":false}`))
+				case "GET /v1/movement/stream":
+					if testCase.streamStatus != http.StatusOK {
+						w.WriteHeader(testCase.streamStatus)
+						return
+					}
+
+					w.Header().Set("Content-Type", "text/event-stream")
+					_, _ = w.Write([]byte("event: progress\ndata: {\"step\":0}\n\n"))
+
+					// The connection is closed before the plan completes,
+					// such as by a proxy.
+					if !testCase.streamClosed {
+						_, _ = w.Write([]byte("event: complete\ndata: {}\n\n"))
+					}
+				}
+			}))
+			defer server.Close()
+
+			plan := testMovementResourceModel()
+			plan.WaitForCompletion = types.BoolValue(true)
+			plan.StreamProgress = types.BoolValue(testCase.streamProgress)
+
+			resp := testCreateResource(t, NewMovementResource(), testClient(t, server), plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if !slices.Equal(paths, testCase.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedPaths, paths)
+			}
+
+			// The submitted plan is saved to state even when waiting fails.
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if expected := types.BoolValue(testCase.expectError); !data.Moving.Equal(expected) {
+				t.Errorf("expected moving to be %s, got %s", expected, data.Moving)
+			}
+		})
+	}
+}
+
+func TestMovementResource_supportedDirections(t *testing.T) {
+	testCases := map[string]struct {
+		supported   types.List
+		expectError bool
+	}{
+		"default": {
+			supported: types.ListNull(types.StringType),
+		},
+		"supported": {
+			supported: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("forward"), types.StringValue("right")}),
+		},
+		"unsupported": {
+			supported:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("forward")}),
+			expectError: true,
+		},
+		"unknown": {
+			supported: types.ListUnknown(types.StringType),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.Moving = types.BoolNull()
+			config.EstimatedDurationSeconds = types.Float64Null()
+			config.SupportedDirections = testCase.supported
+			config.Steps = append(config.Steps, testRotationStep("right", 90))
+
+			// The configuration is validated before data sources are read.
+			schemaResp := testConfigureResource(t, &MovementResource{}, nil)
+			validateResp := &resource.ValidateConfigResponse{}
+			(&MovementResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testResourceState(t, schemaResp, config).Raw,
+				},
+			}, validateResp)
+
+			if validateResp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected validation error: %t, got: %v", testCase.expectError, validateResp.Diagnostics)
+			}
+
+			// The plan is checked again once references are known.
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Moving = types.BoolUnknown()
+			plan.EstimatedDurationSeconds = types.Float64Unknown()
+			plan.SupportedDirections = testCase.supported
+			plan.Steps = config.Steps
+
+			planResp := testModifyPlanResource(t, &MovementResource{}, nil, config, plan)
+
+			if planResp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected plan error: %t, got: %v", testCase.expectError, planResp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				expectedPath := path.Root("steps").AtListIndex(1).AtName("direction")
+				if errPath := planResp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(expectedPath) {
+					t.Errorf("expected error at %s, got %s", expectedPath, errPath)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_queueMode(t *testing.T) {
+	testCases := map[string]struct {
+		queueMode types.String
+		expected  string
+	}{
+		"default": {queueMode: types.StringNull()},
+		"replace": {queueMode: types.StringValue("replace"), expected: `"queue_mode":"replace"`},
+		"queue":   {queueMode: types.StringValue("queue"), expected: `"queue_mode":"queue"`},
+		"reject":  {queueMode: types.StringValue("reject"), expected: `"queue_mode":"reject"`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := *testMovementResourceModel()
+			data.QueueMode = testCase.queueMode
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"queue_mode"`) {
+					t.Errorf("expected queue_mode to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_coordinateMode(t *testing.T) {
+	testCases := map[string]struct {
+		coordinateMode types.String
+		expected       string
+	}{
+		"default":  {coordinateMode: types.StringNull()},
+		"relative": {coordinateMode: types.StringValue("relative"), expected: `"coordinate_mode":"relative"`},
+		"absolute": {coordinateMode: types.StringValue("absolute"), expected: `"coordinate_mode":"absolute"`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := *testMovementResourceModel()
+			data.CoordinateMode = testCase.coordinateMode
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"coordinate_mode"`) {
+					t.Errorf("expected coordinate_mode to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestMovementResource_coordinateMode(t *testing.T) {
+	testCases := map[string]struct {
+		coordinateMode types.String
+		angle          int64
+		expectError    bool
+	}{
+		"default": {
+			coordinateMode: types.StringNull(),
+			angle:          450,
+		},
+		"relative": {
+			coordinateMode: types.StringValue("relative"),
+			angle:          -90,
+		},
+		"absolute heading": {
+			coordinateMode: types.StringValue("absolute"),
+			angle:          270,
+		},
+		"absolute negative": {
+			coordinateMode: types.StringValue("absolute"),
+			angle:          -90,
+			expectError:    true,
+		},
+		"absolute full turn": {
+			coordinateMode: types.StringValue("absolute"),
+			angle:          360,
+			expectError:    true,
+		},
+		"unknown": {
+			coordinateMode: types.StringUnknown(),
+			angle:          450,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.Moving = types.BoolNull()
+			config.EstimatedDurationSeconds = types.Float64Null()
+			config.CoordinateMode = testCase.coordinateMode
+			config.Steps = append(config.Steps, testRotationStep("right", testCase.angle))
+
+			schemaResp := testConfigureResource(t, &MovementResource{}, nil)
+			validateResp := &resource.ValidateConfigResponse{}
+			(&MovementResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testResourceState(t, schemaResp, config).Raw,
+				},
+			}, validateResp)
+
+			if validateResp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected validation error: %t, got: %v", testCase.expectError, validateResp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				expectedPath := path.Root("steps").AtListIndex(1).AtName("angle")
+				if errPath := validateResp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(expectedPath) {
+					t.Errorf("expected error at %s, got %s", expectedPath, errPath)
+				}
+			}
+		})
+	}
+}
+
+func TestMovementResource_Create_queueModeReject(t *testing.T) {
+	testCases := map[string]struct {
+		queueMode       types.String
+		expectedSummary string
+	}{
+		"reject": {
+			queueMode:       types.StringValue("reject"),
+			expectedSummary: "Device Busy",
+		},
+		"default": {
+			queueMode:       types.StringNull(),
+			expectedSummary: "Unable to Create Resource",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message":"device is busy"}`))
+			}))
+			defer server.Close()
+
+			plan := testMovementResourceModel()
+			plan.QueueMode = testCase.queueMode
+
+			resp := testCreateResource(t, NewMovementResource(), testClient(t, server), plan)
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error diagnostic, got: %v", resp.Diagnostics)
+			}
+
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != testCase.expectedSummary {
+				t.Errorf("expected summary %q, got %q", testCase.expectedSummary, summary)
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_stopOnError(t *testing.T) {
+	testCases := map[string]struct {
+		stopOnError types.Bool
+		expected    string
+	}{
+		"null":    {stopOnError: types.BoolNull()},
+		"unknown": {stopOnError: types.BoolUnknown()},
+		"true":    {stopOnError: types.BoolValue(true), expected: `"stop_on_error":true`},
+		"false":   {stopOnError: types.BoolValue(false), expected: `"stop_on_error":false`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := *testMovementResourceModel()
+			data.StopOnError = testCase.stopOnError
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"stop_on_error"`) {
+					t.Errorf("expected stop_on_error to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_avoidObstacles(t *testing.T) {
+	testCases := map[string]struct {
+		avoidObstacles types.Bool
+		expected       string
+	}{
+		"null":  {avoidObstacles: types.BoolNull()},
+		"true":  {avoidObstacles: types.BoolValue(true), expected: `"avoid_obstacles":true`},
+		"false": {avoidObstacles: types.BoolValue(false), expected: `"avoid_obstacles":false`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := *testMovementResourceModel()
+			data.AvoidObstacles = testCase.avoidObstacles
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"avoid_obstacles"`) {
+					t.Errorf("expected avoid_obstacles to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestMovementResource_ValidateConfig_avoidObstacles(t *testing.T) {
+	testCases := map[string]struct {
+		avoidObstacles types.Bool
+		expectWarning  bool
+	}{
+		"omitted":  {avoidObstacles: types.BoolNull()},
+		"unknown":  {avoidObstacles: types.BoolUnknown()},
+		"enabled":  {avoidObstacles: types.BoolValue(true)},
+		"disabled": {avoidObstacles: types.BoolValue(false), expectWarning: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.AvoidObstacles = testCase.avoidObstacles
+
+			schemaResp := testConfigureResource(t, &MovementResource{}, nil)
+			resp := &resource.ValidateConfigResponse{}
+			(&MovementResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testResourceState(t, schemaResp, config).Raw,
+				},
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !testCase.expectWarning {
+				if resp.Diagnostics.WarningsCount() != 0 {
+					t.Errorf("expected no warnings, got: %v", resp.Diagnostics)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.WarningsCount() != 1 {
+				t.Fatalf("expected 1 warning, got: %v", resp.Diagnostics)
+			}
+
+			warning := resp.Diagnostics.Warnings()[0]
+			if warning.Summary() != "Obstacle Avoidance Disabled" {
+				t.Errorf("expected obstacle avoidance warning, got: %s", warning.Summary())
+			}
+
+			if warnPath := warning.(diag.DiagnosticWithPath).Path(); !warnPath.Equal(path.Root("avoid_obstacles")) {
+				t.Errorf("expected warning at avoid_obstacles, got %s", warnPath)
+			}
+		})
+	}
+}
+
+func TestExpandMovementRequest_at(t *testing.T) {
+	testCases := map[string]struct {
+		at       types.String
+		expected string
+	}{
+		"immediate": {at: types.StringNull()},
+		"scheduled": {at: types.StringValue("2030-01-02T15:04:05Z"), expected: `"at":"2030-01-02T15:04:05Z"`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := *testMovementResourceModel()
+			data.At = testCase.at
+
+			body, err := json.Marshal(expandMovementRequest(data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.expected == "" {
+				if strings.Contains(string(body), `"at"`) {
+					t.Errorf("expected at to be omitted, got: %s", body)
+				}
+
+				return
+			}
+
+			if !strings.Contains(string(body), testCase.expected) {
+				t.Errorf("expected %s in body, got: %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestValidateScheduledTime(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		exists      bool
+		priorAt     types.String
+		at          types.String
+		expectError bool
+	}{
+		"immediate": {
+			at: types.StringNull(),
+		},
+		"future": {
+			at: types.StringValue("2024-01-02T16:00:00Z"),
+		},
+		"past": {
+			at:          types.StringValue("2024-01-02T14:00:00Z"),
+			expectError: true,
+		},
+		"now": {
+			at:          types.StringValue("2024-01-02T15:00:00Z"),
+			expectError: true,
+		},
+		"unknown": {
+			at: types.StringUnknown(),
+		},
+		"unchanged past": {
+			exists:  true,
+			priorAt: types.StringValue("2024-01-02T14:00:00Z"),
+			at:      types.StringValue("2024-01-02T14:00:00Z"),
+		},
+		"changed to past": {
+			exists:      true,
+			priorAt:     types.StringValue("2024-01-02T16:00:00Z"),
+			at:          types.StringValue("2024-01-02T14:00:00Z"),
+			expectError: true,
+		},
+	}
+
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			schemaResp := testConfigureResource(t, &MovementResource{}, nil)
+
+			plan := testMovementResourceModel()
+			plan.At = testCase.at
+			planState := testResourceState(t, schemaResp, plan)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if testCase.exists {
+				prior := testMovementResourceModel()
+				prior.At = testCase.priorAt
+				state = testResourceState(t, schemaResp, prior)
+			}
+
+			diags := validateScheduledTime(ctx, tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}, state)
+
+			if diags.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, diags)
+			}
+
+			if testCase.expectError {
+				if errPath := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(path.Root("at")) {
+					t.Errorf("expected error at at, got %s", errPath)
+				}
+			}
+		})
+	}
+}
+
+func TestMovementResource_Read_scheduled(t *testing.T) {
+	client := &clients.Client{
+		Config: clients.ClientConfig{Address: "http://rover.test"},
+		HttpClient: &testDoer{responses: map[string]string{
+			"GET " + clients.DefaultMovementPath: `{"moving":false,"scheduled":true}`,
+		}},
+	}
+
+	state := testMovementResourceModel()
+	state.At = types.StringValue("2030-01-02T15:04:05Z")
+
+	resp := testReadResource(t, NewMovementResource(), client, state)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data MovementResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if !data.Scheduled.Equal(types.BoolValue(true)) {
+		t.Errorf("expected scheduled to be true, got %s", data.Scheduled)
+	}
+}
+
+func TestMovementRequestPatch(t *testing.T) {
+	testCases := map[string]struct {
+		modify   func(plan *MovementResourceModel)
+		expected string
+	}{
+		"unchanged": {
+			modify:   func(plan *MovementResourceModel) {},
+			expected: `{}`,
+		},
+		"provider only": {
+			modify: func(plan *MovementResourceModel) {
+				plan.WaitForCompletion = types.BoolValue(true)
+			},
+			expected: `{}`,
+		},
+		"steps": {
+			modify: func(plan *MovementResourceModel) {
+				plan.Steps = append(plan.Steps, testRotationStep("right", 90))
+			},
+			expected: `{"steps":[{"angle":0,"direction":"forward","distance":1},{"angle":90,"direction":"right"}]}`,
+		},
+		"fields": {
+			modify: func(plan *MovementResourceModel) {
+				plan.Persist = types.BoolValue(false)
+				plan.QueueMode = types.StringValue("queue")
+			},
+			expected: `{"persist":false,"queue_mode":"queue"}`,
+		},
+		"removed": {
+			modify: func(plan *MovementResourceModel) {
+				plan.Persist = types.BoolNull()
+			},
+			expected: `{"persist":null}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := testMovementResourceModel()
+			plan := testMovementResourceModel()
+			testCase.modify(plan)
+
+			patch, err := movementRequestPatch(expandMovementRequest(*state), expandMovementRequest(*plan))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			body, err := json.Marshal(patch)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(body) != testCase.expected {
+				t.Errorf("expected patch %s, got %s", testCase.expected, body)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update(t *testing.T) {
+	testCases := map[string]struct {
+		responses        map[string]string
+		expectedRequests []string
+		expectedBody     string
+		expectError      bool
+	}{
+		"patch": {
+			responses: map[string]string{
+				"PATCH /v1/movement-plan/example": `{"moving":true}`,
+			},
+			expectedRequests: []string{"PATCH /v1/movement-plan/example"},
+			expectedBody:     `{"queue_mode":"queue","steps":[{"angle":0,"direction":"forward","distance":1},{"angle":90,"direction":"right"}]}`,
+		},
+		"patch unsupported": {
+			responses: map[string]string{
+				"POST /v1/movement-plan": `{"moving":true}`,
+			},
+			expectedRequests: []string{"PATCH /v1/movement-plan/example", "POST /v1/movement-plan"},
+			expectedBody:     `{"avoid_obstacles":true,"name":"example","persist":true,"queue_mode":"queue","stop_on_error":true,"steps":[{"angle":0,"direction":"forward","distance":1},{"angle":90,"direction":"right"}]}`,
+		},
+		"error": {
+			responses:        map[string]string{},
+			expectedRequests: []string{"PATCH /v1/movement-plan/example", "POST /v1/movement-plan"},
+			expectError:      true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doer := &testDoer{responses: testCase.responses}
+			client := &clients.Client{HttpClient: doer}
+
+			state := testMovementResourceModel()
+			state.Moving = types.BoolValue(false)
+			state.Scheduled = types.BoolValue(false)
+			state.EstimatedDurationSeconds = types.Float64Value(2)
+
+			plan := testMovementResourceModel()
+			plan.QueueMode = types.StringValue("queue")
+			plan.Steps = append(plan.Steps, testRotationStep("right", 90))
+
+			resp := testUpdateResource(t, NewMovementResource(), client, state, plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			requests := make([]string, len(doer.requests))
+			for i, req := range doer.requests {
+				requests[i] = req.Method + " " + req.URL.Path
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Fatalf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			body, err := io.ReadAll(doer.requests[len(doer.requests)-1].Body)
+			if err != nil {
+				t.Fatalf("unexpected error reading request body: %s", err)
+			}
+
+			if string(body) != testCase.expectedBody {
+				t.Errorf("expected body %s, got %s", testCase.expectedBody, body)
+			}
+
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Moving.ValueBool() {
+				t.Errorf("expected moving to be true, got %s", data.Moving)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_queueModeReject(t *testing.T) {
+	testCases := map[string]struct {
+		patchStatus     int
+		queueMode       types.String
+		expectedSummary string
+	}{
+		"patch": {
+			patchStatus:     http.StatusConflict,
+			queueMode:       types.StringValue("reject"),
+			expectedSummary: "Device Busy",
+		},
+		"patch unsupported": {
+			patchStatus:     http.StatusMethodNotAllowed,
+			queueMode:       types.StringValue("reject"),
+			expectedSummary: "Device Busy",
+		},
+		"default": {
+			patchStatus:     http.StatusConflict,
+			queueMode:       types.StringNull(),
+			expectedSummary: "Unable to Update Resource",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					w.WriteHeader(testCase.patchStatus)
+					return
+				}
+
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message":"device is busy"}`))
+			}))
+			defer server.Close()
+
+			state := testMovementResourceModel()
+			state.Moving = types.BoolValue(false)
+			state.Scheduled = types.BoolValue(false)
+			state.EstimatedDurationSeconds = types.Float64Value(2)
+
+			plan := testMovementResourceModel()
+			plan.QueueMode = testCase.queueMode
+			plan.Steps = append(plan.Steps, testRotationStep("right", 90))
+
+			resp := testUpdateResource(t, NewMovementResource(), testClient(t, server), state, plan)
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error diagnostic, got: %v", resp.Diagnostics)
+			}
+
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != testCase.expectedSummary {
+				t.Errorf("expected summary %q, got %q", testCase.expectedSummary, summary)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_waitForCompletion(t *testing.T) {
+	testCases := map[string]struct {
+		patchStatus   int
+		pollStatus    int
+		expectedPaths []string
+		expectError   bool
+	}{
+		"patch": {
+			patchStatus:   http.StatusOK,
+			pollStatus:    http.StatusOK,
+			expectedPaths: []string{"PATCH", "GET /v1/movement-plan"},
+		},
+		"patch unsupported": {
+			patchStatus:   http.StatusMethodNotAllowed,
+			pollStatus:    http.StatusOK,
+			expectedPaths: []string{"PATCH", "POST /v1/movement-plan", "GET /v1/movement-plan"},
+		},
+		"wait failed": {
+			patchStatus:   http.StatusOK,
+			pollStatus:    http.StatusBadRequest,
+			expectedPaths: []string{"PATCH", "GET /v1/movement-plan"},
+			expectError:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPatch:
+					paths = append(paths, r.Method)
+
+					w.WriteHeader(testCase.patchStatus)
+					_, _ = w.Write([]byte(`{"moving":true}`))
+				case http.MethodPost:
+					paths = append(paths, r.Method+" "+r.URL.Path)
+
+					_, _ = w.Write([]byte(`{"moving":true}`))
+				default:
+					paths = append(paths, r.Method+" "+r.URL.Path)
+
+					w.WriteHeader(testCase.pollStatus)
+					_, _ = w.Write([]byte(`{"moving":false}`))
+				}
+			}))
+			defer server.Close()
+
+			state := testMovementResourceModel()
+			state.Moving = types.BoolValue(false)
+			state.Scheduled = types.BoolValue(false)
+			state.EstimatedDurationSeconds = types.Float64Value(2)
+
+			plan := testMovementResourceModel()
+			plan.WaitForCompletion = types.BoolValue(true)
+			plan.Steps = append(plan.Steps, testRotationStep("right", 90))
+
+			resp := testUpdateResource(t, NewMovementResource(), testClient(t, server), state, plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if !slices.Equal(paths, testCase.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedPaths, paths)
+			}
+
+			// The updated plan is saved to state even when waiting fails.
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if expected := types.BoolValue(testCase.expectError); !data.Moving.Equal(expected) {
+				t.Errorf("expected moving to be %s, got %s", expected, data.Moving)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_unchanged(t *testing.T) {
+	doer := &testDoer{}
+	client := &clients.Client{HttpClient: doer}
+
+	state := testMovementResourceModel()
+	state.Moving = types.BoolValue(true)
+	state.Scheduled = types.BoolValue(false)
+	state.EstimatedDurationSeconds = types.Float64Value(2)
+
+	plan := testMovementResourceModel()
+	plan.WaitForCompletion = types.BoolValue(true)
+
+	resp := testUpdateResource(t, NewMovementResource(), client, state, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(doer.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(doer.requests))
+	}
+
+	var data MovementResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if !data.Moving.ValueBool() {
+		t.Errorf("expected moving to be kept from state, got %s", data.Moving)
+	}
+}
+
+func TestMovementStepsEqual(t *testing.T) {
+	// Values read from Terraform carry a higher precision than values
+	// created by the provider.
+	distance, err := types.Float64Type.ValueFromTerraform(context.Background(), tftypes.NewValue(tftypes.Number, new(big.Float).SetPrec(512).SetFloat64(1.5)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fromTerraform := testLinearStep("forward", 1.5)
+	fromTerraform.Distance = distance.(types.Float64)
+
+	if reflect.DeepEqual(fromTerraform, testLinearStep("forward", 1.5)) {
+		t.Fatal("expected the steps to differ in internal state")
+	}
+
+	testCases := map[string]struct {
+		a, b     []MovementStepsModel
+		expected bool
+	}{
+		"equal": {
+			a:        []MovementStepsModel{testLinearStep("forward", 1.5), testRotationStep("left", 90)},
+			b:        []MovementStepsModel{testLinearStep("forward", 1.5), testRotationStep("left", 90)},
+			expected: true,
+		},
+		"precision": {
+			a:        []MovementStepsModel{fromTerraform},
+			b:        []MovementStepsModel{testLinearStep("forward", 1.5)},
+			expected: true,
+		},
+		"different-distance": {
+			a: []MovementStepsModel{testLinearStep("forward", 1.5)},
+			b: []MovementStepsModel{testLinearStep("forward", 2)},
+		},
+		"different-length": {
+			a: []MovementStepsModel{testLinearStep("forward", 1.5)},
+			b: []MovementStepsModel{testLinearStep("forward", 1.5), testLinearStep("forward", 1.5)},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := movementStepsEqual(testCase.a, testCase.b); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveMovementWaypoints(t *testing.T) {
+	testCases := map[string]struct {
+		steps         []MovementStepsModel
+		waypoints     map[string]MovementWaypointModel
+		absolute      bool
+		expected      []MovementStepsModel
+		expectedError string
+	}{
+		"no waypoints": {
+			steps:    []MovementStepsModel{testLinearStep("forward", 1), testRotationStep("right", 90)},
+			expected: []MovementStepsModel{testLinearStep("forward", 1), testRotationStep("right", 90)},
+		},
+		"ahead": {
+			steps:     []MovementStepsModel{testWaypointStep("dock")},
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(0, 2)},
+			expected:  []MovementStepsModel{testTurnStep(0, 2)},
+		},
+		"relative turn": {
+			steps:     []MovementStepsModel{testRotationStep("right", 90), testWaypointStep("dock")},
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(0, -3)},
+			expected:  []MovementStepsModel{testRotationStep("right", 90), testTurnStep(90, 3)},
+		},
+		"absolute heading": {
+			steps:     []MovementStepsModel{testRotationStep("right", 90), testWaypointStep("dock")},
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(0, -3)},
+			absolute:  true,
+			expected:  []MovementStepsModel{testRotationStep("right", 90), testTurnStep(180, 3)},
+		},
+		"after steps": {
+			steps:     []MovementStepsModel{testLinearStep("forward", 2), testWaypointStep("dock")},
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(-4, 2)},
+			expected:  []MovementStepsModel{testLinearStep("forward", 2), testTurnStep(270, 4)},
+		},
+		"several waypoints": {
+			steps: []MovementStepsModel{testWaypointStep("a"), testWaypointStep("b"), testWaypointStep("a")},
+			waypoints: map[string]MovementWaypointModel{
+				"a": testWaypoint(2, 0),
+				"b": testWaypoint(2, 2),
+			},
+			expected: []MovementStepsModel{testTurnStep(90, 2), testTurnStep(270, 2), testTurnStep(180, 2)},
+		},
+		"split": {
+			steps:     []MovementStepsModel{testWaypointStep("far")},
+			waypoints: map[string]MovementWaypointModel{"far": testWaypoint(150, 0)},
+			expected:  []MovementStepsModel{testTurnStep(90, 75), testTurnStep(0, 75)},
+		},
+		"already there": {
+			steps:     []MovementStepsModel{testLinearStep("forward", 1), testWaypointStep("dock")},
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(0, 1)},
+			expected:  []MovementStepsModel{testLinearStep("forward", 1)},
+		},
+		"too close": {
+			steps:         []MovementStepsModel{testWaypointStep("dock")},
+			waypoints:     map[string]MovementWaypointModel{"dock": testWaypoint(0.5, 0)},
+			expectedError: `step 0: The waypoint "dock" is predicted to be 0.50 meters away, which is less than the minimum distance of a step of 1 meters.`,
+		},
+		"undefined": {
+			steps:         []MovementStepsModel{testLinearStep("forward", 1), testWaypointStep("dock")},
+			waypoints:     map[string]MovementWaypointModel{"home": testWaypoint(0, 0)},
+			expectedError: `step 1: The waypoint "dock" is not defined in waypoints.`,
+		},
+		"no waypoints defined": {
+			steps:         []MovementStepsModel{testWaypointStep("dock")},
+			expectedError: `step 0: The waypoint "dock" is not defined in waypoints.`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveMovementWaypoints(testCase.steps, testCase.waypoints, testCase.absolute)
+
+			if testCase.expectedError != "" {
+				if err == nil || err.Error() != testCase.expectedError {
+					t.Fatalf("expected error %q, got: %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(got) != len(testCase.expected) {
+				t.Fatalf("expected %d steps, got %d: %v", len(testCase.expected), len(got), got)
+			}
+
+			for i := range got {
+				if !testMovementStepEqual(got[i], testCase.expected[i]) {
+					t.Errorf("expected step %d to be %+v, got %+v", i, testCase.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func testMovementStepEqual(a, b MovementStepsModel) bool {
+	return a.Angle.Equal(b.Angle) &&
+		a.Direction.Equal(b.Direction) &&
+		a.Distance.Equal(b.Distance) &&
+		a.Speed.Equal(b.Speed) &&
+		a.Waypoint.Equal(b.Waypoint)
+}
+
+// testTurnStep returns the forward step that a waypoint resolves to.
+func testTurnStep(angle int64, distance float64) MovementStepsModel {
+	step := testLinearStep("forward", distance)
+	step.Angle = types.Int64Value(angle)
+
+	return step
+}
+
+func TestMovementResource_waypoints(t *testing.T) {
+	testCases := map[string]struct {
+		waypoints   map[string]MovementWaypointModel
+		expectError bool
+	}{
+		"defined": {
+			waypoints: map[string]MovementWaypointModel{"dock": testWaypoint(0, 3)},
+		},
+		"undefined": {
+			waypoints:   map[string]MovementWaypointModel{"home": testWaypoint(0, 3)},
+			expectError: true,
+		},
+		"none": {
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			config := testMovementResourceModel()
+			config.Id = types.StringNull()
+			config.Moving = types.BoolNull()
+			config.EstimatedDurationSeconds = types.Float64Null()
+			config.Waypoints = testCase.waypoints
+			config.Steps = append(config.Steps, testWaypointStep("dock"))
+
+			schemaResp := testConfigureResource(t, &MovementResource{}, nil)
+			validateResp := &resource.ValidateConfigResponse{}
+			(&MovementResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testResourceState(t, schemaResp, config).Raw,
+				},
+			}, validateResp)
+
+			if validateResp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected validation error: %t, got: %v", testCase.expectError, validateResp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				expectedPath := path.Root("steps").AtListIndex(1).AtName("waypoint")
+				if errPath := validateResp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(expectedPath) {
+					t.Errorf("expected error at %s, got %s", expectedPath, errPath)
+				}
+
+				return
+			}
+
+			modifyResp := testModifyPlanResource(t, &MovementResource{}, nil, config, config)
+			if modifyResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected plan diagnostics: %v", modifyResp.Diagnostics)
+			}
+
+			// The first step moves 1 meter and the waypoint another 2 meters,
+			// at the default speed of 0.5 meters per second.
+			var duration types.Float64
+			modifyResp.Plan.GetAttribute(ctx, path.Root("estimated_duration_seconds"), &duration)
+			if duration.ValueFloat64() != 6 {
+				t.Errorf("expected estimated duration 6, got %s", duration)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Create_waypoints(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"POST /v1/movement-plan": `{"moving":true}`,
+		},
+	}
+	client := &clients.Client{HttpClient: doer}
+
+	plan := testMovementResourceModel()
+	plan.Id = types.StringUnknown()
+	plan.Waypoints = map[string]MovementWaypointModel{"dock": testWaypoint(2, 1)}
+	plan.Steps = append(plan.Steps, testWaypointStep("dock"))
+
+	resp := testCreateResource(t, NewMovementResource(), client, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	body, err := io.ReadAll(doer.requests[0].Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading request body: %s", err)
+	}
+
+	var request struct {
+		Steps []map[string]any `json:"steps"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []map[string]any{
+		{"angle": float64(0), "direction": "forward", "distance": float64(1)},
+		{"angle": float64(90), "direction": "forward", "distance": float64(2)},
+	}
+
+	if len(request.Steps) != len(expected) {
+		t.Fatalf("expected %d steps, got: %s", len(expected), body)
+	}
+
+	for i := range expected {
+		for key, value := range expected[i] {
+			if request.Steps[i][key] != value {
+				t.Errorf("expected step %d %s to be %v, got: %s", i, key, value, body)
+			}
+		}
+	}
+
+	// The steps are saved to state as configured, rather than resolved.
+	var data MovementResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if len(data.Steps) != 2 || data.Steps[1].Waypoint.ValueString() != "dock" {
+		t.Errorf("expected the configured steps in state, got %+v", data.Steps)
+	}
+}
+
+func TestMovementResource_Create_safeMode(t *testing.T) {
+	testCases := map[string]struct {
+		safeMode         bool
+		expected         []map[string]any
+		expectedWarnings int
+	}{
+		"disabled": {
+			expected: []map[string]any{
+				{"distance": float64(5), "speed": float64(1)},
+				{"distance": float64(1), "speed": nil},
+			},
+		},
+		"enabled": {
+			safeMode: true,
+			expected: []map[string]any{
+				{"distance": safeModeMaxStepDistance, "speed": safeModeMaxStepSpeed},
+				{"distance": float64(1), "speed": safeModeMaxStepSpeed},
+			},
+			expectedWarnings: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doer := &testDoer{
+				responses: map[string]string{
+					"POST /v1/movement-plan": `{"moving":true}`,
+				},
+			}
+			client := &clients.Client{
+				Config:     clients.ClientConfig{SafeMode: testCase.safeMode},
+				HttpClient: doer,
+			}
+
+			fast := testLinearStep("forward", 5)
+			fast.Speed = types.Float64Value(1)
+
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Steps = []MovementStepsModel{fast, testLinearStep("forward", 1)}
+
+			resp := testCreateResource(t, NewMovementResource(), client, plan)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if got := resp.Diagnostics.WarningsCount(); got != testCase.expectedWarnings {
+				t.Errorf("expected %d warnings, got: %v", testCase.expectedWarnings, resp.Diagnostics)
+			}
+
+			body, err := io.ReadAll(doer.requests[0].Body)
+			if err != nil {
+				t.Fatalf("unexpected error reading request body: %s", err)
+			}
+
+			var request struct {
+				Steps []map[string]any `json:"steps"`
+			}
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(request.Steps) != len(testCase.expected) {
+				t.Fatalf("expected %d steps, got: %s", len(testCase.expected), body)
+			}
+
+			for i := range testCase.expected {
+				for key, value := range testCase.expected[i] {
+					if request.Steps[i][key] != value {
+						t.Errorf("expected step %d %s to be %v, got: %s", i, key, value, body)
+					}
+				}
+			}
+
+			// The steps are saved to state as configured, rather than clamped.
+			var data MovementResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if got := data.Steps[0].Distance.ValueFloat64(); got != 5 {
+				t.Errorf("expected the configured distance in state, got %g", got)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_safeMode(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"PATCH /v1/movement-plan/example": `{"moving":true}`,
+		},
+	}
+	client := &clients.Client{
+		Config:     clients.ClientConfig{SafeMode: true},
+		HttpClient: doer,
+	}
+
+	// Steps that differ only beyond the limits are sent the same, so the
+	// patch only contains the step within them.
+	state := testMovementResourceModel()
+	state.Steps = []MovementStepsModel{testLinearStep("forward", 5), testLinearStep("forward", 1)}
+
+	plan := testMovementResourceModel()
+	plan.Steps = []MovementStepsModel{testLinearStep("forward", 10), testLinearStep("forward", 1.5)}
+
+	resp := testUpdateResource(t, NewMovementResource(), client, state, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := resp.Diagnostics.WarningsCount(); got != 1 {
+		t.Errorf("expected 1 warning for the planned steps, got: %v", resp.Diagnostics)
+	}
+
+	body, err := io.ReadAll(doer.requests[0].Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading request body: %s", err)
+	}
+
+	if !strings.Contains(string(body), `"distance":2`) || !strings.Contains(string(body), `"distance":1.5`) {
+		t.Errorf("expected clamped steps in the patch, got: %s", body)
+	}
+}
+
+func TestMovementResource_Create_respectLock(t *testing.T) {
+	testCases := map[string]struct {
+		respectLock      bool
+		lock             string
+		expectedRequests []string
+		expectError      bool
+	}{
+		"unlocked": {
+			respectLock:      true,
+			lock:             `{"locked":false}`,
+			expectedRequests: []string{"GET /v1/movement/lock", "POST /v1/movement-plan"},
+		},
+		"locked": {
+			respectLock:      true,
+			lock:             `{"locked":true,"expires_at":"2024-01-02T15:04:05Z"}`,
+			expectedRequests: []string{"GET /v1/movement/lock"},
+			expectError:      true,
+		},
+		"lock-unsupported": {
+			respectLock:      true,
+			expectedRequests: []string{"GET /v1/movement/lock", "POST /v1/movement-plan"},
+		},
+		"locked-not-respected": {
+			lock:             `{"locked":true}`,
+			expectedRequests: []string{"POST /v1/movement-plan"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			responses := map[string]string{"POST /v1/movement-plan": `{"moving":true}`}
+			if testCase.lock != "" {
+				responses["GET /v1/movement/lock"] = testCase.lock
+			}
+
+			doer := &testDoer{responses: responses}
+
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.RespectLock = types.BoolValue(testCase.respectLock)
+
+			resp := testCreateResource(t, NewMovementResource(), &clients.Client{HttpClient: doer}, plan)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Device Movement Locked" {
+					t.Errorf("expected Device Movement Locked error, got: %s", summary)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var requests []string
+			for _, req := range doer.requests {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_respectLock(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"GET /v1/movement/lock":           `{"locked":true}`,
+			"PATCH /v1/movement-plan/example": `{"moving":true}`,
+		},
+	}
+
+	state := testMovementResourceModel()
+	state.RespectLock = types.BoolValue(true)
+
+	plan := testMovementResourceModel()
+	plan.RespectLock = types.BoolValue(true)
+	plan.Steps = []MovementStepsModel{testLinearStep("forward", 2)}
+
+	resp := testUpdateResource(t, NewMovementResource(), &clients.Client{HttpClient: doer}, state, plan)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Device Movement Locked" {
+		t.Errorf("expected Device Movement Locked error, got: %s", summary)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Errorf("expected only the lock to be checked, got %d requests", len(doer.requests))
+	}
+}
+
+func TestMovementResource_Create_minBattery(t *testing.T) {
+	testCases := map[string]struct {
+		minBattery       types.Int64
+		battery          string
+		expectedRequests []string
+		expectedError    string
+	}{
+		"above": {
+			minBattery:       types.Int64Value(20),
+			battery:          `{"value":80,"unit":"%"}`,
+			expectedRequests: []string{"GET /v1/device/battery", "POST /v1/movement-plan"},
+		},
+		"at-threshold": {
+			minBattery:       types.Int64Value(20),
+			battery:          `{"value":20,"unit":"%"}`,
+			expectedRequests: []string{"GET /v1/device/battery", "POST /v1/movement-plan"},
+		},
+		"below": {
+			minBattery:       types.Int64Value(20),
+			battery:          `{"value":15,"unit":"%"}`,
+			expectedRequests: []string{"GET /v1/device/battery"},
+			expectedError:    "Insufficient Battery",
+		},
+		"battery-unavailable": {
+			minBattery:       types.Int64Value(20),
+			expectedRequests: []string{"GET /v1/device/battery"},
+			expectedError:    "Unable to Check Battery",
+		},
+		"unset": {
+			minBattery:       types.Int64Null(),
+			battery:          `{"value":5,"unit":"%"}`,
+			expectedRequests: []string{"POST /v1/movement-plan"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			responses := map[string]string{"POST /v1/movement-plan": `{"moving":true}`}
+			if testCase.battery != "" {
+				responses["GET /v1/device/battery"] = testCase.battery
+			}
+
+			doer := &testDoer{responses: responses}
+
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.MinBattery = testCase.minBattery
+
+			resp := testCreateResource(t, NewMovementResource(), &clients.Client{HttpClient: doer}, plan)
+
+			if testCase.expectedError != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != testCase.expectedError {
+					t.Errorf("expected %s error, got: %s", testCase.expectedError, summary)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var requests []string
+			for _, req := range doer.requests {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+			}
+
+			if !slices.Equal(requests, testCase.expectedRequests) {
+				t.Errorf("expected requests %v, got %v", testCase.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestMovementResource_Update_minBattery(t *testing.T) {
+	doer := &testDoer{
+		responses: map[string]string{
+			"GET /v1/device/battery":          `{"value":10,"unit":"%"}`,
+			"PATCH /v1/movement-plan/example": `{"moving":true}`,
+		},
+	}
+
+	state := testMovementResourceModel()
+	state.MinBattery = types.Int64Value(25)
+
+	plan := testMovementResourceModel()
+	plan.MinBattery = types.Int64Value(25)
+	plan.Steps = []MovementStepsModel{testLinearStep("forward", 2)}
+
+	resp := testUpdateResource(t, NewMovementResource(), &clients.Client{HttpClient: doer}, state, plan)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error diagnostics, got none")
+	}
+
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Insufficient Battery" {
+		t.Errorf("expected Insufficient Battery error, got: %s", summary)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Errorf("expected only the battery to be checked, got %d requests", len(doer.requests))
+	}
+}
+
+func TestMovementResource_Create_validationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"invalid movement plan","status":422,"fields":{` +
+			`"steps[0].distance":"must be at most 50","name":"is reserved","priority":"is not supported"}}`))
+	}))
+	defer server.Close()
+
+	plan := testMovementResourceModel()
+	plan.Id = types.StringUnknown()
+
+	resp := testCreateResource(t, NewMovementResource(), testClient(t, server), plan)
+
+	expected := []struct {
+		path   path.Path
+		detail string
+	}{
+		{path: path.Root("name"), detail: "The device rejected name of the movement plan: is reserved"},
+		{path: path.Empty(), detail: "The device rejected priority of the movement plan: is not supported"},
+		{path: path.Root("steps").AtListIndex(0).AtName("distance"), detail: "The device rejected steps[0].distance of the movement plan: must be at most 50"},
+	}
+
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d error diagnostics, got: %v", len(expected), resp.Diagnostics)
+	}
+
+	for i, expected := range expected {
+		if errs[i].Summary() != "Invalid Movement Plan" || errs[i].Detail() != expected.detail {
+			t.Errorf("expected error %q, got: %s: %s", expected.detail, errs[i].Summary(), errs[i].Detail())
+		}
+
+		errPath := path.Empty()
+		if withPath, ok := errs[i].(diag.DiagnosticWithPath); ok {
+			errPath = withPath.Path()
+		}
+
+		if !errPath.Equal(expected.path) {
+			t.Errorf("expected error %d on %s, got: %s", i, expected.path, errPath)
+		}
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state to be saved, got: %s", resp.State.Raw)
+	}
+}
+
+func TestMovementFieldPath(t *testing.T) {
+	testCases := map[string]struct {
+		expected path.Path
+		ok       bool
+	}{
+		"name":              {expected: path.Root("name"), ok: true},
+		"queue_mode":        {expected: path.Root("queue_mode"), ok: true},
+		"steps":             {},
+		"steps[2]":          {expected: path.Root("steps").AtListIndex(2), ok: true},
+		"steps[2].speed":    {expected: path.Root("steps").AtListIndex(2).AtName("speed"), ok: true},
+		"steps.2.angle":     {expected: path.Root("steps").AtListIndex(2).AtName("angle"), ok: true},
+		"steps[x].angle":    {},
+		"steps[-1].angle":   {},
+		"steps[0].heading":  {},
+		"steps[0].angle.x":  {},
+		"unknown_attribute": {},
+	}
+
+	for field, testCase := range testCases {
+		t.Run(field, func(t *testing.T) {
+			got, ok := movementFieldPath(field)
+
+			if ok != testCase.ok {
+				t.Fatalf("expected ok %t, got %t", testCase.ok, ok)
+			}
+
+			if ok && !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateMovementCapabilities(t *testing.T) {
+	capabilities := model.MovementCapabilitiesResponse{
+		Directions:  []string{"forward", "backward", "left", "right"},
+		MinDistance: 0.5,
+		MaxDistance: 20,
+		MinAngle:    0,
+		MaxAngle:    180,
+	}
+
+	testCases := map[string]struct {
+		capabilities model.MovementCapabilitiesResponse
+		step         MovementStepsModel
+		expectedPath path.Path
+	}{
+		"valid-linear": {
+			capabilities: capabilities,
+			step:         testLinearStep("forward", 10),
+		},
+		"valid-rotation": {
+			capabilities: capabilities,
+			step:         testRotationStep("left", 90),
+		},
+		"waypoint": {
+			capabilities: capabilities,
+			step:         testWaypointStep("dock"),
+		},
+		"unsupported-direction": {
+			capabilities: model.MovementCapabilitiesResponse{Directions: []string{"forward"}},
+			step:         testRotationStep("left", 90),
+			expectedPath: path.Root("steps").AtListIndex(0).AtName("direction"),
+		},
+		"distance-too-short": {
+			capabilities: capabilities,
+			step:         testLinearStep("forward", 0.1),
+			expectedPath: path.Root("steps").AtListIndex(0).AtName("distance"),
+		},
+		"distance-too-long": {
+			capabilities: capabilities,
+			step:         testLinearStep("backward", 25),
+			expectedPath: path.Root("steps").AtListIndex(0).AtName("distance"),
+		},
+		"angle-too-large": {
+			capabilities: capabilities,
+			step:         testRotationStep("right", 270),
+			expectedPath: path.Root("steps").AtListIndex(0).AtName("angle"),
+		},
+		"unreported-limits": {
+			capabilities: model.MovementCapabilitiesResponse{},
+			step:         testLinearStep("sideways", 100),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateMovementCapabilities([]MovementStepsModel{testCase.step}, testCase.capabilities)
+
+			if expectError := len(testCase.expectedPath.Steps()) > 0; diags.HasError() != expectError {
+				t.Fatalf("expected error: %t, got: %v", expectError, diags)
+			}
+
+			for _, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(testCase.expectedPath) {
+					t.Errorf("expected error at %s, got: %v", testCase.expectedPath, d)
+				}
+			}
+		})
+	}
+}
+
+func TestMovementResource_ModifyPlan_totalDistance(t *testing.T) {
+	testCases := map[string]struct {
+		steps       []MovementStepsModel
+		expectError bool
+	}{
+		"at-limit": {
+			steps: slices.Repeat([]MovementStepsModel{testLinearStep("forward", 100)}, 10),
+		},
+		"over-limit": {
+			steps:       append(slices.Repeat([]MovementStepsModel{testLinearStep("forward", 100)}, 10), testLinearStep("forward", 1)),
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testMovementResourceModel()
+			config.Steps = testCase.steps
+
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Moving = types.BoolUnknown()
+			plan.EstimatedDurationSeconds = types.Float64Unknown()
+			plan.Steps = testCase.steps
+
+			resp := testModifyPlanResource(t, &MovementResource{}, nil, config, plan)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				if errPath := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(path.Root("steps")) {
+					t.Errorf("expected error at steps, got %s", errPath)
+				}
+			}
+		})
+	}
+}
+
+func TestMovementResource_ModifyPlan_unknownCoordinateMode(t *testing.T) {
+	testCases := map[string]struct {
+		coordinateMode types.String
+		expected       types.Float64
+	}{
+		"relative": {
+			coordinateMode: types.StringValue("relative"),
+			expected:       types.Float64Value(8),
+		},
+		"unknown": {
+			coordinateMode: types.StringUnknown(),
+			expected:       types.Float64Unknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testMovementResourceModel()
+			config.CoordinateMode = testCase.coordinateMode
+			config.Waypoints = map[string]MovementWaypointModel{"dock": testWaypoint(3, 1)}
+			config.Steps = append(config.Steps, testWaypointStep("dock"))
+
+			// A concrete estimate, such as one carried over from state, must
+			// not be kept while the coordinate mode is unknown.
+			plan := testMovementResourceModel()
+			plan.Id = types.StringUnknown()
+			plan.Moving = types.BoolUnknown()
+			plan.EstimatedDurationSeconds = types.Float64Value(2)
+			plan.CoordinateMode = config.CoordinateMode
+			plan.Waypoints = config.Waypoints
+			plan.Steps = config.Steps
+
+			resp := testModifyPlanResource(t, &MovementResource{}, nil, config, plan)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got types.Float64
+			resp.Plan.GetAttribute(context.Background(), path.Root("estimated_duration_seconds"), &got)
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected estimated_duration_seconds %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
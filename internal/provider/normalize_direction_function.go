@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeDirectionFunction{}
+
+func NewNormalizeDirectionFunction() function.Function {
+	return &NormalizeDirectionFunction{}
+}
+
+// NormalizeDirectionFunction defines the function implementation.
+type NormalizeDirectionFunction struct{}
+
+func (f *NormalizeDirectionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_direction"
+}
+
+func (f *NormalizeDirectionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a movement direction to the value accepted by the movement resource.",
+		MarkdownDescription: "Normalizes a movement direction, such as `Forward` or `FWD`, to one of the `direction` values " +
+			"accepted by the `pathfinder_movement` resource: `forward`, `backward`, `left`, or `right`.\n\n" +
+			"The following synonyms are accepted, ignoring case and surrounding whitespace:\n\n" +
+			"- `forward`: `forwards`, `fwd`, `f`, `ahead`.\n" +
+			"- `backward`: `backwards`, `back`, `bwd`, `b`, `reverse`, `rev`.\n" +
+			"- `left`: `l`, `lt`.\n" +
+			"- `right`: `r`, `rt`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "direction",
+				MarkdownDescription: "Direction to normalize, for example `FWD`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeDirectionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var direction string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &direction))
+
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, ok := directionSynonyms[strings.ToLower(strings.TrimSpace(direction))]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unrecognized direction %q", direction))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
+
+// directionSynonyms maps lowercase synonyms of movement directions to the
+// directions accepted by the movement resource.
+var directionSynonyms = map[string]string{
+	"forward":   "forward",
+	"forwards":  "forward",
+	"fwd":       "forward",
+	"f":         "forward",
+	"ahead":     "forward",
+	"backward":  "backward",
+	"backwards": "backward",
+	"back":      "backward",
+	"bwd":       "backward",
+	"b":         "backward",
+	"reverse":   "backward",
+	"rev":       "backward",
+	"left":      "left",
+	"l":         "left",
+	"lt":        "left",
+	"right":     "right",
+	"r":         "right",
+	"rt":        "right",
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeDirectionFunction(t *testing.T) {
+	testCases := map[string]struct {
+		expected    string
+		expectError bool
+	}{
+		"forward":    {expected: "forward"},
+		"Forward":    {expected: "forward"},
+		"forwards":   {expected: "forward"},
+		"FWD":        {expected: "forward"},
+		"f":          {expected: "forward"},
+		"ahead":      {expected: "forward"},
+		" forward ":  {expected: "forward"},
+		"backward":   {expected: "backward"},
+		"Backwards":  {expected: "backward"},
+		"back":       {expected: "backward"},
+		"BWD":        {expected: "backward"},
+		"b":          {expected: "backward"},
+		"reverse":    {expected: "backward"},
+		"rev":        {expected: "backward"},
+		"left":       {expected: "left"},
+		"L":          {expected: "left"},
+		"lt":         {expected: "left"},
+		"RIGHT":      {expected: "right"},
+		"r":          {expected: "right"},
+		"rt":         {expected: "right"},
+		"":           {expectError: true},
+		"up":         {expectError: true},
+		"forward-ho": {expectError: true},
+	}
+
+	for direction, testCase := range testCases {
+		t.Run(direction, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewNormalizeDirectionFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(direction)}),
+			}, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := types.StringValue(testCase.expected)
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
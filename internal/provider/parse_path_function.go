@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParsePathFunction{}
+
+func NewParsePathFunction() function.Function {
+	return &ParsePathFunction{}
+}
+
+// ParsePathFunction defines the function implementation.
+type ParsePathFunction struct{}
+
+func (f *ParsePathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_path"
+}
+
+func (f *ParsePathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a compact path string into a list of movement steps.",
+		MarkdownDescription: "Parses a compact path string into a list of movement steps, which can be used to " +
+			"generate `steps` blocks of the `pathfinder_movement` resource with a `dynamic` block.\n\n" +
+			"A path is a list of steps separated by `;`. Each step is a direction letter followed by a number:\n\n" +
+			"- `F<distance>` moves forward by the distance in meters, e.g. `F1.5`.\n" +
+			"- `B<distance>` moves backward by the distance in meters, e.g. `B2`.\n" +
+			"- `L<angle>` rotates left by the angle in whole degrees, e.g. `L90`.\n" +
+			"- `R<angle>` rotates right by the angle in whole degrees, e.g. `R45`.\n\n" +
+			"Direction letters are case-insensitive and whitespace around steps is ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path string to parse, for example `F1.5;R90;F2`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: movementStepAttrTypes,
+			},
+		},
+	}
+}
+
+func (f *ParsePathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &path))
+
+	if resp.Error != nil {
+		return
+	}
+
+	steps, err := parsePath(path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, steps))
+}
+
+// pathDirections maps the direction letters of a path string to movement
+// step directions.
+var pathDirections = map[byte]string{
+	'F': "forward",
+	'B': "backward",
+	'L': "left",
+	'R': "right",
+}
+
+// parsePath parses a path string, such as "F1.5;R90;F2", into movement steps.
+func parsePath(path string) ([]MovementStepsModel, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("path must contain at least one step")
+	}
+
+	tokens := strings.Split(path, ";")
+	steps := make([]MovementStepsModel, 0, len(tokens))
+
+	for i, token := range tokens {
+		token = strings.TrimSpace(token)
+
+		if token == "" {
+			return nil, fmt.Errorf("step %d is empty", i+1)
+		}
+
+		direction, ok := pathDirections[strings.ToUpper(token[:1])[0]]
+		if !ok {
+			return nil, fmt.Errorf("step %d (%q) has an unknown direction %q, expected one of F, B, L, or R", i+1, token, token[:1])
+		}
+
+		value := token[1:]
+		if value == "" {
+			return nil, fmt.Errorf("step %d (%q) is missing a value", i+1, token)
+		}
+
+		step := MovementStepsModel{
+			Direction: types.StringValue(direction),
+			Distance:  types.Float64Null(),
+			Speed:     types.Float64Null(),
+			Waypoint:  types.StringNull(),
+		}
+
+		if validators.IsLinearDirection(direction) {
+			distance, err := strconv.ParseFloat(value, 64)
+			if err != nil || distance <= 0 {
+				return nil, fmt.Errorf("step %d (%q) has an invalid distance %q, expected a positive number", i+1, token, value)
+			}
+
+			step.Angle = types.Int64Value(0)
+			step.Distance = types.Float64Value(distance)
+		} else {
+			angle, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || angle < 0 {
+				return nil, fmt.Errorf("step %d (%q) has an invalid angle %q, expected a positive whole number of degrees", i+1, token, value)
+			}
+
+			step.Angle = types.Int64Value(angle)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParsePathFunction(t *testing.T) {
+	testCases := map[string]struct {
+		path        string
+		expected    []MovementStepsModel
+		expectError bool
+	}{
+		"single-forward": {
+			path: "F1.5",
+			expected: []MovementStepsModel{
+				testLinearStep("forward", 1.5),
+			},
+		},
+		"mixed": {
+			path: "F1.5;R90;F2",
+			expected: []MovementStepsModel{
+				testLinearStep("forward", 1.5),
+				testRotationStep("right", 90),
+				testLinearStep("forward", 2),
+			},
+		},
+		"lowercase-and-whitespace": {
+			path: " b3 ; l45 ",
+			expected: []MovementStepsModel{
+				testLinearStep("backward", 3),
+				testRotationStep("left", 45),
+			},
+		},
+		"empty":              {path: "", expectError: true},
+		"trailing-separator": {path: "F1;", expectError: true},
+		"unknown-direction":  {path: "X1", expectError: true},
+		"missing-value":      {path: "F", expectError: true},
+		"invalid-distance":   {path: "Fabc", expectError: true},
+		"negative-distance":  {path: "F-1", expectError: true},
+		"fractional-angle":   {path: "R4.5", expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.ObjectType{AttrTypes: movementStepAttrTypes})),
+			}
+
+			NewParsePathFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.path)}),
+			}, resp)
+
+			if testCase.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: movementStepAttrTypes}, testCase.expected)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func testLinearStep(direction string, distance float64) MovementStepsModel {
+	return MovementStepsModel{
+		Angle:     types.Int64Value(0),
+		Direction: types.StringValue(direction),
+		Distance:  types.Float64Value(distance),
+		Speed:     types.Float64Null(),
+		Waypoint:  types.StringNull(),
+	}
+}
+
+func testRotationStep(direction string, angle int64) MovementStepsModel {
+	return MovementStepsModel{
+		Angle:     types.Int64Value(angle),
+		Direction: types.StringValue(direction),
+		Distance:  types.Float64Null(),
+		Speed:     types.Float64Null(),
+		Waypoint:  types.StringNull(),
+	}
+}
+
+func testWaypointStep(waypoint string) MovementStepsModel {
+	return MovementStepsModel{
+		Angle:     types.Int64Null(),
+		Direction: types.StringNull(),
+		Distance:  types.Float64Null(),
+		Speed:     types.Float64Null(),
+		Waypoint:  types.StringValue(waypoint),
+	}
+}
+
+func testWaypoint(x, y float64) MovementWaypointModel {
+	return MovementWaypointModel{
+		X: types.Float64Value(x),
+		Y: types.Float64Value(y),
+	}
+}
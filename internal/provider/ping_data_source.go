@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PingDataSource{}
+
+func NewPingDataSource() datasource.DataSource {
+	return &PingDataSource{}
+}
+
+// PingDataSource defines the data source implementation.
+type PingDataSource struct {
+	client *clients.Client
+}
+
+// PingDataSourceModel describes the data source data model.
+type PingDataSourceModel struct {
+	Timeout   types.String  `tfsdk:"timeout"`
+	LatencyMs types.Float64 `tfsdk:"latency_ms"`
+	Ready     types.Bool    `tfsdk:"ready"`
+}
+
+// defaultPingTimeout is the maximum time to wait for the device to respond
+// when no timeout is configured.
+const defaultPingTimeout = 5 * time.Second
+
+func (d *PingDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+func (d *PingDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Measure the latency of the device, by timing a single readiness check. " +
+			"Returns an error if the device does not respond within `timeout`.",
+
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for the device to respond, such as `2s`. Defaults to `5s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"latency_ms": schema.Float64Attribute{
+				MarkdownDescription: "Round-trip time of the readiness check in milliseconds, including reading the response.",
+				Computed:            true,
+			},
+			"ready": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the device and service reported that they are ready for use.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *PingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PingDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPingTimeout
+	if !data.Timeout.IsNull() {
+		timeout, _ = time.ParseDuration(data.Timeout.ValueString())
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The readiness check is not retried, so that the latency is that of a
+	// single round trip.
+	var readResp model.ReadyzResponse
+	start := time.Now()
+	err := d.client.GetJSON(pingCtx, "/v1/readyz", &readResp)
+	latency := time.Since(start)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Device Unreachable",
+			fmt.Sprintf("The readiness check of the device failed, or the device did not respond within %s.\n\n", timeout)+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.LatencyMs = types.Float64Value(float64(latency.Microseconds()) / 1000)
+	data.Ready = types.BoolValue(readResp.Ready)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
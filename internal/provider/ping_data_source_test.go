@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPingDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		timeout     types.String
+		delay       time.Duration
+		expectError bool
+	}{
+		"responds": {
+			timeout: types.StringNull(),
+			delay:   20 * time.Millisecond,
+		},
+		"timeout": {
+			timeout:     types.StringValue("10ms"),
+			delay:       time.Second,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/readyz" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				select {
+				case <-time.After(testCase.delay):
+				case <-r.Context().Done():
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"ready":true}`))
+			}))
+			defer server.Close()
+
+			resp := testReadDataSource(t, NewPingDataSource(), testClient(t, server), &PingDataSourceModel{
+				Timeout: testCase.timeout,
+			})
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Device Unreachable" {
+					t.Errorf("expected Device Unreachable error, got %s", summary)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data PingDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Ready.ValueBool() {
+				t.Error("expected ready to be true")
+			}
+
+			if minLatency := float64(testCase.delay.Milliseconds()); data.LatencyMs.ValueFloat64() < minLatency {
+				t.Errorf("expected latency of at least %gms, got %gms", minLatency, data.LatencyMs.ValueFloat64())
+			}
+		})
+	}
+}
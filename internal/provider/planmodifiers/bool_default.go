@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package planmodifiers contains the plan modifiers shared by the resources of
+// the provider.
+package planmodifiers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ planmodifier.Bool = boolDefaultModifier{}
+
+// BoolDefault returns a plan modifier that plans value when the attribute is
+// not set in the configuration. Unlike a schema default, the value can be
+// chosen once the provider is configured, such as the provider
+// default_persist value, by applying the modifier with ModifyBool.
+func BoolDefault(value bool) planmodifier.Bool {
+	return boolDefaultModifier{value: value}
+}
+
+type boolDefaultModifier struct {
+	value bool
+}
+
+func (m boolDefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("defaults to %t when not configured", m.value)
+}
+
+func (m boolDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("defaults to `%t` when not configured", m.value)
+}
+
+func (m boolDefaultModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.PlanValue = types.BoolValue(m.value)
+}
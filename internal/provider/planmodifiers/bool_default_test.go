@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBoolDefault(t *testing.T) {
+	testCases := map[string]struct {
+		value        bool
+		configValue  types.Bool
+		planValue    types.Bool
+		expectedPlan types.Bool
+	}{
+		"not configured": {
+			value:        false,
+			configValue:  types.BoolNull(),
+			planValue:    types.BoolValue(true),
+			expectedPlan: types.BoolValue(false),
+		},
+		"not configured unknown": {
+			value:        true,
+			configValue:  types.BoolNull(),
+			planValue:    types.BoolUnknown(),
+			expectedPlan: types.BoolValue(true),
+		},
+		"configured": {
+			value:        false,
+			configValue:  types.BoolValue(true),
+			planValue:    types.BoolValue(true),
+			expectedPlan: types.BoolValue(true),
+		},
+		"configured unknown": {
+			value:        false,
+			configValue:  types.BoolUnknown(),
+			planValue:    types.BoolUnknown(),
+			expectedPlan: types.BoolUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &planmodifier.BoolResponse{PlanValue: testCase.planValue}
+			BoolDefault(testCase.value).PlanModifyBool(context.Background(), planmodifier.BoolRequest{
+				ConfigValue: testCase.configValue,
+				PlanValue:   testCase.planValue,
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.PlanValue.Equal(testCase.expectedPlan) {
+				t.Errorf("expected plan %s, got %s", testCase.expectedPlan, resp.PlanValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ModifyBool applies the plan modifier to the attribute at p, updating the
+// plan of resp and marking the attribute as requiring replacement when the
+// modifier asks for it. It is called from the ModifyPlan method of a resource,
+// for modifiers that depend on the configured provider, as the plan modifiers
+// of a schema are created before the provider is configured.
+//
+// Nothing is modified when the resource is being destroyed.
+func ModifyBool(ctx context.Context, m planmodifier.Bool, p path.Path, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if req.Plan.Raw.IsNull() {
+		return diags
+	}
+
+	modifyReq := planmodifier.BoolRequest{
+		Path:           p,
+		PathExpression: p.Expression(),
+		Config:         req.Config,
+		Plan:           resp.Plan,
+		State:          req.State,
+		StateValue:     types.BoolNull(),
+	}
+
+	diags.Append(req.Config.GetAttribute(ctx, p, &modifyReq.ConfigValue)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, p, &modifyReq.PlanValue)...)
+
+	// The prior state is null when the resource is being created.
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, p, &modifyReq.StateValue)...)
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	modifyResp := &planmodifier.BoolResponse{
+		PlanValue: modifyReq.PlanValue,
+	}
+	m.PlanModifyBool(ctx, modifyReq, modifyResp)
+	diags.Append(modifyResp.Diagnostics...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if modifyResp.RequiresReplace {
+		resp.RequiresReplace.Append(p)
+	}
+
+	diags.Append(resp.Plan.SetAttribute(ctx, p, modifyResp.PlanValue)...)
+
+	return diags
+}
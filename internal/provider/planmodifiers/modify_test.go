@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRequiresReplaceBool is a plan modifier that always requires the
+// resource to be replaced.
+type testRequiresReplaceBool struct{}
+
+func (m testRequiresReplaceBool) Description(ctx context.Context) string         { return "" }
+func (m testRequiresReplaceBool) MarkdownDescription(ctx context.Context) string { return "" }
+
+func (m testRequiresReplaceBool) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	resp.RequiresReplace = true
+}
+
+func TestModifyBool(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"persist": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+
+	objectType := testSchema.Type().TerraformType(context.Background())
+	object := func(persist *bool) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"persist": tftypes.NewValue(tftypes.Bool, persist),
+		})
+	}
+
+	testTrue, testFalse := true, false
+
+	testCases := map[string]struct {
+		modifier        planmodifier.Bool
+		config          tftypes.Value
+		plan            tftypes.Value
+		state           tftypes.Value
+		expectedPlan    types.Bool
+		expectedReplace bool
+	}{
+		"create": {
+			modifier:     BoolDefault(false),
+			config:       object(nil),
+			plan:         object(&testTrue),
+			state:        tftypes.NewValue(objectType, nil),
+			expectedPlan: types.BoolValue(false),
+		},
+		"update": {
+			modifier:     BoolDefault(false),
+			config:       object(nil),
+			plan:         object(&testTrue),
+			state:        object(&testTrue),
+			expectedPlan: types.BoolValue(false),
+		},
+		"configured": {
+			modifier:     BoolDefault(false),
+			config:       object(&testTrue),
+			plan:         object(&testTrue),
+			state:        object(&testFalse),
+			expectedPlan: types.BoolValue(true),
+		},
+		"requires replace": {
+			modifier:        testRequiresReplaceBool{},
+			config:          object(&testTrue),
+			plan:            object(&testTrue),
+			state:           object(&testFalse),
+			expectedPlan:    types.BoolValue(true),
+			expectedReplace: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: testSchema, Raw: testCase.config},
+				Plan:   tfsdk.Plan{Schema: testSchema, Raw: testCase.plan},
+				State:  tfsdk.State{Schema: testSchema, Raw: testCase.state},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			diags := ModifyBool(ctx, testCase.modifier, path.Root("persist"), req, resp)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var persist types.Bool
+			resp.Plan.GetAttribute(ctx, path.Root("persist"), &persist)
+
+			if !persist.Equal(testCase.expectedPlan) {
+				t.Errorf("expected plan %s, got %s", testCase.expectedPlan, persist)
+			}
+
+			if replace := resp.RequiresReplace.Contains(path.Root("persist")); replace != testCase.expectedReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.expectedReplace, replace)
+			}
+		})
+	}
+}
+
+func TestModifyBool_destroy(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"persist": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+	}
+
+	objectType := testSchema.Type().TerraformType(context.Background())
+
+	req := resource.ModifyPlanRequest{
+		Plan: tfsdk.Plan{Schema: testSchema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	diags := ModifyBool(context.Background(), BoolDefault(true), path.Root("persist"), req, resp)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !resp.Plan.Raw.IsNull() {
+		t.Errorf("expected plan to remain null, got %s", resp.Plan.Raw)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var _ planmodifier.String = requiresReplaceIfRenamedModifier{}
+
+// RequiresReplaceIfRenamed returns a plan modifier for the name of a resource
+// that the device identifies by its name, such as a movement plan. Changing
+// the name requires the resource to be replaced, as the device would
+// otherwise keep the resource under its previous name.
+func RequiresReplaceIfRenamed() planmodifier.String {
+	return requiresReplaceIfRenamedModifier{}
+}
+
+type requiresReplaceIfRenamedModifier struct{}
+
+func (m requiresReplaceIfRenamedModifier) Description(ctx context.Context) string {
+	return "the device identifies the resource by its name, so changing the name replaces the resource"
+}
+
+func (m requiresReplaceIfRenamedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m requiresReplaceIfRenamedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace when the resource is being created.
+	if req.StateValue.IsNull() {
+		return
+	}
+
+	// A name that is only known once applied may differ from the name in
+	// state.
+	if req.PlanValue.IsUnknown() || !req.PlanValue.Equal(req.StateValue) {
+		resp.RequiresReplace = true
+	}
+}
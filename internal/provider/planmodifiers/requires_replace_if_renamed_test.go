@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequiresReplaceIfRenamed(t *testing.T) {
+	testCases := map[string]struct {
+		stateValue      types.String
+		planValue       types.String
+		expectedReplace bool
+	}{
+		"create": {
+			stateValue: types.StringNull(),
+			planValue:  types.StringValue("example"),
+		},
+		"unchanged": {
+			stateValue: types.StringValue("example"),
+			planValue:  types.StringValue("example"),
+		},
+		"renamed": {
+			stateValue:      types.StringValue("example"),
+			planValue:       types.StringValue("renamed"),
+			expectedReplace: true,
+		},
+		"unknown": {
+			stateValue:      types.StringValue("example"),
+			planValue:       types.StringUnknown(),
+			expectedReplace: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &planmodifier.StringResponse{PlanValue: testCase.planValue}
+			RequiresReplaceIfRenamed().PlanModifyString(context.Background(), planmodifier.StringRequest{
+				ConfigValue: testCase.planValue,
+				PlanValue:   testCase.planValue,
+				StateValue:  testCase.stateValue,
+			}, resp)
+
+			if resp.RequiresReplace != testCase.expectedReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.expectedReplace, resp.RequiresReplace)
+			}
+
+			if !resp.PlanValue.Equal(testCase.planValue) {
+				t.Errorf("expected plan %s to be unchanged, got %s", testCase.planValue, resp.PlanValue)
+			}
+		})
+	}
+}
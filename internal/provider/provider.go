@@ -6,13 +6,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +37,8 @@ import (
 // Ensure PathfinderProvider satisfies various provider interfaces.
 var _ provider.Provider = &PathfinderProvider{}
 var _ provider.ProviderWithFunctions = &PathfinderProvider{}
+var _ provider.ProviderWithValidateConfig = &PathfinderProvider{}
+var _ io.Closer = &PathfinderProvider{}
 
 type ProviderFrameworkConfiguration struct {
 	Client *clients.Client
@@ -31,12 +50,42 @@ type PathfinderProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// configured contains the clients created by Configure, which are
+	// closed by Close, guarded by mu.
+	mu         sync.Mutex
+	configured []*clients.Client
 }
 
 // PathfinderProviderModel describes the provider data model.
 type PathfinderProviderModel struct {
-	Address types.String `tfsdk:"address"`
-	ApiKey  types.String `tfsdk:"api_key"`
+	Address                 types.String  `tfsdk:"address"`
+	ApiKey                  types.String  `tfsdk:"api_key"`
+	AuthToken               types.String  `tfsdk:"auth_token"`
+	AuthScheme              types.String  `tfsdk:"auth_scheme"`
+	Prewarm                 types.Bool    `tfsdk:"prewarm"`
+	CacheCapabilities       types.Bool    `tfsdk:"cache_capabilities"`
+	AllowInsecureHttp       types.Bool    `tfsdk:"allow_insecure_http"`
+	PollInterval            types.String  `tfsdk:"poll_interval"`
+	MaxResponseBytes        types.Int64   `tfsdk:"max_response_bytes"`
+	ReadOnly                types.Bool    `tfsdk:"read_only"`
+	RecordOnly              types.Bool    `tfsdk:"record_only"`
+	DefaultPersist          types.Bool    `tfsdk:"default_persist"`
+	SafeMode                types.Bool    `tfsdk:"safe_mode"`
+	StrictDecode            types.Bool    `tfsdk:"strict_decode"`
+	MethodOverride          types.Bool    `tfsdk:"method_override"`
+	DebugHttpBody           types.Bool    `tfsdk:"debug_http_body"`
+	MaxConcurrentRequests   types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond       types.Float64 `tfsdk:"requests_per_second"`
+	RetryOnStatus           types.List    `tfsdk:"retry_on_status"`
+	CircuitBreakerThreshold types.Int64   `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String  `tfsdk:"circuit_breaker_cooldown"`
+	HttpProxy               types.String  `tfsdk:"http_proxy"`
+	HttpsProxy              types.String  `tfsdk:"https_proxy"`
+	MovementPath            types.String  `tfsdk:"movement_path"`
+	MetricsEndpoint         types.String  `tfsdk:"metrics_endpoint"`
+	ExtraHeaders            types.Map     `tfsdk:"extra_headers"`
+	SensitiveHeaderKeys     types.List    `tfsdk:"sensitive_header_keys"`
 }
 
 func (p *PathfinderProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -52,13 +101,226 @@ func (p *PathfinderProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Required:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "API key used to authenticate to the Pathfinder API.",
+				MarkdownDescription: "API key used to authenticate to the Pathfinder API, sent in the `x-api-key` header.",
 				Optional:            true,
 			},
+			"auth_token": schema.StringAttribute{
+				MarkdownDescription: "Token used to authenticate to the Pathfinder API, sent in the `Authorization` header " +
+					"as a bearer token. Conflicts with `api_key`.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+				},
+			},
+			"auth_scheme": schema.StringAttribute{
+				MarkdownDescription: "How requests are authenticated, either `api_key` to send `api_key` in the `x-api-key` header, " +
+					"or `bearer` to send `auth_token` in the `Authorization` header. " +
+					"Defaults to `bearer` when `auth_token` is set, and `api_key` otherwise.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.AuthSchemeAPIKey, clients.AuthSchemeBearer),
+				},
+			},
+			"prewarm": schema.BoolAttribute{
+				MarkdownDescription: "Open a connection to the Pathfinder API while configuring the provider, " +
+					"so that subsequent requests reuse a warm connection. Defaults to `false`.",
+				Optional: true,
+			},
+			"allow_insecure_http": schema.BoolAttribute{
+				MarkdownDescription: "Allow the `address` to use `http://` for hosts other than loopback addresses without a warning. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Interval between requests while waiting for the device to reach a state, such as `2s`. " +
+					"Can be overridden by resources and data sources that wait. Defaults to `2s`.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Prevent any changes to the device. Resources fail to create, update, or delete with an error " +
+					"instead of sending requests to the device, while data sources work normally. Defaults to `false`.",
+				Optional: true,
+			},
+			"record_only": schema.BoolAttribute{
+				MarkdownDescription: "Record the requests that would be sent to the Pathfinder API in the logs, at the `INFO` level, " +
+					"instead of sending them. Every request succeeds with an empty response, so resources and data sources report " +
+					"empty values. For trying out configurations, such as in CI, without a device. Defaults to `false`.",
+				Optional: true,
+			},
+			"safe_mode": schema.BoolAttribute{
+				MarkdownDescription: fmt.Sprintf("Clamp the distance of every movement step to %g meters and its speed to %g meters per second "+
+					"before it is sent, regardless of the configuration of the movement resources, with a warning for every step "+
+					"that is clamped. Steps that do not set a speed are sent at %g meters per second. A safety governor for shared "+
+					"and test devices. Defaults to `false`.", safeModeMaxStepDistance, safeModeMaxStepSpeed, safeModeMaxStepSpeed),
+				Optional: true,
+			},
+			"default_persist": schema.BoolAttribute{
+				MarkdownDescription: "Default value of `persist` for `pathfinder_movement` resources that do not set it. " +
+					"Defaults to `true`.",
+				Optional: true,
+			},
+			"strict_decode": schema.BoolAttribute{
+				MarkdownDescription: "Fail with an error when a response from the Pathfinder API contains fields the provider does not support, " +
+					"such as when the device runs a newer firmware version than the provider was built for. Defaults to `false`.",
+				Optional: true,
+			},
+			"method_override": schema.BoolAttribute{
+				MarkdownDescription: "Send every request as a `POST` request, with the `X-HTTP-Method-Override` header set to the actual method, " +
+					"for proxies that block methods such as `PUT` and `DELETE`. The device must support the header. Defaults to `false`.",
+				Optional: true,
+			},
+			"debug_http_body": schema.BoolAttribute{
+				MarkdownDescription: "Indent the JSON bodies of requests in logs, such as movement plans, for readability " +
+					"when reading `TF_LOG` output. Sensitive fields are redacted either way, and the bodies sent to the device are not changed. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"cache_capabilities": schema.BoolAttribute{
+				MarkdownDescription: "Read the movement capabilities of the device once when the provider is configured, and reuse them " +
+					"for the `pathfinder_movement_capabilities` data source and for validating the steps of `pathfinder_movement` resources " +
+					"during plan, rather than reading them again each time. Set `refresh` on the data source to read them again. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used for requests to an `http://` address, such as `http://proxy.example.com:3128`. " +
+					"Defaults to the proxy configured by the `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.ProxyURL(),
+				},
+			},
+			"https_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used for requests to an `https://` address, such as `http://proxy.example.com:3128`. " +
+					"Defaults to the proxy configured by the `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.ProxyURL(),
+				},
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers added to every request to the Pathfinder API, such as the authentication headers of a gateway " +
+					"in front of the device. Headers set by the provider, such as `x-api-key`, take precedence.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"sensitive_header_keys": schema.ListAttribute{
+				MarkdownDescription: "Names of request headers whose values are redacted in logs, in addition to `x-api-key`, " +
+					"`Authorization`, and `Proxy-Authorization`, such as custom authentication headers set in `extra_headers`. " +
+					"Names are case-insensitive.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the Pathfinder API at once, " +
+					"further requests wait for an earlier request to complete. Not limited by default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the Pathfinder API per second, such as `5`, for gateways " +
+					"in front of the device that limit the rate of requests. Further requests wait until they can be sent " +
+					"without exceeding it. Set to `0` to disable. Not limited by default.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"retry_on_status": schema.ListAttribute{
+				MarkdownDescription: "Status codes of responses from the Pathfinder API that are retried, in addition to connection errors, " +
+					"such as `[429]` to only retry rate limited requests. Must be between `400` and `599`. Set to `[]` to only retry " +
+					"connection errors. Defaults to `[429, 502, 503, 504]`.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed requests, such as connection errors or `503 Service Unavailable` responses, " +
+					"after which the device is considered down and further requests fail immediately for `circuit_breaker_cooldown`, " +
+					"rather than every resource and data source retrying. Set to `0` to disable. Defaults to `5`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "Time requests fail immediately once the device is considered down, such as `30s`. Defaults to `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"movement_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the movement plan endpoint of the Pathfinder API, used by the `pathfinder_movement` " +
+					"and `pathfinder_movement_batch` resources, for devices running customized firmware. Must start with `/`. " +
+					"Defaults to `/v1/movement-plan`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must start with /"),
+				},
+			},
+			"metrics_endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of a Prometheus Pushgateway, such as `http://pushgateway.example.com:9091`, to push metrics " +
+					"of the requests sent to the Pathfinder API to in the background after requests. The metrics are " +
+					"`pathfinder_requests_total`, `pathfinder_request_errors_total`, and `pathfinder_request_duration_seconds`, " +
+					"labeled with the HTTP `method` of the requests, and are pushed with the job `terraform-provider-pathfinder` " +
+					"and the `address` as the `instance`. " +
+					"Metrics are not recorded when omitted.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http:// or https:// URL"),
+				},
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size of a response from the Pathfinder API in bytes. " +
+					"Larger responses fail with an error rather than being read into memory. Defaults to `10485760` (10 MiB).",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
 
+// ValidateConfig warns when the address uses plain HTTP to a remote host
+// without any credentials, as anyone on the network can then send requests to
+// the device. Nothing is checked while the values are unknown.
+func (p *PathfinderProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var address, apiKey, authToken types.String
+	var allowInsecureHttp types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("address"), &address)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_key"), &apiKey)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_token"), &authToken)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_insecure_http"), &allowInsecureHttp)...)
+
+	if resp.Diagnostics.HasError() || address.IsUnknown() || apiKey.IsUnknown() || authToken.IsUnknown() || allowInsecureHttp.IsUnknown() {
+		return
+	}
+
+	if allowInsecureHttp.ValueBool() || !apiKey.IsNull() || !authToken.IsNull() || !isInsecureRemoteAddress(address.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("address"),
+		"Unauthenticated Insecure Pathfinder API Address",
+		fmt.Sprintf("The address %q uses plain HTTP to a host that is not a loopback address, and neither api_key nor "+
+			"auth_token is set, so anyone on the network can send requests to the device. Use an https:// address "+
+			"and set api_key or auth_token, or set allow_insecure_http to true to silence this warning.", address.ValueString()),
+	)
+}
+
 func (p *PathfinderProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var providerConfig PathfinderProviderModel
 
@@ -69,18 +331,135 @@ func (p *PathfinderProvider) Configure(ctx context.Context, req provider.Configu
 		return // Exit early if there are any configuration errors
 	}
 
+	// An address that is not known yet, such as the output of another
+	// resource, would otherwise be reported as empty.
+	if providerConfig.Address.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("address"),
+			"Unknown Pathfinder API Address",
+			"The provider cannot create the Pathfinder API client as there is an unknown configuration value for the Pathfinder API address. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+
+		return
+	}
+
 	// Prepare client configuration
 	cfg := clients.ClientConfig{
-		Address: providerConfig.Address.ValueString(),
-		ApiKey:  providerConfig.ApiKey.ValueString(),
+		Address:           providerConfig.Address.ValueString(),
+		ApiKey:            providerConfig.ApiKey.ValueString(),
+		AuthScheme:        providerConfig.AuthScheme.ValueString(),
+		AuthToken:         providerConfig.AuthToken.ValueString(),
+		ReadOnly:          providerConfig.ReadOnly.ValueBool(),
+		RecordOnly:        providerConfig.RecordOnly.ValueBool(),
+		SafeMode:          providerConfig.SafeMode.ValueBool(),
+		StrictDecode:      providerConfig.StrictDecode.ValueBool(),
+		MethodOverride:    providerConfig.MethodOverride.ValueBool(),
+		DebugHTTPBody:     providerConfig.DebugHttpBody.ValueBool(),
+		CacheCapabilities: providerConfig.CacheCapabilities.ValueBool(),
+		HTTPProxy:         providerConfig.HttpProxy.ValueString(),
+		HTTPSProxy:        providerConfig.HttpsProxy.ValueString(),
+		MovementPath:      providerConfig.MovementPath.ValueString(),
+		MetricsEndpoint:   providerConfig.MetricsEndpoint.ValueString(),
+	}
+
+	resp.Diagnostics.Append(configureHeaders(ctx, &cfg, providerConfig.ExtraHeaders, providerConfig.SensitiveHeaderKeys)...)
+
+	if providerConfig.AuthScheme.IsNull() && !providerConfig.AuthToken.IsNull() {
+		cfg.AuthScheme = clients.AuthSchemeBearer
+	}
+
+	if cfg.AuthScheme == clients.AuthSchemeBearer && cfg.AuthToken == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_token"),
+			"Missing Auth Token",
+			"The auth_token attribute must be set when auth_scheme is \"bearer\".",
+		)
+	}
+
+	if cfg.AuthScheme == clients.AuthSchemeAPIKey && !providerConfig.AuthToken.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_scheme"),
+			"Conflicting Authentication Attributes",
+			"The auth_token attribute is only sent when auth_scheme is \"bearer\". "+
+				"Remove auth_token to authenticate with api_key, or set auth_scheme to \"bearer\".",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !providerConfig.PollInterval.IsNull() {
+		// The value has already been validated by the schema.
+		cfg.PollInterval, _ = time.ParseDuration(providerConfig.PollInterval.ValueString())
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Configuring Pathfinder provider using configuration: %v", cfg))
+	if !providerConfig.MaxResponseBytes.IsNull() {
+		cfg.MaxResponseBytes = providerConfig.MaxResponseBytes.ValueInt64()
+	}
+
+	if !providerConfig.MaxConcurrentRequests.IsNull() {
+		cfg.MaxConcurrentRequests = int(providerConfig.MaxConcurrentRequests.ValueInt64())
+	}
+
+	cfg.RequestsPerSecond = providerConfig.RequestsPerSecond.ValueFloat64()
+
+	// A null list uses the default status codes, while an empty list only
+	// retries connection errors. An unknown list also uses the defaults until
+	// it is known.
+	if !isFullyKnown(ctx, providerConfig.RetryOnStatus) {
+		tflog.Debug(ctx, "Retried status codes are unknown, retrying the default status codes until known")
+	} else if !providerConfig.RetryOnStatus.IsNull() {
+		var retryOnStatus []int64
+		resp.Diagnostics.Append(providerConfig.RetryOnStatus.ElementsAs(ctx, &retryOnStatus, false)...)
+
+		cfg.RetryOnStatus = make([]int, len(retryOnStatus))
+		for i, status := range retryOnStatus {
+			cfg.RetryOnStatus[i] = int(status)
+		}
+	}
+
+	if !providerConfig.CircuitBreakerThreshold.IsNull() {
+		cfg.CircuitBreakerThreshold = int(providerConfig.CircuitBreakerThreshold.ValueInt64())
+
+		// Zero disables the circuit breaker, rather than using the default.
+		if cfg.CircuitBreakerThreshold == 0 {
+			cfg.CircuitBreakerThreshold = -1
+		}
+	}
+
+	if !providerConfig.CircuitBreakerCooldown.IsNull() {
+		// The value has already been validated by the schema.
+		cfg.CircuitBreakerCooldown, _ = time.ParseDuration(providerConfig.CircuitBreakerCooldown.ValueString())
+	}
+
+	if !providerConfig.DefaultPersist.IsNull() {
+		cfg.DefaultPersist = providerConfig.DefaultPersist.ValueBoolPointer()
+	}
+
+	// Addresses without credentials are warned about by ValidateConfig.
+	hasCredentials := cfg.ApiKey != "" || cfg.AuthToken != ""
+	if !providerConfig.AllowInsecureHttp.ValueBool() && hasCredentials && isInsecureRemoteAddress(cfg.Address) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("address"),
+			"Insecure Pathfinder API Address",
+			fmt.Sprintf("The address %q uses plain HTTP to a host that is not a loopback address, "+
+				"so requests and credentials are sent unencrypted. Use an https:// address, "+
+				"or set allow_insecure_http to true to silence this warning.", cfg.Address),
+		)
+	}
 
 	ctx = tflog.SetField(ctx, "address", cfg.Address)
 	ctx = tflog.SetField(ctx, "api_key", providerConfig.ApiKey.ValueString())
+	ctx = tflog.SetField(ctx, "auth_scheme", cfg.AuthScheme)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_key")
 
+	tflog.Debug(ctx, "Configuring Pathfinder provider", map[string]interface{}{
+		"poll_interval":      cfg.PollInterval.String(),
+		"max_response_bytes": cfg.MaxResponseBytes,
+	})
+
 	tflog.Debug(ctx, "Initializing Pathfinder API client")
 
 	// Initialize the API client
@@ -92,14 +471,99 @@ func (p *PathfinderProvider) Configure(ctx context.Context, req provider.Configu
 
 	tflog.Debug(ctx, "Successfully initialized Pathfinder API client")
 
+	p.mu.Lock()
+	p.configured = append(p.configured, client)
+	p.mu.Unlock()
+
+	if providerConfig.Prewarm.ValueBool() {
+		tflog.Debug(ctx, "Prewarming connection to Pathfinder API")
+
+		if err := client.Prewarm(ctx); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Prewarm Connection",
+				fmt.Sprintf("Unable to open a connection to the Pathfinder API, it will be established on the first request instead: %v", err),
+			)
+		}
+	}
+
+	if cfg.CacheCapabilities {
+		tflog.Debug(ctx, "Reading movement capabilities of the device")
+
+		if _, err := client.MovementCapabilities(ctx); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Read Movement Capabilities",
+				fmt.Sprintf("Unable to read the movement capabilities of the device, they will be read when first used instead: %v", err),
+			)
+		}
+	}
+
 	// Set the API client to be used by resources and data sources
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// Close closes the clients created by Configure, pushing the metrics counted
+// since their last push. It is called once the provider server stopped, as
+// the framework has no hook for the provider exiting.
+func (p *PathfinderProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, client := range p.configured {
+		client.Close()
+	}
+
+	p.configured = nil
+
+	return nil
+}
+
+// configureHeaders sets the extra headers of cfg and the headers redacted in
+// logs, which may not be known yet when the provider is configured during
+// plan, such as when they are the output of another resource.
+//
+// Unknown extra headers are not sent until they are known. While the
+// sensitive header keys are unknown, every extra header is redacted, so that
+// no value meant to be redacted is logged.
+func configureHeaders(ctx context.Context, cfg *clients.ClientConfig, extraHeaders types.Map, sensitiveHeaderKeys types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !isFullyKnown(ctx, extraHeaders) {
+		tflog.Debug(ctx, "Extra headers are unknown, they are not sent until known")
+	} else if !extraHeaders.IsNull() {
+		diags.Append(extraHeaders.ElementsAs(ctx, &cfg.Headers, false)...)
+	}
+
+	if !isFullyKnown(ctx, sensitiveHeaderKeys) {
+		for name := range cfg.Headers {
+			cfg.SensitiveHeaders = append(cfg.SensitiveHeaders, name)
+		}
+	} else if !sensitiveHeaderKeys.IsNull() {
+		diags.Append(sensitiveHeaderKeys.ElementsAs(ctx, &cfg.SensitiveHeaders, false)...)
+	}
+
+	return diags
+}
+
+// isFullyKnown reports whether the value and, for collections, each of its
+// elements are known.
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+
+	return err == nil && tfValue.IsFullyKnown()
+}
+
 func (p *PathfinderProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewMovementResource,
+		NewDeviceResetResource,
+		NewDeviceRebootResource,
+		NewDeviceFirmwareResource,
+		NewDeviceFeatureResource,
+		NewMovementBatchResource,
+		NewBroadcastMovementResource,
+		NewMovementPersistenceResource,
+		NewMovementLockResource,
 	}
 }
 
@@ -107,15 +571,59 @@ func (p *PathfinderProvider) DataSources(ctx context.Context) []func() datasourc
 	return []func() datasource.DataSource{
 		NewDeviceDataSource,
 		NewBatteryDataSource,
+		NewBatteryHistoryDataSource,
 		NewWifiNetworksDataSource,
 		NewHealthDataSource,
 		NewReadyDataSource,
 		NewMovementLockDataSource,
+		NewPingDataSource,
+		NewSystemSummaryDataSource,
+		NewDeviceStatusDataSource,
+		NewMovementPreviewDataSource,
+		NewDeviceIdentifiersDataSource,
+		NewMovementPlanNamesDataSource,
+		NewWifiReachableDataSource,
+		NewDevicePositionDataSource,
+		NewDeviceTimeDataSource,
+		NewCurrentMovementDataSource,
+		NewDeviceReadyForFeatureDataSource,
+		NewFeatureCatalogDataSource,
+		NewMovementCapabilitiesDataSource,
+		NewProviderInfoDataSource,
 	}
 }
 
 func (p *PathfinderProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewParsePathFunction,
+		NewRssiToBarsFunction,
+		NewNormalizeDirectionFunction,
+		NewDeviceUrlFunction,
+		NewValidatePlanFunction,
+		NewBearingFunction,
+		NewDistanceBetweenFunction,
+		NewEncodePlanFunction,
+		NewDecodePlanFunction,
+		NewClampPlanFunction,
+	}
+}
+
+// isInsecureRemoteAddress returns true if the address uses plain HTTP to a
+// host that is not a loopback address, such as localhost or 127.0.0.1.
+func isInsecureRemoteAddress(address string) bool {
+	u, err := url.Parse(address)
+	if err != nil || !strings.EqualFold(u.Scheme, "http") {
+		return false
+	}
+
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip == nil || !ip.IsLoopback()
 }
 
 func New(version string) func() provider.Provider {
@@ -125,3 +633,23 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// flattenTimestamp returns a timestamp reported by the device in RFC 3339
+// format, or null when the device did not report it.
+func flattenTimestamp(in model.Timestamp) types.String {
+	if in.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(in.Format(time.RFC3339Nano))
+}
+
+// addReadOnlyError adds the error diagnostic for a resource operation that is
+// blocked because the provider is configured to be read-only.
+func addReadOnlyError(diags *diag.Diagnostics, operation string) {
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Unable to %s the resource, as the provider is configured with read_only set to true. "+
+			"Set read_only to false to allow changes to the device.", operation),
+	)
+}
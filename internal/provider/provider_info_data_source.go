@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProviderInfoDataSource{}
+
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &ProviderInfoDataSource{}
+}
+
+// ProviderInfoDataSource defines the data source implementation.
+type ProviderInfoDataSource struct {
+	client *clients.Client
+}
+
+// ProviderInfoDataSourceModel describes the data source data model.
+type ProviderInfoDataSourceModel struct {
+	Address                 types.String `tfsdk:"address"`
+	AuthScheme              types.String `tfsdk:"auth_scheme"`
+	CredentialsConfigured   types.Bool   `tfsdk:"credentials_configured"`
+	RetryMax                types.Int64  `tfsdk:"retry_max"`
+	RetryWaitMin            types.String `tfsdk:"retry_wait_min"`
+	PollInterval            types.String `tfsdk:"poll_interval"`
+	MaxResponseBytes        types.Int64  `tfsdk:"max_response_bytes"`
+	MaxConcurrentRequests   types.Int64  `tfsdk:"max_concurrent_requests"`
+	ReadOnly                types.Bool   `tfsdk:"read_only"`
+	RecordOnly              types.Bool   `tfsdk:"record_only"`
+	SafeMode                types.Bool   `tfsdk:"safe_mode"`
+	MovementPath            types.String `tfsdk:"movement_path"`
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+	HttpProxy               types.String `tfsdk:"http_proxy"`
+	HttpsProxy              types.String `tfsdk:"https_proxy"`
+}
+
+func (d *ProviderInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+func (d *ProviderInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get the effective configuration of the provider, after defaults are applied, for troubleshooting. " +
+			"Credentials are never exposed, and passwords in URLs are masked. No requests are sent to the device.",
+
+		Attributes: map[string]schema.Attribute{
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Address of the Pathfinder API.",
+				Computed:            true,
+			},
+			"auth_scheme": schema.StringAttribute{
+				MarkdownDescription: "How requests are authenticated, either `api_key` or `bearer`.",
+				Computed:            true,
+			},
+			"credentials_configured": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the credentials of `auth_scheme`, `api_key` or `auth_token`, are configured.",
+				Computed:            true,
+			},
+			"retry_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request failing with a transient error is retried.",
+				Computed:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Wait before the first retry of a request, which doubles with every retry.",
+				Computed:            true,
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Interval between requests while waiting for the device to reach a state.",
+				Computed:            true,
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size of a response from the Pathfinder API in bytes.",
+				Computed:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent at once, or `0` when not limited.",
+				Computed:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the provider refuses changes to the device.",
+				Computed:            true,
+			},
+			"record_only": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the provider records requests in the logs instead of sending them.",
+				Computed:            true,
+			},
+			"safe_mode": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the provider clamps the distance and speed of movement steps.",
+				Computed:            true,
+			},
+			"movement_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the movement plan endpoint.",
+				Computed:            true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed requests after which the device is considered down, " +
+					"or `0` when the circuit breaker is disabled.",
+				Computed: true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "Time requests fail immediately once the device is considered down.",
+				Computed:            true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy configured for `http://` addresses. Null when the proxy is taken from the environment.",
+				Computed:            true,
+			},
+			"https_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy configured for `https://` addresses. Null when the proxy is taken from the environment.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ProviderInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *ProviderInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderInfoDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := d.client.Config
+
+	authScheme := config.AuthScheme
+	if authScheme == "" {
+		authScheme = clients.AuthSchemeAPIKey
+	}
+
+	credentials := config.ApiKey
+	if authScheme == clients.AuthSchemeBearer {
+		credentials = config.AuthToken
+	}
+
+	data.Address = types.StringValue(redactURL(config.Address))
+	data.AuthScheme = types.StringValue(authScheme)
+	data.CredentialsConfigured = types.BoolValue(credentials != "")
+	data.RetryMax = types.Int64Value(int64(max(config.RetryMax, 0)))
+	data.RetryWaitMin = types.StringValue(config.RetryWaitMin.String())
+	data.PollInterval = types.StringValue(config.PollInterval.String())
+	data.MaxResponseBytes = types.Int64Value(config.MaxResponseBytes)
+	data.MaxConcurrentRequests = types.Int64Value(int64(config.MaxConcurrentRequests))
+	data.ReadOnly = types.BoolValue(config.ReadOnly)
+	data.RecordOnly = types.BoolValue(config.RecordOnly)
+	data.SafeMode = types.BoolValue(config.SafeMode)
+	data.MovementPath = types.StringValue(d.client.MovementPath())
+	data.CircuitBreakerThreshold = types.Int64Value(int64(max(config.CircuitBreakerThreshold, 0)))
+	data.CircuitBreakerCooldown = types.StringValue(config.CircuitBreakerCooldown.String())
+	data.HttpProxy = redactedURLValue(config.HTTPProxy)
+	data.HttpsProxy = redactedURLValue(config.HTTPSProxy)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// redactURL returns the URL with the password of its user information
+// masked, or the URL unchanged if it cannot be parsed.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return u.Redacted()
+}
+
+// redactedURLValue returns the redacted URL, or null if it is empty.
+func redactedURLValue(rawURL string) types.String {
+	if rawURL == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(redactURL(rawURL))
+}
@@ -4,10 +4,31 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -26,3 +47,1093 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestIsInsecureRemoteAddress(t *testing.T) {
+	testCases := map[string]bool{
+		"http://localhost:8080":     false,
+		"http://LOCALHOST":          false,
+		"http://127.0.0.1:80":       false,
+		"http://[::1]:80":           false,
+		"https://192.168.4.1":       false,
+		"https://rover.example.com": false,
+		"http://192.168.4.1:80":     true,
+		"http://rover.example.com":  true,
+	}
+
+	for address, expected := range testCases {
+		t.Run(address, func(t *testing.T) {
+			if got := isInsecureRemoteAddress(address); got != expected {
+				t.Errorf("expected %t, got %t", expected, got)
+			}
+		})
+	}
+}
+
+func TestPathfinderProvider_ValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		config      PathfinderProviderModel
+		expectWarns bool
+	}{
+		"http remote without credentials": {
+			config:      PathfinderProviderModel{Address: types.StringValue("http://192.168.4.1:80")},
+			expectWarns: true,
+		},
+		"http remote with api_key": {
+			config: PathfinderProviderModel{Address: types.StringValue("http://192.168.4.1:80"), ApiKey: types.StringValue("key")},
+		},
+		"http remote with auth_token": {
+			config: PathfinderProviderModel{Address: types.StringValue("http://192.168.4.1:80"), AuthToken: types.StringValue("token")},
+		},
+		"http remote with unknown api_key": {
+			config: PathfinderProviderModel{Address: types.StringValue("http://192.168.4.1:80"), ApiKey: types.StringUnknown()},
+		},
+		"http remote with allow_insecure_http": {
+			config: PathfinderProviderModel{Address: types.StringValue("http://192.168.4.1:80"), AllowInsecureHttp: types.BoolValue(true)},
+		},
+		"http loopback": {
+			config: PathfinderProviderModel{Address: types.StringValue("http://localhost:8080")},
+		},
+		"https remote": {
+			config: PathfinderProviderModel{Address: types.StringValue("https://rover.example.com")},
+		},
+		"unknown address": {
+			config: PathfinderProviderModel{Address: types.StringUnknown()},
+		},
+		"null address": {},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			p := New("test")()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+			configState := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := configState.Set(ctx, testNullProviderCollections(&testCase.config)); diags.HasError() {
+				t.Fatalf("unexpected diagnostics building config: %v", diags)
+			}
+
+			resp := &provider.ValidateConfigResponse{}
+			p.(provider.ProviderWithValidateConfig).ValidateConfig(ctx, provider.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    configState.Raw,
+				},
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			if got := resp.Diagnostics.WarningsCount() > 0; got != testCase.expectWarns {
+				t.Errorf("expected warnings %t, got: %v", testCase.expectWarns, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPathfinderProvider_Configure_aliases(t *testing.T) {
+	// Each server reports a different battery value, so that reads reveal
+	// which device they were sent to.
+	servers := make([]*httptest.Server, 2)
+	requests := make([]int, len(servers))
+
+	for i := range servers {
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests[i]++
+			_, _ = fmt.Fprintf(w, `{"value":%d,"unit":"%%"}`, i)
+		}))
+		defer servers[i].Close()
+	}
+
+	// Aliased provider blocks are configured as separate provider instances,
+	// all of which are configured before any data source is read.
+	providerClients := make([]*clients.Client, len(servers))
+	for i, server := range servers {
+		providerClients[i] = testConfigureProvider(t, &PathfinderProviderModel{
+			Address: types.StringValue(server.URL),
+		})
+	}
+
+	for i, client := range providerClients {
+		resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var data BatteryDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+		if data.Value.ValueInt64() != int64(i) {
+			t.Errorf("expected battery value %d from server %d, got %s", i, i, data.Value)
+		}
+	}
+
+	for i, count := range requests {
+		if count != 1 {
+			t.Errorf("expected 1 request to server %d, got %d", i, count)
+		}
+	}
+}
+
+func TestPathfinderProvider_Configure_maxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"` + strings.Repeat("a", 1024) + `"}`))
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:          types.StringValue(server.URL),
+		MaxResponseBytes: types.Int64Value(512),
+	})
+
+	resp := testReadDataSource(t, NewDeviceStatusDataSource(), client, &DeviceStatusDataSourceModel{
+		Features:        types.MapNull(types.BoolType),
+		EnabledFeatures: types.ListNull(types.StringType),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected error diagnostics, got none")
+	}
+
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "exceeds the maximum size of 512 bytes") {
+		t.Errorf("expected response size error, got: %s", detail)
+	}
+}
+
+func TestPathfinderProvider_Configure_httpProxy(t *testing.T) {
+	var proxiedHosts []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHosts = append(proxiedHosts, r.Host)
+		_, _ = w.Write([]byte(`{"value":95,"unit":"%"}`))
+	}))
+	defer proxy.Close()
+
+	// The address does not resolve, so the request only succeeds through the
+	// proxy.
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:           types.StringValue("http://rover.invalid"),
+		AllowInsecureHttp: types.BoolValue(true),
+		HttpProxy:         types.StringValue(proxy.URL),
+	})
+
+	resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(proxiedHosts) != 1 || proxiedHosts[0] != "rover.invalid" {
+		t.Errorf("expected 1 request for rover.invalid through the proxy, got: %v", proxiedHosts)
+	}
+}
+
+func TestPathfinderProvider_Configure_unknownAddress(t *testing.T) {
+	resp := testConfigureProviderResponse(t, &PathfinderProviderModel{
+		Address: types.StringUnknown(),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error diagnostic, got: %v", resp.Diagnostics)
+	}
+
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unknown Pathfinder API Address" {
+		t.Errorf("expected summary %q, got %q", "Unknown Pathfinder API Address", summary)
+	}
+
+	if errPath := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path(); !errPath.Equal(path.Root("address")) {
+		t.Errorf("expected error at address, got %s", errPath)
+	}
+}
+
+func TestPathfinderProvider_Configure_unknownHeaders(t *testing.T) {
+	headers := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"X-Team-Token": types.StringValue("secret"),
+	})
+
+	testCases := map[string]struct {
+		extraHeaders        types.Map
+		sensitiveHeaderKeys types.List
+		expectedHeaders     map[string]string
+		expectedSensitive   []string
+	}{
+		"known": {
+			extraHeaders:        headers,
+			sensitiveHeaderKeys: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("X-Other")}),
+			expectedHeaders:     map[string]string{"X-Team-Token": "secret"},
+			expectedSensitive:   []string{"X-Other"},
+		},
+		"unknown extra headers": {
+			extraHeaders:        types.MapUnknown(types.StringType),
+			sensitiveHeaderKeys: types.ListNull(types.StringType),
+		},
+		"unknown extra header value": {
+			extraHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"X-Team-Token": types.StringUnknown(),
+			}),
+			sensitiveHeaderKeys: types.ListNull(types.StringType),
+		},
+		"unknown sensitive header keys": {
+			extraHeaders:        headers,
+			sensitiveHeaderKeys: types.ListUnknown(types.StringType),
+			expectedHeaders:     map[string]string{"X-Team-Token": "secret"},
+			expectedSensitive:   []string{"X-Team-Token"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := testConfigureProvider(t, &PathfinderProviderModel{
+				Address:             types.StringValue("http://localhost:8080"),
+				ExtraHeaders:        testCase.extraHeaders,
+				SensitiveHeaderKeys: testCase.sensitiveHeaderKeys,
+			})
+
+			if !maps.Equal(client.Config.Headers, testCase.expectedHeaders) {
+				t.Errorf("expected headers %v, got %v", testCase.expectedHeaders, client.Config.Headers)
+			}
+
+			if !slices.Equal(client.Config.SensitiveHeaders, testCase.expectedSensitive) {
+				t.Errorf("expected sensitive headers %v, got %v", testCase.expectedSensitive, client.Config.SensitiveHeaders)
+			}
+		})
+	}
+}
+
+func TestPathfinderProvider_Configure_authScheme(t *testing.T) {
+	testCases := map[string]struct {
+		config          PathfinderProviderModel
+		expectedHeaders map[string]string
+		expectError     string
+	}{
+		"api-key": {
+			config: PathfinderProviderModel{ApiKey: types.StringValue("key")},
+			expectedHeaders: map[string]string{
+				"X-Api-Key":     "key",
+				"Authorization": "",
+			},
+		},
+		"api-key-explicit": {
+			config: PathfinderProviderModel{ApiKey: types.StringValue("key"), AuthScheme: types.StringValue("api_key")},
+			expectedHeaders: map[string]string{
+				"X-Api-Key":     "key",
+				"Authorization": "",
+			},
+		},
+		"bearer": {
+			config: PathfinderProviderModel{AuthToken: types.StringValue("token"), AuthScheme: types.StringValue("bearer")},
+			expectedHeaders: map[string]string{
+				"X-Api-Key":     "",
+				"Authorization": "Bearer token",
+			},
+		},
+		"bearer-inferred": {
+			config: PathfinderProviderModel{AuthToken: types.StringValue("token")},
+			expectedHeaders: map[string]string{
+				"X-Api-Key":     "",
+				"Authorization": "Bearer token",
+			},
+		},
+		"bearer-missing-token": {
+			config:      PathfinderProviderModel{AuthScheme: types.StringValue("bearer")},
+			expectError: "Missing Auth Token",
+		},
+		"api-key-with-token": {
+			config:      PathfinderProviderModel{AuthToken: types.StringValue("token"), AuthScheme: types.StringValue("api_key")},
+			expectError: "Conflicting Authentication Attributes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var headers http.Header
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+				_, _ = w.Write([]byte(`{"value":95,"unit":"%"}`))
+			}))
+			defer server.Close()
+
+			config := testCase.config
+			config.Address = types.StringValue(server.URL)
+
+			if testCase.expectError != "" {
+				resp := testConfigureProviderResponse(t, &config)
+
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != testCase.expectError {
+					t.Errorf("expected %q error, got: %v", testCase.expectError, resp.Diagnostics)
+				}
+
+				return
+			}
+
+			client := testConfigureProvider(t, &config)
+			resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			for name, expected := range testCase.expectedHeaders {
+				if value := headers.Get(name); value != expected {
+					t.Errorf("expected header %s to be %q, got %q", name, expected, value)
+				}
+			}
+		})
+	}
+}
+
+func TestPathfinderProvider_Configure_circuitBreaker(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:                 types.StringValue(server.URL),
+		CircuitBreakerThreshold: types.Int64Value(1),
+		CircuitBreakerCooldown:  types.StringValue("1h"),
+	})
+
+	for _, expected := range []string{"unexpected status code 502", "device appears down"} {
+		resp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error diagnostics, got none")
+		}
+
+		if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, expected) {
+			t.Errorf("expected error detail containing %q, got: %s", expected, detail)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request before the circuit breaker opened, got %d", requests)
+	}
+}
+
+func TestPathfinderProvider_Configure_retryOnStatus(t *testing.T) {
+	testCases := map[string]struct {
+		retryOnStatus    types.List
+		status           int
+		expectedRequests int
+	}{
+		"default": {
+			retryOnStatus:    types.ListNull(types.Int64Type),
+			status:           http.StatusTooManyRequests,
+			expectedRequests: 4,
+		},
+		"not-in-list": {
+			retryOnStatus:    types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(http.StatusTooManyRequests)}),
+			status:           http.StatusServiceUnavailable,
+			expectedRequests: 1,
+		},
+		"in-list": {
+			retryOnStatus:    types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(http.StatusInternalServerError)}),
+			status:           http.StatusInternalServerError,
+			expectedRequests: 4,
+		},
+		"empty": {
+			retryOnStatus:    types.ListValueMust(types.Int64Type, []attr.Value{}),
+			status:           http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		"unknown": {
+			retryOnStatus:    types.ListUnknown(types.Int64Type),
+			status:           http.StatusTooManyRequests,
+			expectedRequests: 4,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			client := testConfigureProvider(t, &PathfinderProviderModel{
+				Address:                 types.StringValue(server.URL),
+				RetryOnStatus:           testCase.retryOnStatus,
+				CircuitBreakerThreshold: types.Int64Value(0),
+			})
+			client.Config.RetryWaitMin = time.Millisecond
+
+			err := client.SendJSON(context.Background(), clients.Request{Method: http.MethodGet, Path: "/v1/healthz", Retry: true}, nil)
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if requests != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestPathfinderProvider_Configure_requestsPerSecond(t *testing.T) {
+	var times []time.Time
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		_, _ = w.Write([]byte(`{"ready":true}`))
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:           types.StringValue(server.URL),
+		RequestsPerSecond: types.Float64Value(20),
+	})
+
+	for i := 0; i < 3; i++ {
+		resp := testReadDataSource(t, NewReadyDataSource(), client, &ReadyDataSourceModel{})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+	}
+
+	if len(times) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(times))
+	}
+
+	if elapsed := times[2].Sub(times[0]); elapsed < 90*time.Millisecond {
+		t.Errorf("expected 3 requests at 20 per second to take at least 100ms, took %s", elapsed)
+	}
+}
+
+func TestPathfinderProvider_Configure_movementPath(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"moving":true}`))
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:      types.StringValue(server.URL),
+		MovementPath: types.StringValue("/custom/movement"),
+	})
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := testReadResource(t, NewMovementResource(), client, testMovementResourceModel())
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	expected := []string{"POST /custom/movement", "GET /custom/movement"}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("expected requests %v, got %v", expected, paths)
+	}
+}
+
+func TestPathfinderProvider_Configure_cacheCapabilities(t *testing.T) {
+	var capabilitiesRequests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/movement/capabilities" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+		}
+
+		capabilitiesRequests++
+		_, _ = w.Write([]byte(`{"directions":["forward","left","right"],"min_distance":0.5,"max_distance":20,"min_angle":0,"max_angle":180}`))
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:           types.StringValue(server.URL),
+		CacheCapabilities: types.BoolValue(true),
+	})
+
+	for i := 0; i < 2; i++ {
+		readResp := testReadDataSource(t, NewMovementCapabilitiesDataSource(), client, &MovementCapabilitiesDataSourceModel{
+			Directions: types.ListNull(types.StringType),
+		})
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+		}
+	}
+
+	for name, steps := range map[string][]MovementStepsModel{
+		"supported":   {testLinearStep("forward", 1)},
+		"unsupported": {testLinearStep("backward", 1)},
+	} {
+		config := testMovementResourceModel()
+		config.Steps = steps
+
+		plan := testMovementResourceModel()
+		plan.Id = types.StringUnknown()
+		plan.Moving = types.BoolUnknown()
+		plan.EstimatedDurationSeconds = types.Float64Unknown()
+		plan.Steps = steps
+
+		planResp := testModifyPlanResource(t, &MovementResource{}, client, config, plan)
+		if expectError := name == "unsupported"; planResp.Diagnostics.HasError() != expectError {
+			t.Fatalf("%s: expected plan error: %t, got: %v", name, expectError, planResp.Diagnostics)
+		}
+	}
+
+	if capabilitiesRequests != 1 {
+		t.Errorf("expected 1 capabilities request, got %d", capabilitiesRequests)
+	}
+
+	refreshResp := testReadDataSource(t, NewMovementCapabilitiesDataSource(), client, &MovementCapabilitiesDataSourceModel{
+		Directions: types.ListNull(types.StringType),
+		Refresh:    types.BoolValue(true),
+	})
+	if refreshResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", refreshResp.Diagnostics)
+	}
+
+	if capabilitiesRequests != 2 {
+		t.Errorf("expected refresh to read capabilities again, got %d requests", capabilitiesRequests)
+	}
+}
+
+func TestPathfinderProvider_Configure_cacheCapabilitiesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resp := testConfigureProviderResponse(t, &PathfinderProviderModel{
+		Address:           types.StringValue(server.URL),
+		CacheCapabilities: types.BoolValue(true),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure errors: %v", resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got: %v", resp.Diagnostics)
+	}
+
+	if resp.DataSourceData == nil {
+		t.Error("expected the client to be provided despite the warning")
+	}
+}
+
+func TestPathfinderProvider_Configure_recordOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s sent in record-only mode", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := testConfigureProvider(t, &PathfinderProviderModel{
+		Address:    types.StringValue(server.URL),
+		RecordOnly: types.BoolValue(true),
+	})
+
+	recorder, ok := client.HttpClient.(*clients.RecordingDoer)
+	if !ok {
+		t.Fatalf("expected *clients.RecordingDoer, got: %T", client.HttpClient)
+	}
+
+	createResp := testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := testReadDataSource(t, NewBatteryDataSource(), client, &BatteryDataSourceModel{})
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	requests := recorder.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d: %v", len(requests), requests)
+	}
+
+	if requests[0].Method != http.MethodPost || requests[0].Path != clients.DefaultMovementPath || !strings.Contains(requests[0].Body, `"name":"example"`) {
+		t.Errorf("expected the movement plan to be recorded, got %+v", requests[0])
+	}
+
+	if requests[1].Method != http.MethodGet || requests[1].Path != "/v1/device/battery" || requests[1].Body != "" {
+		t.Errorf("expected the battery read to be recorded, got %+v", requests[1])
+	}
+}
+
+// testInvalidAddress contains a control character, so that creating requests
+// fails.
+const testInvalidAddress = "http://rover\x7f.test"
+
+// testRequestDataSources returns the data sources that send requests to the
+// Pathfinder API, with an empty configuration for each.
+func testRequestDataSources() map[string]struct {
+	dataSource datasource.DataSource
+	config     any
+} {
+	return map[string]struct {
+		dataSource datasource.DataSource
+		config     any
+	}{
+		"battery":                  {dataSource: NewBatteryDataSource(), config: &BatteryDataSourceModel{}},
+		"battery_history":          {dataSource: NewBatteryHistoryDataSource(), config: &BatteryHistoryDataSourceModel{}},
+		"current_movement":         {dataSource: NewCurrentMovementDataSource(), config: &CurrentMovementDataSourceModel{}},
+		"device":                   {dataSource: NewDeviceDataSource(), config: &DeviceDataSourceModel{Features: types.MapNull(types.StringType)}},
+		"device_ready_for_feature": {dataSource: NewDeviceReadyForFeatureDataSource(), config: &DeviceReadyForFeatureDataSourceModel{Feature: types.StringValue("lidar")}},
+		"device_identifiers":       {dataSource: NewDeviceIdentifiersDataSource(), config: &DeviceIdentifiersDataSourceModel{}},
+		"device_position":          {dataSource: NewDevicePositionDataSource(), config: &DevicePositionDataSourceModel{}},
+		"device_time":              {dataSource: NewDeviceTimeDataSource(), config: &DeviceTimeDataSourceModel{}},
+		"device_status":            {dataSource: NewDeviceStatusDataSource(), config: &DeviceStatusDataSourceModel{Features: types.MapNull(types.BoolType), EnabledFeatures: types.ListNull(types.StringType)}},
+		"feature_catalog":          {dataSource: NewFeatureCatalogDataSource(), config: &FeatureCatalogDataSourceModel{}},
+		"health":                   {dataSource: NewHealthDataSource(), config: &HealthDataSourceModel{}},
+		"movement_capabilities":    {dataSource: NewMovementCapabilitiesDataSource(), config: &MovementCapabilitiesDataSourceModel{Directions: types.ListNull(types.StringType)}},
+		"movement_lock":            {dataSource: NewMovementLockDataSource(), config: &MovementLockDataSourceModel{}},
+		"ping":                     {dataSource: NewPingDataSource(), config: &PingDataSourceModel{}},
+		"wifi_networks":            {dataSource: NewWifiNetworksDataSource(), config: &WifiNetworksDataSourceModel{}},
+		"wifi_reachable":           {dataSource: NewWifiReachableDataSource(), config: &WifiReachableDataSourceModel{Ssid: types.StringValue("lab")}},
+	}
+}
+
+func TestDataSources_invalidAddress(t *testing.T) {
+	for name, testCase := range testRequestDataSources() {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				Config:     clients.ClientConfig{Address: testInvalidAddress},
+				HttpClient: &testDoer{},
+			}
+
+			resp := testReadDataSource(t, testCase.dataSource, client, testCase.config)
+
+			if !resp.Diagnostics.HasError() {
+				t.Errorf("expected error diagnostics, got none")
+			}
+		})
+	}
+}
+
+// TestEndpoints_addressTrailingSlash verifies that every endpoint is joined
+// onto an address ending with a slash, including a base path, without
+// doubled or missing slashes.
+func TestEndpoints_addressTrailingSlash(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"ready":true,"locked":true}`))
+	}))
+	defer server.Close()
+
+	client, err := clients.NewClient(clients.ClientConfig{
+		Address:  server.URL + "/rovers/1/",
+		RetryMax: -1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	// Responses that do not match the model fail to decode, which does not
+	// matter as only the paths of the requests are verified.
+	for _, testCase := range testRequestDataSources() {
+		testReadDataSource(t, testCase.dataSource, client, testCase.config)
+	}
+
+	testReadDataSource(t, NewReadyDataSource(), client, &ReadyDataSourceModel{})
+	testCreateResource(t, NewMovementResource(), client, testMovementResourceModel())
+	testCreateResource(t, NewMovementLockResource(), client, &MovementLockResourceModel{
+		Id:        types.StringUnknown(),
+		ExpiresAt: types.StringUnknown(),
+	})
+
+	expected := []string{
+		"/rovers/1/v1/movement",
+		"/rovers/1/v1/movement-plan",
+		"/rovers/1/v1/movement/lock",
+		"/rovers/1/v1/device/status",
+		"/rovers/1/v1/device/wifi",
+		"/rovers/1/v1/device/battery",
+		"/rovers/1/v1/healthz",
+		"/rovers/1/v1/readyz",
+	}
+
+	for _, path := range expected {
+		if !slices.Contains(paths, path) {
+			t.Errorf("expected a request to %s, got requests to %v", path, paths)
+		}
+	}
+
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "/rovers/1/v1/") || strings.Contains(path, "//") {
+			t.Errorf("expected the path %s to be joined onto the address", path)
+		}
+	}
+}
+
+func TestDataSources_doerError(t *testing.T) {
+	for name, testCase := range testRequestDataSources() {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{
+				Config:     clients.ClientConfig{Address: "http://rover.test"},
+				HttpClient: testErrorDoer{err: errors.New("connection refused")},
+			}
+
+			resp := testReadDataSource(t, testCase.dataSource, client, testCase.config)
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Errorf("expected 1 error diagnostic, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// testConfigureProvider configures a new provider instance using config and
+// returns the client it provides to resources and data sources.
+func testConfigureProvider(t *testing.T, config *PathfinderProviderModel) *clients.Client {
+	t.Helper()
+
+	resp := testConfigureProviderResponse(t, config)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", resp.Diagnostics)
+	}
+
+	client, ok := resp.DataSourceData.(*clients.Client)
+	if !ok {
+		t.Fatalf("expected *clients.Client, got: %T", resp.DataSourceData)
+	}
+
+	return client
+}
+
+// testConfigureProviderResponse configures a new provider instance using
+// config and returns the response, including its diagnostics.
+func testConfigureProviderResponse(t *testing.T, config *PathfinderProviderModel) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	// Collection attributes left unset by the test are null.
+	config = testNullProviderCollections(config)
+
+	configState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := configState.Set(ctx, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics building config: %v", diags)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configState.Raw,
+		},
+	}, resp)
+
+	return resp
+}
+
+// testNullProviderCollections returns a copy of config with the collection
+// attributes that are not set replaced by typed null values, as the zero
+// value of a collection has no element type.
+func testNullProviderCollections(config *PathfinderProviderModel) *PathfinderProviderModel {
+	copied := *config
+	config = &copied
+
+	if config.ExtraHeaders.ElementType(context.Background()) == nil {
+		config.ExtraHeaders = types.MapNull(types.StringType)
+	}
+
+	if config.SensitiveHeaderKeys.ElementType(context.Background()) == nil {
+		config.SensitiveHeaderKeys = types.ListNull(types.StringType)
+	}
+
+	if config.RetryOnStatus.ElementType(context.Background()) == nil {
+		config.RetryOnStatus = types.ListNull(types.Int64Type)
+	}
+
+	return config
+}
+
+// testClient returns a client that sends requests to the given test server.
+func testClient(t *testing.T, server *httptest.Server) *clients.Client {
+	t.Helper()
+
+	client, err := clients.NewClient(clients.ClientConfig{
+		Address:      server.URL,
+		RetryWaitMin: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return client
+}
+
+// testDoer is a clients.Doer that returns canned responses, keyed by request
+// method and path, without sending any requests. Requests without a canned
+// response receive a 404 Not Found response.
+type testDoer struct {
+	responses map[string]string
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (d *testDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	d.requests = append(d.requests, req)
+	d.mu.Unlock()
+
+	body, ok := d.responses[req.Method+" "+req.URL.Path]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// testErrorDoer is a clients.Doer that fails every request with err, as when
+// the device cannot be reached.
+type testErrorDoer struct {
+	err error
+}
+
+func (d testErrorDoer) Do(req *http.Request) (*http.Response, error) {
+	return nil, d.err
+}
+
+// testReadDataSource configures the data source with the given client and
+// reads it using config, which must be a pointer to the data source model.
+func testReadDataSource(t *testing.T, d datasource.DataSource, client *clients.Client, config any) *datasource.ReadResponse {
+	t.Helper()
+
+	return testReadDataSourceContext(t, context.Background(), d, client, config)
+}
+
+// testReadDataSourceContext is testReadDataSource reading the data source with
+// the given context, such as a context that is cancelled during the read.
+func testReadDataSourceContext(t *testing.T, ctx context.Context, d datasource.DataSource, client *clients.Client, config any) *datasource.ReadResponse {
+	t.Helper()
+
+	if d, ok := d.(datasource.DataSourceWithConfigure); ok {
+		configureResp := &datasource.ConfigureResponse{}
+		d.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, configureResp)
+
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected configure diagnostics: %v", configureResp.Diagnostics)
+		}
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	// The configuration is built by setting the model on an empty state, as
+	// tfsdk.Config does not support setting values directly.
+	configState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := configState.Set(ctx, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	d.Read(ctx, datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configState.Raw,
+		},
+	}, resp)
+
+	return resp
+}
+
+// testConfigureResource configures the resource with the given client and
+// returns its schema.
+func testConfigureResource(t *testing.T, r resource.Resource, client *clients.Client) resource.SchemaResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if r, ok := r.(resource.ResourceWithConfigure); ok {
+		configureResp := &resource.ConfigureResponse{}
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, configureResp)
+
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected configure diagnostics: %v", configureResp.Diagnostics)
+		}
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	return schemaResp
+}
+
+// testResourceState returns a state for the resource schema holding the
+// given value, which must be a pointer to the resource model.
+func testResourceState(t *testing.T, schemaResp resource.SchemaResponse, value any) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, value); diags.HasError() {
+		t.Fatalf("unexpected diagnostics building state: %v", diags)
+	}
+
+	return state
+}
+
+// testReadResource configures the resource with the given client and reads
+// it using state, which must be a pointer to the resource model.
+func testReadResource(t *testing.T, r resource.Resource, client *clients.Client, state any) *resource.ReadResponse {
+	t.Helper()
+
+	schemaResp := testConfigureResource(t, r, client)
+	priorState := testResourceState(t, schemaResp, state)
+
+	resp := &resource.ReadResponse{
+		State: priorState,
+	}
+	r.Read(context.Background(), resource.ReadRequest{State: priorState}, resp)
+
+	return resp
+}
+
+// testCreateResource configures the resource with the given client and
+// creates it using plan, which must be a pointer to the resource model.
+func testCreateResource(t *testing.T, r resource.Resource, client *clients.Client, plan any) *resource.CreateResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := testConfigureResource(t, r, client)
+	planState := testResourceState(t, schemaResp, plan)
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.Create(ctx, resource.CreateRequest{
+		Plan: tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw:    planState.Raw,
+		},
+	}, resp)
+
+	return resp
+}
+
+// testDeleteResource configures the resource with the given client and
+// deletes it using state, which must be a pointer to the resource model.
+func testDeleteResource(t *testing.T, r resource.Resource, client *clients.Client, state any) *resource.DeleteResponse {
+	t.Helper()
+
+	schemaResp := testConfigureResource(t, r, client)
+	priorState := testResourceState(t, schemaResp, state)
+
+	resp := &resource.DeleteResponse{
+		State: priorState,
+	}
+	r.Delete(context.Background(), resource.DeleteRequest{State: priorState}, resp)
+
+	return resp
+}
+
+// testModifyPlanResource configures the resource with the given client and
+// modifies the plan for creating it from config and plan, which must be
+// pointers to the resource model.
+func testModifyPlanResource(t *testing.T, r resource.ResourceWithModifyPlan, client *clients.Client, config any, plan any) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := testConfigureResource(t, r, client)
+	configState := testResourceState(t, schemaResp, config)
+	planState := testResourceState(t, schemaResp, plan)
+	priorState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	resp := &resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw:    planState.Raw,
+		},
+	}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configState.Raw,
+		},
+		Plan:  resp.Plan,
+		State: priorState,
+	}, resp)
+
+	return resp
+}
+
+// testUpdateResource configures the resource with the given client and
+// updates it from state to plan, which must be pointers to the resource model.
+func testUpdateResource(t *testing.T, r resource.Resource, client *clients.Client, state any, plan any) *resource.UpdateResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := testConfigureResource(t, r, client)
+	priorState := testResourceState(t, schemaResp, state)
+	planState := testResourceState(t, schemaResp, plan)
+
+	resp := &resource.UpdateResponse{
+		State: priorState,
+	}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan: tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw:    planState.Raw,
+		},
+		State: priorState,
+	}, resp)
+
+	return resp
+}
@@ -5,16 +5,16 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -33,9 +33,26 @@ type ReadyDataSource struct {
 
 // ReadyDataSourceModel describes the data source data model.
 type ReadyDataSourceModel struct {
-	Ready types.Bool `tfsdk:"ready"`
+	Ready        types.Bool   `tfsdk:"ready"`
+	WaitForReady types.Bool   `tfsdk:"wait_for_ready"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+	WaitTimeout  types.String `tfsdk:"wait_timeout"`
+	LongPoll     types.Bool   `tfsdk:"long_poll"`
 }
 
+// defaultReadyWaitTimeout is the maximum time to wait for the device to be
+// ready when no wait_timeout is configured.
+const defaultReadyWaitTimeout = 5 * time.Minute
+
+// readyLongPollWait is the time the device is asked to block a long-poll
+// readiness check for until it is ready. It is a variable so that tests can
+// shorten it.
+var readyLongPollWait = 30 * time.Second
+
+// readyLongPollMargin extends the timeout of a long-poll readiness check
+// beyond the wait, for the device to respond once the wait is over.
+const readyLongPollMargin = 10 * time.Second
+
 func (d *ReadyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_ready"
 }
@@ -50,6 +67,33 @@ func (d *ReadyDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "Indicates if the device and service are ready for use.",
 				Computed:            true,
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the device and service to be ready, returning an error if they are not ready " +
+					"within `wait_timeout`. Defaults to `false`.",
+				Optional: true,
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Interval between readiness checks while waiting, such as `2s`. " +
+					"Defaults to the `poll_interval` of the provider.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for the device and service to be ready, such as `5m`. Defaults to `5m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"long_poll": schema.BoolAttribute{
+				MarkdownDescription: "Wait with long-poll readiness checks, which the device holds for up to 30 seconds until it is " +
+					"ready, rather than checking every `poll_interval`, reducing the number of requests. Only used when " +
+					"`wait_for_ready` is `true`. Falls back to checking every `poll_interval` if the device does not support " +
+					"long polling or a check fails. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -80,63 +124,77 @@ func (d *ReadyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/readyz", d.client.Config.Address),
-		io.NopCloser(strings.NewReader("")),
-	)
+	var readResp model.ReadyzResponse
 
-	// Example of setting a custom header, such as an API key
-	// httpReq.Header.Set("x-api-key", d.client.Config.ApiKey)
+	if data.WaitForReady.ValueBool() {
+		interval := d.client.Config.PollInterval
+		if !data.PollInterval.IsNull() {
+			interval, _ = time.ParseDuration(data.PollInterval.ValueString())
+		}
 
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
+		timeout := defaultReadyWaitTimeout
+		if !data.WaitTimeout.IsNull() {
+			timeout, _ = time.ParseDuration(data.WaitTimeout.ValueString())
+		}
 
-	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
-		return
-	}
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
 
-	httpResp, err := d.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
+		var lastErr error
+		var err error
 
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+		done := false
+		if data.LongPoll.ValueBool() {
+			done, err = d.longPollReady(waitCtx, &readResp)
+		}
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while attempting to refresh resource state. "+
-				"Please retry the operation or report this issue to the provider developers.\n\n"+
-				"HTTP Error: "+err.Error(),
-		)
+		// Errors are expected while the device is starting, so they are
+		// logged and the readiness check is retried until the timeout.
+		if !done {
+			err = clients.Poll(waitCtx, interval, func(ctx context.Context) (bool, error) {
+				lastErr = d.client.GetJSON(ctx, "/v1/readyz", &readResp)
+				if lastErr != nil {
+					tflog.Debug(ctx, "Readiness check failed", map[string]interface{}{
+						"error": lastErr.Error(),
+					})
+					return false, nil
+				}
 
-		return
-	}
+				return readResp.Ready, nil
+			})
+		}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+		if err != nil {
+			detail := fmt.Sprintf("The device and service were not ready within %s.", timeout)
+			if lastErr != nil {
+				detail += "\n\nLast HTTP Error: " + lastErr.Error()
+			}
 
-		return
-	}
+			resp.Diagnostics.AddError("Device Not Ready", detail)
 
-	var readResp model.ReadyzResponse
-	err = json.NewDecoder(httpResp.Body).Decode(&readResp)
+			return
+		}
+	} else {
+		err := d.client.GetJSON(ctx, "/v1/readyz", &readResp)
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while parsing the resource read response. "+
-				"Please report this issue to the provider developers.\n\n"+
-				"JSON Error: "+err.Error(),
-		)
+		// Treat HTTP 404 Not Found status as a signal to recreate resource
+		// and return early
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
 
-		return
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Refresh Resource",
+				"An unexpected error occurred while attempting to refresh resource state. "+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					"HTTP Error: "+err.Error(),
+			)
+
+			return
+		}
 	}
 
 	data.Ready = types.BoolValue(readResp.Ready)
@@ -144,3 +202,58 @@ func (d *ReadyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// longPollReady waits for the device to be ready with long-poll readiness
+// checks, which the device holds until it is ready or the wait is over. It
+// returns true once the device is ready, or with the context error when ctx
+// is done first. It returns false if the device does not support long
+// polling, or a check fails, so that the caller falls back to polling, which
+// retries failed checks.
+//
+// Devices that do not support long polling either reject the wait query
+// parameter, or ignore it and respond immediately.
+func (d *ReadyDataSource) longPollReady(ctx context.Context, readResp *model.ReadyzResponse) (bool, error) {
+	for {
+		wait := readyLongPollWait
+		if deadline, ok := ctx.Deadline(); ok {
+			wait = min(wait, time.Until(deadline).Truncate(time.Millisecond))
+		}
+
+		if wait <= 0 {
+			return true, context.DeadlineExceeded
+		}
+
+		// The device holds the request for the wait, so it needs longer than
+		// a readiness check to complete.
+		reqCtx, cancel := context.WithTimeout(ctx, wait+readyLongPollMargin)
+		start := time.Now()
+		err := d.client.GetJSON(reqCtx, "/v1/readyz?"+url.Values{"wait": {wait.String()}}.Encode(), readResp)
+		elapsed := time.Since(start)
+		cancel()
+
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+
+		if err != nil {
+			tflog.Debug(ctx, "Long-poll readiness check failed, falling back to polling", map[string]interface{}{
+				"error": err.Error(),
+			})
+
+			return false, nil
+		}
+
+		if readResp.Ready {
+			return true, nil
+		}
+
+		if elapsed < wait/2 {
+			tflog.Debug(ctx, "Device responded to the long-poll readiness check without waiting, falling back to polling", map[string]interface{}{
+				"wait":    wait.String(),
+				"elapsed": elapsed.String(),
+			})
+
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadyDataSource_Read_longPoll(t *testing.T) {
+	testCases := map[string]struct {
+		// handler responds to the nth request, starting at 1, with the wait
+		// query parameter of the request
+		handler       func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration)
+		longPollWait  time.Duration
+		waitTimeout   string
+		expectedWaits []string
+		expectError   bool
+	}{
+		"ready": {
+			handler: func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration) {
+				_, _ = w.Write([]byte(`{"ready":true}`))
+			},
+			expectedWaits: []string{"30s"},
+		},
+		"held-until-ready": {
+			handler: func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration) {
+				if n == 1 {
+					time.Sleep(wait)
+					_, _ = w.Write([]byte(`{"ready":false}`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"ready":true}`))
+			},
+			longPollWait:  50 * time.Millisecond,
+			expectedWaits: []string{"50ms", "50ms"},
+		},
+		"wait-ignored": {
+			handler: func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration) {
+				_, _ = w.Write([]byte(`{"ready":` + strconv.FormatBool(n == 3) + `}`))
+			},
+			expectedWaits: []string{"30s", "", ""},
+		},
+		"wait-rejected": {
+			handler: func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration) {
+				if wait > 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"ready":true}`))
+			},
+			expectedWaits: []string{"30s", ""},
+		},
+		"timeout": {
+			handler: func(w http.ResponseWriter, r *http.Request, n int, wait time.Duration) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(wait):
+				}
+
+				_, _ = w.Write([]byte(`{"ready":false}`))
+			},
+			waitTimeout: "100ms",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if testCase.longPollWait > 0 {
+				defaultWait := readyLongPollWait
+				readyLongPollWait = testCase.longPollWait
+				t.Cleanup(func() { readyLongPollWait = defaultWait })
+			}
+
+			var waits []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/readyz" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				waitParam := r.URL.Query().Get("wait")
+				waits = append(waits, waitParam)
+
+				var wait time.Duration
+				if waitParam != "" {
+					var err error
+					if wait, err = time.ParseDuration(waitParam); err != nil {
+						t.Errorf("unexpected wait %q: %s", waitParam, err)
+					}
+				}
+
+				testCase.handler(w, r, len(waits), wait)
+			}))
+			defer server.Close()
+
+			config := &ReadyDataSourceModel{
+				WaitForReady: types.BoolValue(true),
+				LongPoll:     types.BoolValue(true),
+				PollInterval: types.StringValue("1ms"),
+				WaitTimeout:  types.StringNull(),
+			}
+			if testCase.waitTimeout != "" {
+				config.WaitTimeout = types.StringValue(testCase.waitTimeout)
+			}
+
+			resp := testReadDataSource(t, NewReadyDataSource(), testClient(t, server), config)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Device Not Ready" {
+					t.Errorf("expected Device Not Ready error, got: %s", summary)
+				}
+
+				// Every readiness check is a long-poll check, waiting at most
+				// for the remaining time.
+				for _, wait := range waits {
+					if d, err := time.ParseDuration(wait); err != nil || d > 100*time.Millisecond {
+						t.Errorf("expected long-poll checks waiting for the remaining time, got waits %q", waits)
+					}
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !slices.Equal(waits, testCase.expectedWaits) {
+				t.Errorf("expected requests with waits %q, got %q", testCase.expectedWaits, waits)
+			}
+
+			var data ReadyDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Ready.ValueBool() {
+				t.Errorf("expected ready to be true, got %s", data.Ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RssiToBarsFunction{}
+
+func NewRssiToBarsFunction() function.Function {
+	return &RssiToBarsFunction{}
+}
+
+// RssiToBarsFunction defines the function implementation.
+type RssiToBarsFunction struct{}
+
+func (f *RssiToBarsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rssi_to_bars"
+}
+
+func (f *RssiToBarsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert an RSSI value to signal quality bars.",
+		MarkdownDescription: "Converts a Received Signal Strength Indicator (RSSI) value in dBm, such as the `rssi` " +
+			"of the `pathfinder_wifi_networks` data source, to a signal quality from 0 to 4 bars:\n\n" +
+			"- 4 bars from -55 dBm.\n" +
+			"- 3 bars from -67 dBm.\n" +
+			"- 2 bars from -75 dBm.\n" +
+			"- 1 bar from -85 dBm.\n" +
+			"- 0 bars below -85 dBm.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "rssi",
+				MarkdownDescription: "RSSI value to convert in dBm, for example `-60`.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *RssiToBarsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rssi float64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &rssi))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rssiToBars(rssi)))
+}
+
+// rssiThresholds are the minimum RSSI values in dBm for 4, 3, 2, and 1 bars.
+var rssiThresholds = []float64{-55, -67, -75, -85}
+
+// rssiToBars returns the number of signal quality bars for the RSSI value,
+// from 0 to 4. Values outside of the thresholds are clamped.
+func rssiToBars(rssi float64) int64 {
+	for i, threshold := range rssiThresholds {
+		if rssi >= threshold {
+			return int64(len(rssiThresholds) - i)
+		}
+	}
+
+	return 0
+}
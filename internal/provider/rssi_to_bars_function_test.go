@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRssiToBarsFunction(t *testing.T) {
+	testCases := map[string]struct {
+		rssi     float64
+		expected int64
+	}{
+		"absurdly-strong": {rssi: 20, expected: 4},
+		"four-bars":       {rssi: -55, expected: 4},
+		"below-four-bars": {rssi: -55.5, expected: 3},
+		"three-bars":      {rssi: -67, expected: 3},
+		"below-three":     {rssi: -68, expected: 2},
+		"two-bars":        {rssi: -75, expected: 2},
+		"below-two":       {rssi: -76, expected: 1},
+		"one-bar":         {rssi: -85, expected: 1},
+		"below-one":       {rssi: -86, expected: 0},
+		"absurdly-weak":   {rssi: -1000, expected: 0},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+			}
+
+			NewRssiToBarsFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.Float64Value(testCase.rssi)}),
+			}, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := types.Int64Value(testCase.expected)
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
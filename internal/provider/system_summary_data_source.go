@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SystemSummaryDataSource{}
+
+func NewSystemSummaryDataSource() datasource.DataSource {
+	return &SystemSummaryDataSource{}
+}
+
+// SystemSummaryDataSource defines the data source implementation.
+type SystemSummaryDataSource struct {
+	client *clients.Client
+}
+
+// SystemSummaryDataSourceModel describes the data source data model.
+type SystemSummaryDataSourceModel struct {
+	Device  *SystemSummaryDeviceModel  `tfsdk:"device"`
+	Battery *SystemSummaryBatteryModel `tfsdk:"battery"`
+	Health  *SystemSummaryHealthModel  `tfsdk:"health"`
+}
+
+type SystemSummaryDeviceModel struct {
+	Name   types.String  `tfsdk:"name"`
+	Uptime types.Float64 `tfsdk:"uptime"`
+}
+
+type SystemSummaryBatteryModel struct {
+	Value types.Int64  `tfsdk:"value"`
+	Unit  types.String `tfsdk:"unit"`
+}
+
+type SystemSummaryHealthModel struct {
+	Healthy types.Bool `tfsdk:"healthy"`
+}
+
+func (d *SystemSummaryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_summary"
+}
+
+func (d *SystemSummaryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Get a combined summary of the device, battery, and health status. " +
+			"If one of the underlying endpoints is unavailable, its attribute is null and a warning is returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"device": schema.SingleNestedAttribute{
+				MarkdownDescription: "Summary of the device status.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the device.",
+						Computed:            true,
+					},
+					"uptime": schema.Float64Attribute{
+						MarkdownDescription: "Uptime (in seconds). Changes on every read, so referencing it in resource arguments causes a change to be planned on every run.",
+						Computed:            true,
+					},
+				},
+			},
+			"battery": schema.SingleNestedAttribute{
+				MarkdownDescription: "Summary of the on-board battery.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"value": schema.Int64Attribute{
+						MarkdownDescription: "Current battery value.",
+						Computed:            true,
+					},
+					"unit": schema.StringAttribute{
+						MarkdownDescription: "Unit of the battery value.",
+						Computed:            true,
+					},
+				},
+			},
+			"health": schema.SingleNestedAttribute{
+				MarkdownDescription: "Summary of the service and device health.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"healthy": schema.BoolAttribute{
+						MarkdownDescription: "Indicates if the device and service are healthy for use.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SystemSummaryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *SystemSummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SystemSummaryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		deviceResp  model.DeviceResponse
+		batteryResp model.BatteryResponse
+		healthResp  model.HealthzResponse
+
+		deviceErr, batteryErr, healthErr error
+	)
+
+	// Each request records its own error rather than returning it, so that a
+	// single unavailable endpoint does not cancel the others.
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		deviceErr = d.client.GetJSON(gctx, "/v1/device/status", &deviceResp)
+		return nil
+	})
+	g.Go(func() error {
+		batteryErr = d.client.GetJSON(gctx, "/v1/device/battery", &batteryResp)
+		return nil
+	})
+	g.Go(func() error {
+		// An unhealthy device responds with 503 Service Unavailable along
+		// with its health status
+		healthErr = d.client.SendJSON(gctx, clients.Request{
+			Method:         http.MethodGet,
+			Path:           "/v1/healthz",
+			ExpectedStatus: []int{http.StatusOK, http.StatusServiceUnavailable},
+		}, &healthResp)
+		return nil
+	})
+	_ = g.Wait()
+
+	if deviceErr != nil && batteryErr != nil && healthErr != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read System Summary",
+			"All of the device, battery, and health endpoints failed. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+deviceErr.Error(),
+		)
+
+		return
+	}
+
+	if deviceErr != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Device Status",
+			"The device status is omitted from the summary.\n\nHTTP Error: "+deviceErr.Error(),
+		)
+	} else {
+		data.Device = &SystemSummaryDeviceModel{
+			Name:   types.StringValue(deviceResp.Name),
+			Uptime: types.Float64Value(float64(deviceResp.Uptime)),
+		}
+	}
+
+	if batteryErr != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Battery Status",
+			"The battery status is omitted from the summary.\n\nHTTP Error: "+batteryErr.Error(),
+		)
+	} else {
+		data.Battery = &SystemSummaryBatteryModel{
+			Value: types.Int64Value(int64(batteryResp.Value)),
+			Unit:  types.StringValue(batteryResp.Unit),
+		}
+	}
+
+	if healthErr != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Health Status",
+			"The health status is omitted from the summary.\n\nHTTP Error: "+healthErr.Error(),
+		)
+	} else {
+		data.Health = &SystemSummaryHealthModel{
+			Healthy: types.BoolValue(healthResp.Healthy),
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
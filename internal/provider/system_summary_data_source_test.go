@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSystemSummaryDataSource_partialFailure(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/device/status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"rover","uptime":12.5}`))
+	})
+	mux.HandleFunc("/v1/device/battery", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":95,"unit":"%"}`))
+	})
+	mux.HandleFunc("/v1/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"unhealthy","status":500}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp := testReadDataSource(t, NewSystemSummaryDataSource(), testClient(t, server), &SystemSummaryDataSourceModel{})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got: %v", resp.Diagnostics)
+	}
+
+	var data SystemSummaryDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if data.Device == nil || data.Device.Name.ValueString() != "rover" {
+		t.Errorf("expected device name rover, got: %+v", data.Device)
+	}
+
+	if data.Battery == nil || data.Battery.Value.ValueInt64() != 95 {
+		t.Errorf("expected battery value 95, got: %+v", data.Battery)
+	}
+
+	if data.Health != nil {
+		t.Errorf("expected health to be null, got: %+v", data.Health)
+	}
+}
+
+func TestSystemSummaryDataSource_unhealthy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/device/status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"rover","uptime":12.5}`))
+	})
+	mux.HandleFunc("/v1/device/battery", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":95,"unit":"%"}`))
+	})
+	mux.HandleFunc("/v1/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"healthy":false}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp := testReadDataSource(t, NewSystemSummaryDataSource(), testClient(t, server), &SystemSummaryDataSourceModel{})
+
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data SystemSummaryDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	if data.Health == nil || !data.Health.Healthy.Equal(types.BoolValue(false)) {
+		t.Errorf("expected healthy to be false, got: %+v", data.Health)
+	}
+}
+
+func TestSystemSummaryDataSource_allFailed(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	resp := testReadDataSource(t, NewSystemSummaryDataSource(), testClient(t, server), &SystemSummaryDataSourceModel{})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected error diagnostics, got: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidatePlanFunction{}
+
+func NewValidatePlanFunction() function.Function {
+	return &ValidatePlanFunction{}
+}
+
+// ValidatePlanFunction defines the function implementation.
+type ValidatePlanFunction struct{}
+
+func (f *ValidatePlanFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_plan"
+}
+
+func (f *ValidatePlanFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate the steps of a movement plan.",
+		MarkdownDescription: "Validates a list of movement steps against the rules of the `steps` blocks of the " +
+			"`pathfinder_movement` resource, and returns a list of error messages, which is empty if the steps are valid.\n\n" +
+			"This allows generated steps, such as the result of `parse_path`, to be checked in a `precondition` " +
+			"before the resource is planned. The following rules are checked:\n\n" +
+			"- The plan has between 1 and 50 steps.\n" +
+			"- `angle` and `direction` are set, and `direction` is one of `forward`, `backward`, `left`, or `right`.\n" +
+			"- Steps with a `waypoint` do not set `angle`, `direction`, or `distance`, which are computed from the " +
+			"waypoint. The waypoint itself is not checked, as the function does not know the waypoints of the resource.\n" +
+			"- `distance` is set for `forward` and `backward` steps, is not set for `left` and `right` steps, " +
+			"and is between 1 and 100 meters.\n" +
+			"- The `distance` of all `forward` and `backward` steps adds up to at most 1000 meters. Steps with a " +
+			"`waypoint` are not counted, as their distance is computed by the resource, which checks the total again.\n" +
+			"- `speed`, when set, is between 0.1 and 2 meters per second.\n\n" +
+			"Messages about a single step start with the index of the step in `steps`, starting at 0.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name: "steps",
+				MarkdownDescription: "Movement steps to validate, as objects with the `angle`, `direction`, `distance`, " +
+					"`speed`, and `waypoint` attributes. `distance`, `speed`, and `waypoint` may be `null`.",
+				ElementType: types.ObjectType{
+					AttrTypes: movementStepAttrTypes,
+				},
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ValidatePlanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var steps []MovementStepsModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &steps))
+
+	if resp.Error != nil {
+		return
+	}
+
+	errs := validateMovementSteps(steps)
+	if errs == nil {
+		errs = []string{}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, errs))
+}
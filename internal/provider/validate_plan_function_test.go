@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidatePlanFunction(t *testing.T) {
+	testCases := map[string]struct {
+		steps    []MovementStepsModel
+		expected []string
+	}{
+		"valid": {
+			steps:    []MovementStepsModel{testLinearStep("forward", 1.5), testRotationStep("right", 90)},
+			expected: []string{},
+		},
+		"empty": {
+			steps:    []MovementStepsModel{},
+			expected: []string{"The movement plan must have at least one step."},
+		},
+		"too-many-steps": {
+			steps:    slices.Repeat([]MovementStepsModel{testRotationStep("left", 10)}, 51),
+			expected: []string{"The movement plan has 51 steps, at most 50 are allowed."},
+		},
+		"total-distance-at-limit": {
+			steps:    slices.Repeat([]MovementStepsModel{testLinearStep("forward", 100)}, 10),
+			expected: []string{},
+		},
+		"total-distance-over-limit": {
+			steps: append(
+				slices.Repeat([]MovementStepsModel{testLinearStep("forward", 100)}, 10),
+				testRotationStep("left", 90),
+				testLinearStep("backward", 1),
+			),
+			expected: []string{"The movement plan moves 1001 meters in total, at most 1000 meters are allowed."},
+		},
+		"unknown-direction": {
+			steps:    []MovementStepsModel{testLinearStep("up", 1)},
+			expected: []string{`Step 0: The direction "up" is not one of ["forward" "backward" "left" "right"].`},
+		},
+		"missing-distance": {
+			steps: []MovementStepsModel{testRotationStep("right", 90), testRotationStep("backward", 0)},
+			expected: []string{
+				`Step 1: The distance attribute must be set when direction is "backward".`,
+			},
+		},
+		"unexpected-distance": {
+			steps: []MovementStepsModel{testLinearStep("left", 1)},
+			expected: []string{
+				`Step 0: The distance attribute must not be set when direction is "left", as the device rotates in place.`,
+			},
+		},
+		"waypoint": {
+			steps: []MovementStepsModel{
+				{
+					Angle:     types.Int64Value(90),
+					Direction: types.StringNull(),
+					Distance:  types.Float64Null(),
+					Speed:     types.Float64Null(),
+					Waypoint:  types.StringValue("dock"),
+				},
+			},
+			expected: []string{
+				"Step 0: The angle attribute must not be set for a step with a waypoint, as it is computed from the waypoint.",
+			},
+		},
+		"out-of-range": {
+			steps: []MovementStepsModel{
+				{
+					Angle:     types.Int64Null(),
+					Direction: types.StringValue("forward"),
+					Distance:  types.Float64Value(150),
+					Speed:     types.Float64Value(3),
+				},
+			},
+			expected: []string{
+				"Step 0: The angle attribute must be set for a step without a waypoint.",
+				"Step 0: The distance must be between 1 and 100 meters, got: 150.",
+				"Step 0: The speed must be between 0.1 and 2 meters per second, got: 3.",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			steps, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: movementStepAttrTypes}, testCase.steps)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewValidatePlanFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{steps}),
+			}, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected, diags := types.ListValueFrom(ctx, types.StringType, testCase.expected)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !resp.Result.Value().Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
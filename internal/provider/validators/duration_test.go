@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testValidateString validates value with v and returns whether it failed.
+func testValidateString(t *testing.T, v validator.String, value types.String) bool {
+	t.Helper()
+
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+
+	return resp.Diagnostics.HasError()
+}
+
+func TestDuration(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"seconds":  {value: types.StringValue("2s")},
+		"minutes":  {value: types.StringValue("5m")},
+		"zero":     {value: types.StringValue("0s"), expectError: true},
+		"negative": {value: types.StringValue("-1s"), expectError: true},
+		"no unit":  {value: types.StringValue("5"), expectError: true},
+		"invalid":  {value: types.StringValue("soon"), expectError: true},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testValidateString(t, Duration(), testCase.value); got != testCase.expectError {
+				t.Errorf("expected error: %t, got: %t", testCase.expectError, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MovementDirections are the directions of movement steps accepted by the
+// device.
+var MovementDirections = []string{"forward", "backward", "left", "right"}
+
+// MaxMovementAngle is the largest angle of a movement step, a full turn.
+const MaxMovementAngle = 360
+
+// Limits of the distance and speed of movement steps accepted by the device.
+const (
+	MinMovementStepDistance = 1.0
+	MaxMovementStepDistance = 100.0
+	MinMovementStepSpeed    = 0.1
+	MaxMovementStepSpeed    = 2.0
+)
+
+// IsLinearDirection returns true if the direction moves the device in a line,
+// rather than rotating it in place.
+func IsLinearDirection(direction string) bool {
+	return direction == "forward" || direction == "backward"
+}
+
+// MovementStepDistanceError returns why the distance of a step is invalid,
+// as it must be set for linear steps and not set for rotation steps, or an
+// empty string if it is valid.
+func MovementStepDistanceError(direction string, hasDistance bool) string {
+	if IsLinearDirection(direction) && !hasDistance {
+		return fmt.Sprintf("The distance attribute must be set when direction is %q.", direction)
+	}
+
+	if !IsLinearDirection(direction) && hasDistance {
+		return fmt.Sprintf("The distance attribute must not be set when direction is %q, as the device rotates in place.", direction)
+	}
+
+	return ""
+}
+
+// MovementDirectionError returns why the direction of a step is invalid, as
+// it is not one of MovementDirections, or an empty string if it is valid.
+func MovementDirectionError(direction string) string {
+	if slices.Contains(MovementDirections, direction) {
+		return ""
+	}
+
+	return fmt.Sprintf("The direction %q is not one of %q.", direction, MovementDirections)
+}
+
+// MovementAngleError returns why the angle of a step is invalid, as it is not
+// between 0 and MaxMovementAngle degrees, or an empty string if it is valid.
+func MovementAngleError(angle int64) string {
+	if angle >= 0 && angle <= MaxMovementAngle {
+		return ""
+	}
+
+	return fmt.Sprintf("The angle must be between 0 and %d degrees, got: %d.", MaxMovementAngle, angle)
+}
+
+// MovementStepDistanceRangeError returns why the distance of a step is
+// invalid, as it is not between MinMovementStepDistance and
+// MaxMovementStepDistance meters, or an empty string if it is valid.
+func MovementStepDistanceRangeError(distance float64) string {
+	if distance >= MinMovementStepDistance && distance <= MaxMovementStepDistance {
+		return ""
+	}
+
+	return fmt.Sprintf("The distance must be between %g and %g meters, got: %g.", MinMovementStepDistance, MaxMovementStepDistance, distance)
+}
+
+// MovementStepSpeedError returns why the speed of a step is invalid, as it is
+// not between MinMovementStepSpeed and MaxMovementStepSpeed meters per
+// second, or an empty string if it is valid.
+func MovementStepSpeedError(speed float64) string {
+	if speed >= MinMovementStepSpeed && speed <= MaxMovementStepSpeed {
+		return ""
+	}
+
+	return fmt.Sprintf("The speed must be between %g and %g meters per second, got: %g.", MinMovementStepSpeed, MaxMovementStepSpeed, speed)
+}
+
+// MovementStepWaypointError returns why the named attribute of a step is
+// invalid, as angle, direction, and distance must not be set for a step with
+// a waypoint, and angle and direction must be set for other steps, or an
+// empty string if it is valid.
+func MovementStepWaypointError(name string, hasWaypoint bool, isSet bool) string {
+	if hasWaypoint && isSet {
+		return fmt.Sprintf("The %s attribute must not be set for a step with a waypoint, as it is computed from the waypoint.", name)
+	}
+
+	if !hasWaypoint && !isSet && name != "distance" {
+		return fmt.Sprintf("The %s attribute must be set for a step without a waypoint.", name)
+	}
+
+	return ""
+}
+
+var _ validator.String = movementDirectionValidator{}
+
+// MovementDirection returns a validator that checks that a string is one of
+// MovementDirections.
+func MovementDirection() validator.String {
+	return movementDirectionValidator{}
+}
+
+type movementDirectionValidator struct{}
+
+func (v movementDirectionValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %q", MovementDirections)
+}
+
+func (v movementDirectionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v movementDirectionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if detail := MovementDirectionError(req.ConfigValue.ValueString()); detail != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Movement Direction", detail)
+	}
+}
+
+var _ validator.Int64 = movementAngleValidator{}
+
+// MovementAngle returns a validator that checks that the angle of a movement
+// step is between 0 and MaxMovementAngle degrees.
+func MovementAngle() validator.Int64 {
+	return movementAngleValidator{}
+}
+
+type movementAngleValidator struct{}
+
+func (v movementAngleValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between 0 and %d degrees", MaxMovementAngle)
+}
+
+func (v movementAngleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v movementAngleValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if detail := MovementAngleError(req.ConfigValue.ValueInt64()); detail != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Movement Angle", detail)
+	}
+}
+
+var _ validator.Object = movementStepDistanceValidator{}
+
+// MovementStepDistance returns a validator for the objects of movement steps,
+// which checks that distance is set for linear steps and not set for rotation
+// steps.
+func MovementStepDistance() validator.Object {
+	return movementStepDistanceValidator{}
+}
+
+type movementStepDistanceValidator struct{}
+
+func (v movementStepDistanceValidator) Description(ctx context.Context) string {
+	return "distance must be set for forward and backward steps, and must not be set for left and right steps"
+}
+
+func (v movementStepDistanceValidator) MarkdownDescription(ctx context.Context) string {
+	return "distance must be set for `forward` and `backward` steps, and must not be set for `left` and `right` steps"
+}
+
+func (v movementStepDistanceValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	direction, directionOk := attributes["direction"].(types.String)
+	distance, distanceOk := attributes["distance"].(types.Float64)
+
+	if !directionOk || !distanceOk {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Movement Step",
+			"The movement step must have a direction string and a distance number. "+
+				"Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	// Defer validation until both values are known. Steps without a
+	// direction are validated by MovementStepWaypoint.
+	if direction.IsNull() || direction.IsUnknown() || distance.IsUnknown() {
+		return
+	}
+
+	detail := MovementStepDistanceError(direction.ValueString(), !distance.IsNull())
+	if detail == "" {
+		return
+	}
+
+	summary := "Missing Movement Step Distance"
+	if !distance.IsNull() {
+		summary = "Unexpected Movement Step Distance"
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path.AtName("distance"), summary, detail)
+}
+
+var _ validator.Object = movementStepWaypointValidator{}
+
+// MovementStepWaypoint returns a validator for the objects of movement steps,
+// which checks that a step either references a waypoint, or sets an angle and
+// direction.
+func MovementStepWaypoint() validator.Object {
+	return movementStepWaypointValidator{}
+}
+
+type movementStepWaypointValidator struct{}
+
+func (v movementStepWaypointValidator) Description(ctx context.Context) string {
+	return "angle, direction, and distance must not be set for steps with a waypoint, and angle and direction must be set for other steps"
+}
+
+func (v movementStepWaypointValidator) MarkdownDescription(ctx context.Context) string {
+	return "`angle`, `direction`, and `distance` must not be set for steps with a `waypoint`, and `angle` and `direction` must be set for other steps"
+}
+
+func (v movementStepWaypointValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	waypoint := attributes["waypoint"]
+
+	// Defer validation until it is known whether the step has a waypoint.
+	if waypoint == nil || waypoint.IsUnknown() {
+		return
+	}
+
+	for _, name := range []string{"angle", "direction", "distance"} {
+		value := attributes[name]
+		if value == nil {
+			continue
+		}
+
+		detail := MovementStepWaypointError(name, !waypoint.IsNull(), !value.IsNull())
+		if detail == "" {
+			continue
+		}
+
+		summary := "Missing Movement Step Attribute"
+		if !value.IsNull() {
+			summary = "Unexpected Movement Step Attribute"
+		}
+
+		resp.Diagnostics.AddAttributeError(req.Path.AtName(name), summary, detail)
+	}
+}
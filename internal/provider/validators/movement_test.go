@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMovementDirection(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"forward":  {value: types.StringValue("forward")},
+		"backward": {value: types.StringValue("backward")},
+		"left":     {value: types.StringValue("left")},
+		"right":    {value: types.StringValue("right")},
+		"case":     {value: types.StringValue("Forward"), expectError: true},
+		"unknown direction": {
+			value:       types.StringValue("up"),
+			expectError: true,
+		},
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testValidateString(t, MovementDirection(), testCase.value); got != testCase.expectError {
+				t.Errorf("expected error: %t, got: %t", testCase.expectError, got)
+			}
+		})
+	}
+}
+
+func TestMovementAngle(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.Int64
+		expectError bool
+	}{
+		"zero":      {value: types.Int64Value(0)},
+		"right":     {value: types.Int64Value(90)},
+		"full turn": {value: types.Int64Value(360)},
+		"negative":  {value: types.Int64Value(-90), expectError: true},
+		"too large": {value: types.Int64Value(361), expectError: true},
+		"null":      {value: types.Int64Null()},
+		"unknown":   {value: types.Int64Unknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.Int64Response{}
+			MovementAngle().ValidateInt64(context.Background(), validator.Int64Request{
+				Path:        path.Root("angle"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMovementStepDistance(t *testing.T) {
+	testCases := map[string]struct {
+		direction   string
+		distance    types.Float64
+		expectError bool
+	}{
+		"forward-with-distance":     {direction: "forward", distance: types.Float64Value(1)},
+		"forward-without-distance":  {direction: "forward", distance: types.Float64Null(), expectError: true},
+		"backward-with-distance":    {direction: "backward", distance: types.Float64Value(1)},
+		"backward-without-distance": {direction: "backward", distance: types.Float64Null(), expectError: true},
+		"left-with-distance":        {direction: "left", distance: types.Float64Value(1), expectError: true},
+		"left-without-distance":     {direction: "left", distance: types.Float64Null()},
+		"right-with-distance":       {direction: "right", distance: types.Float64Value(1), expectError: true},
+		"right-without-distance":    {direction: "right", distance: types.Float64Null()},
+		"forward-unknown-distance":  {direction: "forward", distance: types.Float64Unknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			value, diags := types.ObjectValue(
+				map[string]attr.Type{
+					"angle":     types.Int64Type,
+					"direction": types.StringType,
+					"distance":  types.Float64Type,
+					"speed":     types.Float64Type,
+				},
+				map[string]attr.Value{
+					"angle":     types.Int64Value(90),
+					"direction": types.StringValue(testCase.direction),
+					"distance":  testCase.distance,
+					"speed":     types.Float64Null(),
+				},
+			)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &validator.ObjectResponse{}
+			MovementStepDistance().ValidateObject(ctx, validator.ObjectRequest{
+				Path:        path.Root("steps").AtListIndex(0),
+				ConfigValue: value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMovementStepRangeErrors(t *testing.T) {
+	testCases := map[string]struct {
+		detail      string
+		expectError bool
+	}{
+		"min-distance":       {detail: MovementStepDistanceRangeError(MinMovementStepDistance)},
+		"max-distance":       {detail: MovementStepDistanceRangeError(MaxMovementStepDistance)},
+		"distance-too-short": {detail: MovementStepDistanceRangeError(0.5), expectError: true},
+		"distance-too-long":  {detail: MovementStepDistanceRangeError(150), expectError: true},
+		"min-speed":          {detail: MovementStepSpeedError(MinMovementStepSpeed)},
+		"max-speed":          {detail: MovementStepSpeedError(MaxMovementStepSpeed)},
+		"speed-too-slow":     {detail: MovementStepSpeedError(0.05), expectError: true},
+		"speed-too-fast":     {detail: MovementStepSpeedError(3), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.detail != ""; got != testCase.expectError {
+				t.Errorf("expected error: %t, got: %q", testCase.expectError, testCase.detail)
+			}
+		})
+	}
+}
+
+func TestMovementStepWaypoint(t *testing.T) {
+	testCases := map[string]struct {
+		angle       types.Int64
+		direction   types.String
+		distance    types.Float64
+		waypoint    types.String
+		expectError bool
+	}{
+		"step": {
+			angle:     types.Int64Value(0),
+			direction: types.StringValue("forward"),
+			distance:  types.Float64Value(1),
+			waypoint:  types.StringNull(),
+		},
+		"waypoint": {
+			angle:     types.Int64Null(),
+			direction: types.StringNull(),
+			distance:  types.Float64Null(),
+			waypoint:  types.StringValue("dock"),
+		},
+		"waypoint with direction": {
+			angle:       types.Int64Null(),
+			direction:   types.StringValue("forward"),
+			distance:    types.Float64Null(),
+			waypoint:    types.StringValue("dock"),
+			expectError: true,
+		},
+		"waypoint with distance": {
+			angle:       types.Int64Null(),
+			direction:   types.StringNull(),
+			distance:    types.Float64Value(1),
+			waypoint:    types.StringValue("dock"),
+			expectError: true,
+		},
+		"missing angle": {
+			angle:       types.Int64Null(),
+			direction:   types.StringValue("forward"),
+			distance:    types.Float64Value(1),
+			waypoint:    types.StringNull(),
+			expectError: true,
+		},
+		"missing direction": {
+			angle:       types.Int64Value(90),
+			direction:   types.StringNull(),
+			distance:    types.Float64Null(),
+			waypoint:    types.StringNull(),
+			expectError: true,
+		},
+		"unknown waypoint": {
+			angle:     types.Int64Null(),
+			direction: types.StringValue("forward"),
+			distance:  types.Float64Null(),
+			waypoint:  types.StringUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			value, diags := types.ObjectValue(
+				map[string]attr.Type{
+					"angle":     types.Int64Type,
+					"direction": types.StringType,
+					"distance":  types.Float64Type,
+					"waypoint":  types.StringType,
+				},
+				map[string]attr.Value{
+					"angle":     testCase.angle,
+					"direction": testCase.direction,
+					"distance":  testCase.distance,
+					"waypoint":  testCase.waypoint,
+				},
+			)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &validator.ObjectResponse{}
+			MovementStepWaypoint().ValidateObject(ctx, validator.ObjectRequest{
+				Path:        path.Root("steps").AtListIndex(0),
+				ConfigValue: value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = proxyURLValidator{}
+
+// ProxyURL returns a validator that checks that a string is the absolute URL
+// of a proxy, such as "http://proxy.example.com:3128".
+func ProxyURL() validator.String {
+	return proxyURLValidator{}
+}
+
+type proxyURLValidator struct{}
+
+func (v proxyURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http, https, or socks5 URL, such as http://proxy.example.com:3128"
+}
+
+func (v proxyURLValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an absolute `http`, `https`, or `socks5` URL, such as `http://proxy.example.com:3128`"
+}
+
+func (v proxyURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	proxyURL, err := url.Parse(req.ConfigValue.ValueString())
+	if err != nil || proxyURL.Host == "" || !slices.Contains([]string{"http", "https", "socks5"}, proxyURL.Scheme) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Proxy URL",
+			fmt.Sprintf("The value %q must be an absolute http, https, or socks5 URL, such as http://proxy.example.com:3128.", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = rfc3339Validator{}
+
+// RFC3339 returns a validator that checks that a string is a timestamp in
+// RFC 3339 format, such as "2024-01-02T15:04:05Z".
+func RFC3339() validator.String {
+	return rfc3339Validator{}
+}
+
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp, such as 2024-01-02T15:04:05Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp, such as `2024-01-02T15:04:05Z`"
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("The value %q must be an RFC 3339 timestamp, such as 2024-01-02T15:04:05Z.", req.ConfigValue.ValueString()),
+		)
+	}
+}
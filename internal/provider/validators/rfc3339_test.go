@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRFC3339(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"utc":       {value: types.StringValue("2024-01-02T15:04:05Z")},
+		"offset":    {value: types.StringValue("2024-01-02T15:04:05+01:00")},
+		"fraction":  {value: types.StringValue("2024-01-02T15:04:05.123Z")},
+		"date only": {value: types.StringValue("2024-01-02"), expectError: true},
+		"no zone":   {value: types.StringValue("2024-01-02T15:04:05"), expectError: true},
+		"invalid":   {value: types.StringValue("tomorrow"), expectError: true},
+		"null":      {value: types.StringNull()},
+		"unknown":   {value: types.StringUnknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testValidateString(t, RFC3339(), testCase.value); got != testCase.expectError {
+				t.Errorf("expected error: %t, got: %t", testCase.expectError, got)
+			}
+		})
+	}
+}
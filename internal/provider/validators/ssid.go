@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// maxSSIDBytes is the maximum length of an SSID, as defined by IEEE 802.11.
+const maxSSIDBytes = 32
+
+var _ validator.String = ssidValidator{}
+
+// SSID returns a validator that checks that a string is a valid Service Set
+// Identifier (SSID) of a WiFi network, which is 1 to 32 bytes long.
+func SSID() validator.String {
+	return ssidValidator{}
+}
+
+type ssidValidator struct{}
+
+func (v ssidValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be an SSID of 1 to %d bytes", maxSSIDBytes)
+}
+
+func (v ssidValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ssidValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The limit applies to the encoded SSID, so multi-byte characters count
+	// for each of their bytes.
+	if length := len(req.ConfigValue.ValueString()); length == 0 || length > maxSSIDBytes {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SSID",
+			fmt.Sprintf("The value %q is %d bytes long, an SSID must be 1 to %d bytes long.", req.ConfigValue.ValueString(), length, maxSSIDBytes),
+		)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSSID(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"short":      {value: types.StringValue("a")},
+		"spaces":     {value: types.StringValue("Home Network")},
+		"max length": {value: types.StringValue(strings.Repeat("a", 32))},
+		"empty":      {value: types.StringValue(""), expectError: true},
+		"too long":   {value: types.StringValue(strings.Repeat("a", 33)), expectError: true},
+		// Each of these characters is encoded as 3 bytes.
+		"multi-byte": {value: types.StringValue(strings.Repeat("日", 11)), expectError: true},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testValidateString(t, SSID(), testCase.value); got != testCase.expectError {
+				t.Errorf("expected error: %t, got: %t", testCase.expectError, got)
+			}
+		})
+	}
+}
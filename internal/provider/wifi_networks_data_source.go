@@ -4,19 +4,19 @@
 package provider
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"slices"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -33,7 +33,9 @@ type WifiNetworksDataSource struct {
 
 // WifiNetworksDataSourceModel describes the data source data model.
 type WifiNetworksDataSourceModel struct {
-	Networks []WifiNetworkModel `tfsdk:"networks"`
+	SortByRssi types.Bool         `tfsdk:"sort_by_rssi"`
+	Descending types.Bool         `tfsdk:"descending"`
+	Networks   []WifiNetworkModel `tfsdk:"networks"`
 }
 
 type WifiNetworkModel struct {
@@ -52,6 +54,19 @@ func (d *WifiNetworksDataSource) Schema(ctx context.Context, req datasource.Sche
 		MarkdownDescription: "Get information about the available WiFi networks.",
 
 		Attributes: map[string]schema.Attribute{
+			"sort_by_rssi": schema.BoolAttribute{
+				MarkdownDescription: "Sort `networks` by `rssi`, so that the strongest network is first. Networks with the same " +
+					"`rssi` keep the order returned by the device. Defaults to `false`, which keeps the order returned by the device.",
+				Optional: true,
+			},
+			"descending": schema.BoolAttribute{
+				MarkdownDescription: "Sort `networks` from the strongest to the weakest network when `true`, and from the weakest " +
+					"to the strongest network when `false`. Requires `sort_by_rssi`. Defaults to `true`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("sort_by_rssi")),
+				},
+			},
 			"networks": schema.ListNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -102,31 +117,8 @@ func (d *WifiNetworksDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/device/wifi", d.client.Config.Address),
-		io.NopCloser(strings.NewReader("")),
-	)
-
-	// Example of setting a custom header, such as an API key
-	// httpReq.Header.Set("x-api-key", d.client.Config.ApiKey)
-
-	ctx = tflog.SetField(ctx, "endpoint", httpReq.URL.String())
-	ctx = tflog.SetField(ctx, "method", httpReq.Method)
-	tflog.Debug(ctx, fmt.Sprintf("Sending %s request to: %s", httpReq.Method, httpReq.URL.String()))
-
-	if err != nil {
-		// handle error
-		fmt.Println("Error creating request:", err)
-		return
-	}
-
-	httpResp, err := d.client.HttpClient.Do(httpReq)
-	defer httpReq.Body.Close()
-
-	tflog.Debug(ctx, fmt.Sprintf("Received response %v", httpResp))
+	var readResp []model.WifiNetworkItem
+	err := d.client.GetJSON(ctx, "/v1/device/wifi", &readResp)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -139,40 +131,35 @@ func (d *WifiNetworksDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Treat HTTP 404 Not Found status as a signal to recreate resource
-	// and return early
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-
-		return
-	}
-
-	var readResp []model.WifiNetworkItem
-	err = json.NewDecoder(httpResp.Body).Decode(&readResp)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Refresh Resource",
-			"An unexpected error occurred while parsing the resource read response. "+
-				"Please report this issue to the provider developers.\n\n"+
-				"JSON Error: "+err.Error(),
-		)
-
-		return
-	}
-
 	// Iterate over the response and convert it to the model
 	var networks = make([]WifiNetworkModel, len(readResp))
 	for i := range readResp {
 		networks[i] = WifiNetworkModel{
 			Encrypted: types.BoolValue(readResp[i].Encrypted),
-			Rssi:      types.Float64Value(readResp[i].Rssi),
+			Rssi:      types.Float64Value(float64(readResp[i].Rssi)),
 			Ssid:      types.StringValue(readResp[i].Ssid),
 		}
 	}
 
+	if data.SortByRssi.ValueBool() {
+		sortWifiNetworksByRssi(networks, data.Descending.IsNull() || data.Descending.ValueBool())
+	}
+
 	data.Networks = networks
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// sortWifiNetworksByRssi sorts the networks by RSSI, from the strongest to the
+// weakest network when descending. Networks with the same RSSI keep their
+// order.
+func sortWifiNetworksByRssi(networks []WifiNetworkModel, descending bool) {
+	slices.SortStableFunc(networks, func(a, b WifiNetworkModel) int {
+		if descending {
+			return cmp.Compare(b.Rssi.ValueFloat64(), a.Rssi.ValueFloat64())
+		}
+
+		return cmp.Compare(a.Rssi.ValueFloat64(), b.Rssi.ValueFloat64())
+	})
+}
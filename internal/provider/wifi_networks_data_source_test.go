@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWifiNetworksDataSource_Read_sortByRssi(t *testing.T) {
+	// The guest and lab networks have the same RSSI, so their order is kept.
+	body := `[{"ssid":"guest","rssi":-70,"encrypted":false},{"ssid":"office","rssi":-40,"encrypted":true},` +
+		`{"ssid":"lab","rssi":-70,"encrypted":true},{"ssid":"far","rssi":-90,"encrypted":true}]`
+
+	testCases := map[string]struct {
+		sortByRssi types.Bool
+		descending types.Bool
+		expected   []string
+	}{
+		"unsorted": {
+			expected: []string{"guest", "office", "lab", "far"},
+		},
+		"not-sorted": {
+			sortByRssi: types.BoolValue(false),
+			expected:   []string{"guest", "office", "lab", "far"},
+		},
+		"strongest-first": {
+			sortByRssi: types.BoolValue(true),
+			expected:   []string{"office", "guest", "lab", "far"},
+		},
+		"descending": {
+			sortByRssi: types.BoolValue(true),
+			descending: types.BoolValue(true),
+			expected:   []string{"office", "guest", "lab", "far"},
+		},
+		"ascending": {
+			sortByRssi: types.BoolValue(true),
+			descending: types.BoolValue(false),
+			expected:   []string{"far", "guest", "lab", "office"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &clients.Client{HttpClient: &testDoer{responses: map[string]string{"GET /v1/device/wifi": body}}}
+
+			config := &WifiNetworksDataSourceModel{SortByRssi: testCase.sortByRssi, Descending: testCase.descending}
+			resp := testReadDataSource(t, NewWifiNetworksDataSource(), client, config)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data WifiNetworksDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			ssids := make([]string, len(data.Networks))
+			for i, network := range data.Networks {
+				ssids[i] = network.Ssid.ValueString()
+			}
+
+			if !slices.Equal(ssids, testCase.expected) {
+				t.Errorf("expected networks %v, got %v", testCase.expected, ssids)
+			}
+		})
+	}
+}
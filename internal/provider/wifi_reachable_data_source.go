@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/clients/model"
+	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WifiReachableDataSource{}
+
+func NewWifiReachableDataSource() datasource.DataSource {
+	return &WifiReachableDataSource{}
+}
+
+// WifiReachableDataSource defines the data source implementation.
+type WifiReachableDataSource struct {
+	client *clients.Client
+}
+
+// WifiReachableDataSourceModel describes the data source data model.
+type WifiReachableDataSourceModel struct {
+	Ssid      types.String  `tfsdk:"ssid"`
+	Reachable types.Bool    `tfsdk:"reachable"`
+	Rssi      types.Float64 `tfsdk:"rssi"`
+}
+
+func (d *WifiReachableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wifi_reachable"
+}
+
+func (d *WifiReachableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Check whether the device can see a WiFi network, for example to gate other resources with a `precondition`.",
+
+		Attributes: map[string]schema.Attribute{
+			"ssid": schema.StringAttribute{
+				MarkdownDescription: "Service Set Identifier (SSID) of the network, 1 to 32 bytes long.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.SSID(),
+				},
+			},
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if the network is in range of the device.",
+				Computed:            true,
+			},
+			"rssi": schema.Float64Attribute{
+				MarkdownDescription: "Received Signal Strength Indicator (RSSI) of the network (in dBm). " +
+					"The strongest signal is used when several access points share the SSID. Null if the network is not in range.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *WifiReachableDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *WifiReachableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WifiReachableDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var readResp []model.WifiNetworkItem
+	err := d.client.GetJSON(ctx, "/v1/device/wifi", &readResp)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Refresh Resource",
+			"An unexpected error occurred while attempting to refresh resource state. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"HTTP Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	data.Reachable = types.BoolValue(false)
+	data.Rssi = types.Float64Null()
+
+	for _, network := range readResp {
+		if network.Ssid != data.Ssid.ValueString() {
+			continue
+		}
+
+		if !data.Reachable.ValueBool() || float64(network.Rssi) > data.Rssi.ValueFloat64() {
+			data.Rssi = types.Float64Value(float64(network.Rssi))
+		}
+
+		data.Reachable = types.BoolValue(true)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
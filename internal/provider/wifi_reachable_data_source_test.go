@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWifiReachableDataSource_Read(t *testing.T) {
+	testCases := map[string]struct {
+		body              string
+		expectedReachable types.Bool
+		expectedRssi      types.Float64
+	}{
+		"in-range": {
+			body:              `[{"ssid":"lab","rssi":-60,"encrypted":true},{"ssid":"guest","rssi":-40,"encrypted":false}]`,
+			expectedReachable: types.BoolValue(true),
+			expectedRssi:      types.Float64Value(-60),
+		},
+		"strongest-access-point": {
+			body:              `[{"ssid":"lab","rssi":-80,"encrypted":true},{"ssid":"lab","rssi":-50,"encrypted":true},{"ssid":"lab","rssi":-70,"encrypted":true}]`,
+			expectedReachable: types.BoolValue(true),
+			expectedRssi:      types.Float64Value(-50),
+		},
+		"not-in-range": {
+			body:              `[{"ssid":"guest","rssi":-40,"encrypted":false}]`,
+			expectedReachable: types.BoolValue(false),
+			expectedRssi:      types.Float64Null(),
+		},
+		"no-networks": {
+			body:              `[]`,
+			expectedReachable: types.BoolValue(false),
+			expectedRssi:      types.Float64Null(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/device/wifi" {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			config := &WifiReachableDataSourceModel{Ssid: types.StringValue("lab")}
+			resp := testReadDataSource(t, NewWifiReachableDataSource(), testClient(t, server), config)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data WifiReachableDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if !data.Reachable.Equal(testCase.expectedReachable) {
+				t.Errorf("expected reachable %s, got %s", testCase.expectedReachable, data.Reachable)
+			}
+
+			if !data.Rssi.Equal(testCase.expectedRssi) {
+				t.Errorf("expected rssi %s, got %s", testCase.expectedRssi, data.Rssi)
+			}
+		})
+	}
+}
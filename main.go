@@ -6,9 +6,11 @@ package main
 import (
 	"context"
 	"flag"
+	"io"
 	"log"
 
 	"github.com/hashicorp-dev-advocates/terraform-provider-pathfinder/internal/provider"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -32,7 +34,14 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	p := provider.New(version)()
+
+	err := providerserver.Serve(context.Background(), func() fwprovider.Provider { return p }, opts)
+
+	// Push the metrics counted since the last push before the provider exits
+	if closer, ok := p.(io.Closer); ok {
+		_ = closer.Close()
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())